@@ -0,0 +1,76 @@
+// Package bot 提供机器人玩家框架
+//
+// Agent 是玩家代理的抽象：接收按迷雾过滤后的事件和消息，轮到行动时根据自己的视角决策。
+// Driver 负责把代理接入 Engine：分发可见事件、按座位号提示行动玩家并提交动作。
+// StrategyAgent 按角色委托给可插拔的 Strategy，DefaultStrategies 提供各角色的基础策略。
+package bot
+
+import (
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// Agent 玩家代理
+// 所有输入都已按迷雾规则过滤，代理只能看到自己有权知道的信息
+type Agent interface {
+	// OnEvent 收到自己可见的事件
+	OnEvent(event *pb.Event)
+	// OnMessage 收到发给自己的消息
+	OnMessage(msg *werewolf.Message)
+	// Act 轮到自己行动时决策，返回要执行的动作（可以为空表示放弃）
+	Act(turn *Turn) []Action
+}
+
+// Retrier 可选接口：动作被引擎拒绝时由驱动器回调
+// 返回替代动作重试，返回空表示放弃；重试次数由 Driver.SetMaxRetries 限制
+type Retrier interface {
+	Retry(turn *Turn, rejected Action, err error) []Action
+}
+
+// Turn 一次行动提示
+type Turn struct {
+	PlayerID string
+	Phase    pb.PhaseType
+	Round    int
+	View     *pb.PlayerView          // 玩家视角（迷雾过滤后）
+	Info     *werewolf.RolePhaseInfo // 自己所属角色的阶段信息（如狼人队友、女巫刀口）
+}
+
+// CanUse 当前是否可以使用该技能
+func (t *Turn) CanUse(skill pb.SkillType) bool {
+	for _, s := range t.View.GetAllowedSkills() {
+		if s == skill {
+			return true
+		}
+	}
+	return false
+}
+
+// AlivePlayers 存活玩家（不含自己，按座位号排序）
+func (t *Turn) AlivePlayers() []string {
+	var ids []string
+	for _, p := range t.View.GetPlayers() {
+		if p.GetAlive() && p.GetId() != t.PlayerID {
+			ids = append(ids, p.GetId())
+		}
+	}
+	return ids
+}
+
+// Action 代理动作
+// Skill 为 SKILL_TYPE_SPEAK 时发送消息（白天发言或狼人夜间交流），其余技能提交给引擎
+type Action struct {
+	Skill   pb.SkillType
+	Target  string
+	Content string
+}
+
+// Speak 发言动作
+func Speak(content string) Action {
+	return Action{Skill: pb.SkillType_SKILL_TYPE_SPEAK, Content: content}
+}
+
+// Use 技能动作
+func Use(skill pb.SkillType, target string) Action {
+	return Action{Skill: skill, Target: target}
+}
@@ -0,0 +1,235 @@
+package bot
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// DefaultMaxRetries 每次提示中动作被拒绝后的默认最大重试次数
+const DefaultMaxRetries = 2
+
+// RejectHandler 引擎拒绝代理动作时的回调
+type RejectHandler func(playerID string, action Action, err error)
+
+// Driver 将代理接入引擎
+// 创建时注册事件和消息回调，按迷雾规则只把可见的事件和发给该玩家的消息转发给代理
+type Driver struct {
+	engine *werewolf.Engine
+
+	mu         sync.RWMutex
+	agents     map[string]Agent
+	onReject   RejectHandler
+	maxRetries int
+}
+
+// NewDriver 创建驱动器
+func NewDriver(engine *werewolf.Engine) *Driver {
+	d := &Driver{
+		engine:     engine,
+		agents:     make(map[string]Agent),
+		maxRetries: DefaultMaxRetries,
+	}
+	engine.OnEvent(d.dispatchEvent)
+	engine.OnMessage(d.dispatchMessage)
+	return d
+}
+
+// Add 为玩家指定代理（覆盖已有代理）
+func (d *Driver) Add(playerID string, agent Agent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.agents[playerID] = agent
+}
+
+// Agent 获取玩家的代理
+func (d *Driver) Agent(playerID string) (Agent, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	agent, ok := d.agents[playerID]
+	return agent, ok
+}
+
+// OnReject 设置动作被拒绝时的回调（默认忽略，机器人的非法动作不影响对局）
+func (d *Driver) OnReject(handler RejectHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onReject = handler
+}
+
+// SetMaxRetries 设置每次提示中动作被拒绝后的最大重试次数（仅对实现 Retrier 的代理生效）
+func (d *Driver) SetMaxRetries(n int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.maxRetries = n
+}
+
+// Prompt 提示单个玩家的代理行动并提交动作
+// 玩家没有代理或当前没有可用技能时不做任何事
+func (d *Driver) Prompt(playerID string) error {
+	agent, ok := d.Agent(playerID)
+	if !ok {
+		return nil
+	}
+
+	view, err := d.engine.GetPlayerView(playerID)
+	if err != nil {
+		return err
+	}
+	if len(view.GetAllowedSkills()) == 0 {
+		return nil
+	}
+
+	turn := &Turn{
+		PlayerID: playerID,
+		Phase:    view.GetPhase(),
+		Round:    int(view.GetRound()),
+		View:     view,
+		Info:     roleInfoFor(d.engine.GetPhaseInfo(), view.GetRole()),
+	}
+
+	d.mu.RLock()
+	maxRetries := d.maxRetries
+	d.mu.RUnlock()
+
+	retrier, canRetry := agent.(Retrier)
+	actions := agent.Act(turn)
+	for retries := 0; len(actions) > 0; {
+		action := actions[0]
+		actions = actions[1:]
+
+		err := d.submit(playerID, action)
+		if err == nil {
+			continue
+		}
+		d.reject(playerID, action, err)
+
+		// 被拒绝的动作由代理给出替代动作，插在剩余动作之前
+		if canRetry && retries < maxRetries {
+			retries++
+			actions = append(retrier.Retry(turn, action, err), actions...)
+		}
+	}
+	return nil
+}
+
+// PlayPhase 按座位号提示当前阶段所有行动玩家的代理
+func (d *Driver) PlayPhase() error {
+	for _, id := range Actors(d.engine) {
+		if err := d.Prompt(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run 驱动对局直到结束（每个阶段先提示代理，再结束阶段）
+// 没有代理的玩家不会行动；ctx 取消时返回 ctx.Err()
+func (d *Driver) Run(ctx context.Context) error {
+	for !d.engine.IsGameOver() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := d.PlayPhase(); err != nil {
+			return err
+		}
+		if _, err := d.engine.EndSubStep(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Actors 当前阶段需要行动的玩家（按座位号排序）
+func Actors(engine *werewolf.Engine) []string {
+	info := engine.GetPhaseInfo()
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, role := range info.ActiveRoles {
+		roleInfo := info.RoleInfos[role]
+		if roleInfo == nil {
+			continue
+		}
+		for _, id := range roleInfo.PlayerIDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	seats := make(map[string]int, len(ids))
+	for _, id := range ids {
+		info, _ := engine.GetPlayerInfo(id)
+		seats[id] = info.Seat
+	}
+	sort.Slice(ids, func(i, j int) bool { return seats[ids[i]] < seats[ids[j]] })
+	return ids
+}
+
+// submit 提交单个动作
+func (d *Driver) submit(playerID string, action Action) error {
+	if action.Skill == pb.SkillType_SKILL_TYPE_SPEAK {
+		return d.engine.SendMessage(playerID, action.Content)
+	}
+	return d.engine.SubmitSkillUse(&werewolf.SkillUse{
+		PlayerID: playerID,
+		Skill:    action.Skill,
+		TargetID: action.Target,
+		Content:  action.Content,
+	})
+}
+
+// reject 通知动作被拒绝
+func (d *Driver) reject(playerID string, action Action, err error) {
+	d.mu.RLock()
+	handler := d.onReject
+	d.mu.RUnlock()
+
+	if handler != nil {
+		handler(playerID, action, err)
+	}
+}
+
+// snapshotAgents 复制代理列表，避免回调时持有锁
+func (d *Driver) snapshotAgents() map[string]Agent {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	agents := make(map[string]Agent, len(d.agents))
+	for id, agent := range d.agents {
+		agents[id] = agent
+	}
+	return agents
+}
+
+// dispatchEvent 按迷雾规则分发事件
+func (d *Driver) dispatchEvent(event *pb.Event) {
+	for id, agent := range d.snapshotAgents() {
+		if d.engine.IsEventVisibleTo(event, id) {
+			agent.OnEvent(event)
+		}
+	}
+}
+
+// dispatchMessage 只把消息分发给接收者
+func (d *Driver) dispatchMessage(msg *werewolf.Message, receiverIDs []string) {
+	agents := d.snapshotAgents()
+	for _, id := range receiverIDs {
+		if agent, ok := agents[id]; ok {
+			agent.OnMessage(msg)
+		}
+	}
+}
+
+// roleInfoFor 获取角色对应的阶段信息（白天和投票阶段所有玩家共用）
+func roleInfoFor(info *werewolf.PhaseInfo, role pb.RoleType) *werewolf.RolePhaseInfo {
+	if roleInfo, ok := info.RoleInfos[role]; ok {
+		return roleInfo
+	}
+	return info.RoleInfos[pb.RoleType_ROLE_TYPE_UNSPECIFIED]
+}
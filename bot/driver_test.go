@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// recordingAgent 记录收到的事件、消息和做出的动作
+type recordingAgent struct {
+	*StrategyAgent
+
+	events   []*pb.Event
+	messages []*werewolf.Message
+	actions  []Action
+}
+
+func (a *recordingAgent) OnEvent(event *pb.Event) {
+	a.events = append(a.events, event)
+	a.StrategyAgent.OnEvent(event)
+}
+
+func (a *recordingAgent) OnMessage(msg *werewolf.Message) {
+	a.messages = append(a.messages, msg)
+	a.StrategyAgent.OnMessage(msg)
+}
+
+func (a *recordingAgent) Act(turn *Turn) []Action {
+	actions := a.StrategyAgent.Act(turn)
+	a.actions = append(a.actions, actions...)
+	return actions
+}
+
+// newBotGame 通过大厅入座并开局，每个座位都由记录代理控制
+func newBotGame(t *testing.T, boardName string, seed int64) (*werewolf.Engine, *Driver, map[string]*recordingAgent) {
+	t.Helper()
+
+	board, ok := werewolf.LookupBoard(boardName)
+	if !ok {
+		t.Fatalf("unknown board %s", boardName)
+	}
+
+	engine, err := werewolf.NewEngine(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	engine.SetSeed(seed)
+	driver := NewDriver(engine)
+	if err := engine.SetBoard("", board); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	agents := make(map[string]*recordingAgent)
+	for seat := 1; seat <= board.Size(); seat++ {
+		id := fmt.Sprintf("p%d", seat)
+		engine.Join(id)
+		engine.SetReady(id, true)
+		agents[id] = &recordingAgent{StrategyAgent: NewStrategyAgent(id, seed+int64(seat))}
+		driver.Add(id, agents[id])
+	}
+
+	if err := engine.Start(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return engine, driver, agents
+}
+
+// advanceTo 驱动对局直到进入指定阶段
+func advanceTo(t *testing.T, engine *werewolf.Engine, driver *Driver, phase pb.PhaseType) {
+	t.Helper()
+
+	for engine.GetCurrentPhase() != phase {
+		if engine.IsGameOver() {
+			t.Fatalf("game ended before reaching %v", phase)
+		}
+		driver.PlayPhase()
+		engine.EndSubStep()
+	}
+}
+
+func TestDriver_RunFinishesPresetBoards(t *testing.T) {
+	for _, board := range werewolf.PresetBoards() {
+		for seed := int64(1); seed <= 10; seed++ {
+			engine, driver, _ := newBotGame(t, board.Name, seed)
+
+			if err := driver.Run(context.Background()); err != nil {
+				t.Fatalf("%s seed %d: expected no error, got %v", board.Name, seed, err)
+			}
+			if !engine.IsGameOver() {
+				t.Errorf("%s seed %d: expected game over", board.Name, seed)
+			}
+		}
+	}
+}
+
+func TestDriver_RunCancelled(t *testing.T) {
+	_, driver, _ := newBotGame(t, werewolf.BoardSixPlayers, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := driver.Run(ctx); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestDriver_FogOfWar(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		engine, driver, agents := newBotGame(t, werewolf.BoardTwelvePlayers, seed)
+		if err := driver.Run(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for id, agent := range agents {
+			for _, event := range agent.events {
+				switch event.Type {
+				case pb.EventType_EVENT_TYPE_PROTECT,
+					pb.EventType_EVENT_TYPE_SAVE,
+					pb.EventType_EVENT_TYPE_CHECK:
+					if event.SourceId != id {
+						t.Errorf("%s received private %v event of %s", id, event.Type, event.SourceId)
+					}
+				case pb.EventType_EVENT_TYPE_POISON:
+					if event.SourceId != id {
+						t.Errorf("%s received poison event of %q", id, event.SourceId)
+					}
+				case pb.EventType_EVENT_TYPE_KILL:
+					t.Errorf("%s received night kill of %s", id, event.TargetId)
+				}
+			}
+
+			info, _ := engine.GetPlayerInfo(id)
+			for _, msg := range agent.messages {
+				if msg.Phase == pb.PhaseType_PHASE_TYPE_NIGHT_WOLF && info.Role != pb.RoleType_ROLE_TYPE_WEREWOLF {
+					t.Errorf("%s (%v) received wolf chat %q", id, info.Role, msg.Content)
+				}
+			}
+		}
+	}
+}
+
+func TestDriver_PromptWithoutAgent(t *testing.T) {
+	engine, err := werewolf.NewEngine(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	engine.AddPlayer("p1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("p2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	driver := NewDriver(engine)
+	if err := driver.Prompt("p1"); err != nil {
+		t.Errorf("expected no error for player without agent, got %v", err)
+	}
+	if _, ok := driver.Agent("p1"); ok {
+		t.Error("expected no agent for p1")
+	}
+}
+
+func TestDriver_OnReject(t *testing.T) {
+	engine, err := werewolf.NewEngine(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	engine.AddPlayer("p1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("p2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("p3", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	driver := NewDriver(engine)
+	agent := NewStrategyAgent("p1", 1)
+	agent.SetStrategy(pb.RoleType_ROLE_TYPE_WEREWOLF, StrategyFunc(func(*Turn, *Memory, *rand.Rand) []Action {
+		return []Action{Use(pb.SkillType_SKILL_TYPE_KILL, "nobody")}
+	}))
+	driver.Add("p1", agent)
+
+	var rejected []Action
+	driver.OnReject(func(playerID string, action Action, err error) {
+		if playerID != "p1" || err == nil {
+			t.Errorf("unexpected rejection %s: %v", playerID, err)
+		}
+		rejected = append(rejected, action)
+	})
+
+	advanceTo(t, engine, driver, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	if err := driver.PlayPhase(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(rejected) != 1 || rejected[0].Target != "nobody" {
+		t.Errorf("expected the invalid kill to be rejected, got %+v", rejected)
+	}
+}
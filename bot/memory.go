@@ -0,0 +1,216 @@
+package bot
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// 机器人之间约定的发言格式（人类玩家也可以按此格式发言让机器人理解）
+const (
+	seerClaimFormat = "我是预言家，%s 是%s"
+	wolfKillFormat  = "今晚刀 %s"
+
+	verdictWolf = "狼人"
+	verdictGood = "好人"
+)
+
+var (
+	seerClaimPattern = regexp.MustCompile(`^我是预言家，(\S+) 是(狼人|好人)$`)
+	wolfKillPattern  = regexp.MustCompile(`^今晚刀 (\S+)$`)
+)
+
+// SeerClaim 预言家报查验的发言
+func SeerClaim(target string, isWolf bool) string {
+	verdict := verdictGood
+	if isWolf {
+		verdict = verdictWolf
+	}
+	return fmt.Sprintf(seerClaimFormat, target, verdict)
+}
+
+// WolfKillProposal 狼人夜间提议刀口的发言
+func WolfKillProposal(target string) string {
+	return fmt.Sprintf(wolfKillFormat, target)
+}
+
+// Claim 公开的查验声明
+type Claim struct {
+	SpeakerID string
+	TargetID  string
+	IsWolf    bool
+}
+
+// Memory 代理记忆
+// 只由代理收到的事件和消息构建，因此天然遵守迷雾规则
+type Memory struct {
+	mu sync.RWMutex
+
+	playerID      string
+	checks        map[string]bool // 自己的查验结果：玩家ID -> 是否狼人
+	claims        []Claim         // 白天听到的查验声明
+	wolfProposals map[int]string  // 回合 -> 狼队刀口提议
+	dead          map[string]bool // 已知出局的玩家
+	lastProtected string          // 自己上一次守护的目标
+	reported      map[string]bool // 已经公开报过的查验
+	rules         werewolf.Rules  // 当前规则变体（默认规则，房主更改时更新）
+}
+
+// NewMemory 创建代理记忆
+func NewMemory(playerID string) *Memory {
+	return &Memory{
+		playerID:      playerID,
+		checks:        make(map[string]bool),
+		wolfProposals: make(map[int]string),
+		dead:          make(map[string]bool),
+		reported:      make(map[string]bool),
+		rules:         werewolf.DefaultGameConfig().GetRules(),
+	}
+}
+
+// PlayerID 记忆所属玩家
+func (m *Memory) PlayerID() string {
+	return m.playerID
+}
+
+// Observe 记录事件
+func (m *Memory) Observe(event *pb.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch event.Type {
+	case pb.EventType_EVENT_TYPE_CHECK:
+		if event.SourceId == m.playerID {
+			m.checks[event.TargetId] = !event.GetCheck().GetIsGood()
+		}
+	case pb.EventType_EVENT_TYPE_PROTECT:
+		if event.SourceId == m.playerID {
+			m.lastProtected = event.TargetId
+		}
+	case pb.EventType_EVENT_TYPE_NIGHT_SUMMARY:
+		for _, id := range event.GetNightSummary().GetDeadPlayerIds() {
+			m.dead[id] = true
+		}
+	case pb.EventType_EVENT_TYPE_ELIMINATE,
+		pb.EventType_EVENT_TYPE_SHOOT:
+		m.dead[event.TargetId] = true
+	case pb.EventType_EVENT_TYPE_RULES_CHANGED:
+		tiePolicy, _ := werewolf.ParseWolfTiePolicy(event.Data["wolf_tie_policy"])
+		m.rules = werewolf.Rules{
+			WitchCanSaveSelf:     event.Data["witch_can_save_self"] == "true",
+			GuardCanProtectSelf:  event.Data["guard_can_protect_self"] == "true",
+			GuardCanRepeat:       event.Data["guard_can_repeat"] == "true",
+			SameGuardKillIsEmpty: event.Data["same_guard_kill_is_empty"] == "true",
+			WolfKillUnanimous:    event.Data["wolf_kill_unanimous"] == "true",
+			WolfTiePolicy:        tiePolicy,
+		}
+	}
+}
+
+// Hear 记录消息
+func (m *Memory) Hear(msg *werewolf.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch msg.Phase {
+	case pb.PhaseType_PHASE_TYPE_DAY:
+		if match := seerClaimPattern.FindStringSubmatch(msg.Content); match != nil {
+			m.claims = append(m.claims, Claim{
+				SpeakerID: msg.SenderID,
+				TargetID:  match[1],
+				IsWolf:    match[2] == verdictWolf,
+			})
+		}
+	case pb.PhaseType_PHASE_TYPE_NIGHT_WOLF:
+		if match := wolfKillPattern.FindStringSubmatch(msg.Content); match != nil {
+			// 以第一个提议为准，后发言的狼人跟刀
+			if _, ok := m.wolfProposals[msg.Round]; !ok {
+				m.wolfProposals[msg.Round] = match[1]
+			}
+		}
+	}
+}
+
+// Checks 自己的查验结果（玩家ID -> 是否狼人）
+func (m *Memory) Checks() map[string]bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	checks := make(map[string]bool, len(m.checks))
+	for id, isWolf := range m.checks {
+		checks[id] = isWolf
+	}
+	return checks
+}
+
+// Claims 白天听到的查验声明
+func (m *Memory) Claims() []Claim {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Claim(nil), m.claims...)
+}
+
+// WolfProposal 本回合狼队的刀口提议
+func (m *Memory) WolfProposal(round int) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	target, ok := m.wolfProposals[round]
+	return target, ok
+}
+
+// IsDead 是否已知该玩家出局
+func (m *Memory) IsDead(playerID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.dead[playerID]
+}
+
+// Rules 当前规则变体
+func (m *Memory) Rules() werewolf.Rules {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.rules
+}
+
+// LastProtected 自己上一次守护的目标
+func (m *Memory) LastProtected() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.lastProtected
+}
+
+// Unreported 尚未公开报过的查验（报出后标记为已报）
+func (m *Memory) Unreported() []Claim {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var claims []Claim
+	for id, isWolf := range m.checks {
+		if !m.reported[id] {
+			m.reported[id] = true
+			claims = append(claims, Claim{SpeakerID: m.playerID, TargetID: id, IsWolf: isWolf})
+		}
+	}
+	sort.Slice(claims, func(i, j int) bool { return claims[i].TargetID < claims[j].TargetID })
+	return claims
+}
+
+// Suspects 被声明为狼人的存活玩家（不含自己）
+func (m *Memory) Suspects() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, claim := range m.claims {
+		if claim.IsWolf && !m.dead[claim.TargetID] && claim.TargetID != m.playerID && !seen[claim.TargetID] {
+			seen[claim.TargetID] = true
+			ids = append(ids, claim.TargetID)
+		}
+	}
+	return ids
+}
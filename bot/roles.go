@@ -0,0 +1,250 @@
+package bot
+
+import (
+	"math/rand"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// ==================== 好人通用 ====================
+
+// VillagerStrategy 村民策略
+// 白天附和预言家的查杀，投票优先投被查杀的玩家，否则随机投票
+func VillagerStrategy(turn *Turn, memory *Memory, rng *rand.Rand) []Action {
+	switch turn.Phase {
+	case pb.PhaseType_PHASE_TYPE_DAY:
+		return []Action{daySpeech(turn, memory)}
+	case pb.PhaseType_PHASE_TYPE_VOTE:
+		if target := goodVoteTarget(turn, memory, rng, nil); target != "" {
+			return []Action{Use(pb.SkillType_SKILL_TYPE_VOTE, target)}
+		}
+	}
+	return nil
+}
+
+// daySpeech 好人白天发言
+func daySpeech(turn *Turn, memory *Memory) Action {
+	alive := turn.AlivePlayers()
+	for _, id := range memory.Suspects() {
+		if contains(alive, id) {
+			return Speak("我相信预言家，今天出 " + id + "。")
+		}
+	}
+	return Speak("过。")
+}
+
+// goodVoteTarget 好人投票目标：优先被查杀的玩家，否则在未被证明是好人的玩家中随机
+func goodVoteTarget(turn *Turn, memory *Memory, rng *rand.Rand, cleared []string) string {
+	alive := turn.AlivePlayers()
+	for _, id := range memory.Suspects() {
+		if contains(alive, id) {
+			return id
+		}
+	}
+	if target := pick(rng, exclude(alive, cleared)); target != "" {
+		return target
+	}
+	return pick(rng, alive)
+}
+
+// ==================== 预言家 ====================
+
+// SeerStrategy 预言家策略
+// 夜晚查验未验过的玩家；白天如实报出所有新的查验结果；投票优先投自己查到的狼人
+func SeerStrategy(turn *Turn, memory *Memory, rng *rand.Rand) []Action {
+	checks := memory.Checks()
+
+	switch turn.Phase {
+	case pb.PhaseType_PHASE_TYPE_NIGHT_SEER:
+		var unchecked []string
+		for _, id := range turn.AlivePlayers() {
+			if _, ok := checks[id]; !ok {
+				unchecked = append(unchecked, id)
+			}
+		}
+		if target := pick(rng, unchecked); target != "" {
+			return []Action{Use(pb.SkillType_SKILL_TYPE_CHECK, target)}
+		}
+
+	case pb.PhaseType_PHASE_TYPE_DAY:
+		var actions []Action
+		for _, claim := range memory.Unreported() {
+			actions = append(actions, Speak(SeerClaim(claim.TargetID, claim.IsWolf)))
+		}
+		if len(actions) == 0 {
+			actions = append(actions, Speak("过。"))
+		}
+		return actions
+
+	case pb.PhaseType_PHASE_TYPE_VOTE:
+		var wolves, cleared []string
+		for _, id := range turn.AlivePlayers() {
+			isWolf, ok := checks[id]
+			switch {
+			case ok && isWolf:
+				wolves = append(wolves, id)
+			case ok:
+				cleared = append(cleared, id)
+			}
+		}
+		target := pick(rng, wolves)
+		if target == "" {
+			target = goodVoteTarget(turn, memory, rng, cleared)
+		}
+		if target != "" {
+			return []Action{Use(pb.SkillType_SKILL_TYPE_VOTE, target)}
+		}
+	}
+	return nil
+}
+
+// ==================== 狼人 ====================
+
+// WolfStrategy 狼人策略
+// 夜晚通过狼人频道协调刀口：第一个行动的狼人提议目标（优先跳出来的预言家），队友跟刀；
+// 白天不发言，投票优先投查杀狼队友的预言家
+func WolfStrategy(turn *Turn, memory *Memory, rng *rand.Rand) []Action {
+	teammates := turn.View.GetTeammates()
+	targets := exclude(turn.AlivePlayers(), teammates)
+
+	switch turn.Phase {
+	case pb.PhaseType_PHASE_TYPE_NIGHT_WOLF:
+		if target, ok := memory.WolfProposal(turn.Round); ok && contains(targets, target) {
+			return []Action{Use(pb.SkillType_SKILL_TYPE_KILL, target)}
+		}
+
+		target := pick(rng, claimedSeers(memory, targets))
+		if target == "" {
+			target = pick(rng, targets)
+		}
+		if target == "" {
+			return nil
+		}
+		return []Action{
+			Speak(WolfKillProposal(target)),
+			Use(pb.SkillType_SKILL_TYPE_KILL, target),
+		}
+
+	case pb.PhaseType_PHASE_TYPE_DAY:
+		return []Action{Speak("过。")}
+
+	case pb.PhaseType_PHASE_TYPE_VOTE:
+		accused := append([]string{turn.PlayerID}, teammates...)
+		target := pick(rng, accusers(memory, targets, accused))
+		if target == "" {
+			target = pick(rng, targets)
+		}
+		if target != "" {
+			return []Action{Use(pb.SkillType_SKILL_TYPE_VOTE, target)}
+		}
+	}
+	return nil
+}
+
+// claimedSeers 在候选中跳过预言家的玩家
+func claimedSeers(memory *Memory, candidates []string) []string {
+	var ids []string
+	for _, claim := range memory.Claims() {
+		if contains(candidates, claim.SpeakerID) && !contains(ids, claim.SpeakerID) {
+			ids = append(ids, claim.SpeakerID)
+		}
+	}
+	return ids
+}
+
+// accusers 在候选中查杀过指定玩家的预言家
+func accusers(memory *Memory, candidates []string, accused []string) []string {
+	var ids []string
+	for _, claim := range memory.Claims() {
+		if claim.IsWolf && contains(accused, claim.TargetID) &&
+			contains(candidates, claim.SpeakerID) && !contains(ids, claim.SpeakerID) {
+			ids = append(ids, claim.SpeakerID)
+		}
+	}
+	return ids
+}
+
+// ==================== 女巫 ====================
+
+// WitchStrategy 女巫策略
+// 首夜、预言家被刀或（规则允许时）自己被刀时用解药；毒药留给被查杀的玩家，没有查杀时不用；
+// 白天和投票同村民
+func WitchStrategy(turn *Turn, memory *Memory, rng *rand.Rand) []Action {
+	if turn.Phase != pb.PhaseType_PHASE_TYPE_NIGHT_WITCH {
+		return VillagerStrategy(turn, memory, rng)
+	}
+
+	view := turn.View
+	if target := view.GetNightKillTarget(); view.GetHasAntidote() && target != "" {
+		isSelf := target == turn.PlayerID
+		worthSaving := turn.Round == 1 || isSelf || contains(claimedSeers(memory, []string{target}), target)
+		if worthSaving && (!isSelf || memory.Rules().WitchCanSaveSelf) {
+			// 同一晚不再用毒
+			return []Action{Use(pb.SkillType_SKILL_TYPE_ANTIDOTE, target)}
+		}
+	}
+
+	if view.GetHasPoison() {
+		alive := turn.AlivePlayers()
+		for _, id := range memory.Suspects() {
+			if contains(alive, id) {
+				return []Action{Use(pb.SkillType_SKILL_TYPE_POISON, id)}
+			}
+		}
+	}
+	return nil
+}
+
+// ==================== 守卫 ====================
+
+// GuardStrategy 守卫策略
+// 优先守护跳出来的预言家；按规则决定能否自守、能否连续守同一人；白天和投票同村民
+func GuardStrategy(turn *Turn, memory *Memory, rng *rand.Rand) []Action {
+	if turn.Phase != pb.PhaseType_PHASE_TYPE_NIGHT_GUARD {
+		return VillagerStrategy(turn, memory, rng)
+	}
+
+	rules := memory.Rules()
+	candidates := turn.AlivePlayers()
+	if rules.GuardCanProtectSelf {
+		candidates = append(candidates, turn.PlayerID)
+	}
+	if !rules.GuardCanRepeat {
+		candidates = exclude(candidates, []string{memory.LastProtected()})
+	}
+
+	target := pick(rng, claimedSeers(memory, candidates))
+	if target == "" {
+		target = pick(rng, candidates)
+	}
+	if target == "" {
+		return nil
+	}
+	return []Action{Use(pb.SkillType_SKILL_TYPE_PROTECT, target)}
+}
+
+// ==================== 猎人 ====================
+
+// HunterStrategy 猎人策略
+// 出局时优先带走被查杀的玩家，否则随机开枪；白天和投票同村民
+func HunterStrategy(turn *Turn, memory *Memory, rng *rand.Rand) []Action {
+	if !turn.CanUse(pb.SkillType_SKILL_TYPE_SHOOT) {
+		return VillagerStrategy(turn, memory, rng)
+	}
+
+	alive := turn.AlivePlayers()
+	target := ""
+	for _, id := range memory.Suspects() {
+		if contains(alive, id) {
+			target = id
+			break
+		}
+	}
+	if target == "" {
+		target = pick(rng, alive)
+	}
+	if target == "" {
+		return []Action{Use(pb.SkillType_SKILL_TYPE_SKIP, "")}
+	}
+	return []Action{Use(pb.SkillType_SKILL_TYPE_SHOOT, target)}
+}
@@ -0,0 +1,160 @@
+package bot
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// Strategy 决策策略
+// 根据行动提示和代理记忆决定本次动作
+type Strategy interface {
+	Decide(turn *Turn, memory *Memory, rng *rand.Rand) []Action
+}
+
+// StrategyFunc 函数形式的策略
+type StrategyFunc func(turn *Turn, memory *Memory, rng *rand.Rand) []Action
+
+// Decide 实现 Strategy 接口
+func (f StrategyFunc) Decide(turn *Turn, memory *Memory, rng *rand.Rand) []Action {
+	return f(turn, memory, rng)
+}
+
+// DefaultStrategies 各角色的基础策略
+func DefaultStrategies() map[pb.RoleType]Strategy {
+	return map[pb.RoleType]Strategy{
+		pb.RoleType_ROLE_TYPE_WEREWOLF: StrategyFunc(WolfStrategy),
+		pb.RoleType_ROLE_TYPE_SEER:     StrategyFunc(SeerStrategy),
+		pb.RoleType_ROLE_TYPE_WITCH:    StrategyFunc(WitchStrategy),
+		pb.RoleType_ROLE_TYPE_GUARD:    StrategyFunc(GuardStrategy),
+		pb.RoleType_ROLE_TYPE_HUNTER:   StrategyFunc(HunterStrategy),
+		pb.RoleType_ROLE_TYPE_VILLAGER: StrategyFunc(VillagerStrategy),
+	}
+}
+
+// StrategyAgent 按角色委托给策略的代理
+// 身份在发牌后才确定，因此每次行动时根据视角中的角色选择策略，未配置的角色使用随机策略
+type StrategyAgent struct {
+	memory *Memory
+
+	mu         sync.Mutex
+	rng        *rand.Rand
+	strategies map[pb.RoleType]Strategy
+	fallback   Strategy
+}
+
+// NewStrategyAgent 创建使用默认策略的代理
+func NewStrategyAgent(playerID string, seed int64) *StrategyAgent {
+	return &StrategyAgent{
+		memory:     NewMemory(playerID),
+		rng:        rand.New(rand.NewSource(seed)),
+		strategies: DefaultStrategies(),
+		fallback:   StrategyFunc(RandomStrategy),
+	}
+}
+
+// SetStrategy 替换某个角色的策略
+func (a *StrategyAgent) SetStrategy(role pb.RoleType, strategy Strategy) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.strategies[role] = strategy
+}
+
+// SetFallback 替换未配置角色使用的策略
+func (a *StrategyAgent) SetFallback(strategy Strategy) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.fallback = strategy
+}
+
+// Memory 代理记忆
+func (a *StrategyAgent) Memory() *Memory {
+	return a.memory
+}
+
+// OnEvent 实现 Agent 接口
+func (a *StrategyAgent) OnEvent(event *pb.Event) {
+	a.memory.Observe(event)
+}
+
+// OnMessage 实现 Agent 接口
+func (a *StrategyAgent) OnMessage(msg *werewolf.Message) {
+	a.memory.Hear(msg)
+}
+
+// Act 实现 Agent 接口
+func (a *StrategyAgent) Act(turn *Turn) []Action {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	strategy, ok := a.strategies[turn.View.GetRole()]
+	if !ok {
+		strategy = a.fallback
+	}
+	return strategy.Decide(turn, a.memory, a.rng)
+}
+
+// RandomStrategy 随机策略：每个可用技能随机选择合法目标
+// 狼人不刀、不投队友；女巫随机使用药水；猎人总是开枪
+func RandomStrategy(turn *Turn, _ *Memory, rng *rand.Rand) []Action {
+	var actions []Action
+	for _, skill := range turn.View.GetAllowedSkills() {
+		switch skill {
+		case pb.SkillType_SKILL_TYPE_SPEAK:
+			actions = append(actions, Speak("过。"))
+		case pb.SkillType_SKILL_TYPE_SKIP:
+		case pb.SkillType_SKILL_TYPE_ANTIDOTE:
+			if target := turn.View.GetNightKillTarget(); target != "" && rng.Intn(2) == 0 {
+				actions = append(actions, Use(skill, target))
+			}
+		case pb.SkillType_SKILL_TYPE_POISON:
+			if rng.Intn(4) == 0 {
+				if target := pick(rng, turn.AlivePlayers()); target != "" {
+					actions = append(actions, Use(skill, target))
+				}
+			}
+		default:
+			targets := exclude(turn.AlivePlayers(), turn.View.GetTeammates())
+			if target := pick(rng, targets); target != "" {
+				actions = append(actions, Use(skill, target))
+			}
+		}
+	}
+	return actions
+}
+
+// pick 随机选择一个，列表为空时返回空字符串
+func pick(rng *rand.Rand, ids []string) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[rng.Intn(len(ids))]
+}
+
+// exclude 从列表中去掉指定玩家
+func exclude(ids []string, excluded []string) []string {
+	skip := make(map[string]bool, len(excluded))
+	for _, id := range excluded {
+		skip[id] = true
+	}
+
+	var result []string
+	for _, id := range ids {
+		if !skip[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// contains 列表中是否包含该玩家
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
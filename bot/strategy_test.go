@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// testTurn 构造行动提示，alive 为存活的其他玩家
+func testTurn(playerID string, phase pb.PhaseType, round int, view *pb.PlayerView, alive ...string) *Turn {
+	view.PlayerId = playerID
+	view.Phase = phase
+	view.Round = int32(round)
+	view.Players = append(view.Players, &pb.PlayerSummary{Id: playerID, Alive: true})
+	for _, id := range alive {
+		view.Players = append(view.Players, &pb.PlayerSummary{Id: id, Alive: true})
+	}
+	return &Turn{PlayerID: playerID, Phase: phase, Round: round, View: view}
+}
+
+func TestWolfStrategy_CoordinatesKill(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		engine, driver, agents := newBotGame(t, werewolf.BoardTwelvePlayers, seed)
+		advanceTo(t, engine, driver, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+		if err := driver.PlayPhase(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		targets := make(map[string]bool)
+		for _, agent := range agents {
+			for _, action := range agent.actions {
+				if action.Skill == pb.SkillType_SKILL_TYPE_KILL {
+					targets[action.Target] = true
+				}
+			}
+		}
+		if len(targets) != 1 {
+			t.Errorf("seed %d: expected wolves to agree on one target, got %v", seed, targets)
+		}
+	}
+}
+
+func TestSeerStrategy_ReportsHonestly(t *testing.T) {
+	for seed := int64(1); seed <= 10; seed++ {
+		engine, driver, agents := newBotGame(t, werewolf.BoardNinePlayers, seed)
+		advanceTo(t, engine, driver, pb.PhaseType_PHASE_TYPE_DAY)
+		driver.PlayPhase()
+
+		for id, agent := range agents {
+			if info, _ := engine.GetPlayerInfo(id); info.Role != pb.RoleType_ROLE_TYPE_SEER || !info.Alive {
+				continue
+			}
+			for target, isWolf := range agent.Memory().Checks() {
+				info, _ := engine.GetPlayerInfo(target)
+				if isWolf != (info.Camp == pb.Camp_CAMP_EVIL) {
+					t.Errorf("seed %d: seer recorded wrong result for %s", seed, target)
+				}
+				if !containsAction(agent.actions, Speak(SeerClaim(target, isWolf))) {
+					t.Errorf("seed %d: expected seer to report %s, got %+v", seed, target, agent.actions)
+				}
+			}
+		}
+	}
+}
+
+func TestWitchStrategy(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	memory := NewMemory("w")
+
+	// 首夜有刀口：使用解药
+	turn := testTurn("w", pb.PhaseType_PHASE_TYPE_NIGHT_WITCH, 1,
+		&pb.PlayerView{HasAntidote: true, HasPoison: true, NightKillTarget: "p2"}, "p2", "p3")
+	if actions := WitchStrategy(turn, memory, rng); len(actions) != 1 || actions[0] != Use(pb.SkillType_SKILL_TYPE_ANTIDOTE, "p2") {
+		t.Errorf("expected antidote on p2, got %+v", actions)
+	}
+
+	// 没有查杀时不用毒
+	turn = testTurn("w", pb.PhaseType_PHASE_TYPE_NIGHT_WITCH, 2, &pb.PlayerView{HasPoison: true}, "p2", "p3")
+	if actions := WitchStrategy(turn, memory, rng); len(actions) != 0 {
+		t.Errorf("expected no action without suspects, got %+v", actions)
+	}
+
+	// 预言家查杀 p3 后毒 p3
+	memory.Hear(&werewolf.Message{SenderID: "p2", Content: SeerClaim("p3", true), Phase: pb.PhaseType_PHASE_TYPE_DAY})
+	turn = testTurn("w", pb.PhaseType_PHASE_TYPE_NIGHT_WITCH, 2, &pb.PlayerView{HasPoison: true}, "p2", "p3")
+	if actions := WitchStrategy(turn, memory, rng); len(actions) != 1 || actions[0] != Use(pb.SkillType_SKILL_TYPE_POISON, "p3") {
+		t.Errorf("expected poison on p3, got %+v", actions)
+	}
+}
+
+func TestGuardStrategy_FollowsRules(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	memory := NewMemory("g")
+	memory.Observe(&pb.Event{Type: pb.EventType_EVENT_TYPE_PROTECT, SourceId: "g", TargetId: "p2"})
+
+	// 默认规则：不能连续守同一人，可以自守
+	targets := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		turn := testTurn("g", pb.PhaseType_PHASE_TYPE_NIGHT_GUARD, 2, &pb.PlayerView{}, "p2", "p3")
+		for _, action := range GuardStrategy(turn, memory, rng) {
+			targets[action.Target] = true
+		}
+	}
+	if targets["p2"] || !targets["p3"] || !targets["g"] {
+		t.Errorf("expected guard to protect p3 or self but never p2 again, got %v", targets)
+	}
+
+	// 房主改为允许连守后，优先守护跳出来的预言家
+	memory.Observe(&pb.Event{Type: pb.EventType_EVENT_TYPE_RULES_CHANGED, Data: map[string]string{
+		"guard_can_repeat": "true",
+		"wolf_tie_policy":  "random",
+	}})
+	memory.Hear(&werewolf.Message{SenderID: "p2", Content: SeerClaim("p3", false), Phase: pb.PhaseType_PHASE_TYPE_DAY})
+	if rules := memory.Rules(); !rules.GuardCanRepeat || rules.GuardCanProtectSelf || rules.WolfTiePolicy != werewolf.WolfTieRandom {
+		t.Errorf("expected rules from event, got %+v", rules)
+	}
+	turn := testTurn("g", pb.PhaseType_PHASE_TYPE_NIGHT_GUARD, 2, &pb.PlayerView{}, "p2", "p3")
+	if actions := GuardStrategy(turn, memory, rng); len(actions) != 1 || actions[0].Target != "p2" {
+		t.Errorf("expected guard to protect claimed seer p2, got %+v", actions)
+	}
+}
+
+func TestVillagerStrategy_FollowsSeer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	memory := NewMemory("v")
+	memory.Hear(&werewolf.Message{SenderID: "p2", Content: SeerClaim("p4", true), Phase: pb.PhaseType_PHASE_TYPE_DAY})
+
+	turn := testTurn("v", pb.PhaseType_PHASE_TYPE_VOTE, 1, &pb.PlayerView{}, "p2", "p3", "p4")
+	if actions := VillagerStrategy(turn, memory, rng); len(actions) != 1 || actions[0] != Use(pb.SkillType_SKILL_TYPE_VOTE, "p4") {
+		t.Errorf("expected vote for p4, got %+v", actions)
+	}
+
+	// 被查杀的玩家出局后不再怀疑
+	memory.Observe(&pb.Event{Type: pb.EventType_EVENT_TYPE_ELIMINATE, TargetId: "p4"})
+	if suspects := memory.Suspects(); len(suspects) != 0 {
+		t.Errorf("expected no suspects after elimination, got %v", suspects)
+	}
+}
+
+func containsAction(actions []Action, want Action) bool {
+	for _, action := range actions {
+		if action == want {
+			return true
+		}
+	}
+	return false
+}
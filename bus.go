@@ -0,0 +1,370 @@
+package werewolf
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// ==================== 事件总线 ====================
+//
+// 每个引擎有一条事件总线，事件和消息按发布顺序分配本局内递增的序号（从 1 开始），
+// 并按序号顺序投递给所有订阅者：
+//
+//	sub := engine.SubscribeEvents(handler, werewolf.WithBuffer(64), werewolf.WithOverflow(werewolf.OverflowDrop))
+//	defer sub.Unsubscribe()
+//
+// 同步订阅者（默认）在正在投递的 goroutine 中执行：没有其他投递进行时就是发布方，
+// 引擎方法返回时处理器已经执行完（单个 goroutine 驱动引擎时，如 simulation.PlayGame，OnEvent 可以依赖这一点）。
+// 多个 goroutine 并发调用引擎时，由先开始投递的 goroutine 按序号顺序投递所有事件，
+// 后来的发布方入队后立即返回，其事件的处理器可能在它返回之后、在另一个 goroutine 中执行。
+// 处理器中可以再调用引擎方法，期间新发布的事件排在当前事件之后投递，不会死锁。
+// 异步订阅者（WithBuffer）有独立的缓冲区和 goroutine，慢消费者不会拖慢游戏。
+// OverflowBlock 订阅者阻塞投递期间，待投递队列积压达到 MaxPendingItems 时该订阅者被断开，队列不会无限增长。
+// 处理器 panic 会被捕获，通过 Logger 记录并计入 Metrics.IncHandlerPanic。
+
+// OverflowPolicy 异步订阅者缓冲区满时的处理策略
+type OverflowPolicy int
+
+const (
+	// OverflowBlock 阻塞投递直到缓冲区有空位（默认，不丢事件）
+	// 阻塞期间待投递队列积压达到 MaxPendingItems 时断开订阅，Subscription.Err 返回 ErrSubscriberOverflow
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDrop 丢弃新的事件，通过 Subscription.Dropped 查看丢弃数量
+	OverflowDrop
+	// OverflowDisconnect 断开订阅，Subscription.Err 返回 ErrSubscriberOverflow
+	OverflowDisconnect
+)
+
+// MaxPendingItems 事件总线待投递队列的上限（见 OverflowBlock）
+const MaxPendingItems = 1024
+
+// SubscribeOption 订阅选项
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	buffer   int // 异步缓冲区大小，0 表示同步投递
+	overflow OverflowPolicy
+}
+
+// applySubscribeOptions 合并订阅选项
+func applySubscribeOptions(opts []SubscribeOption) subscribeOptions {
+	var o subscribeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithBuffer 异步投递，size 为缓冲区大小（size <= 0 时仍同步投递）
+func WithBuffer(size int) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.buffer = size
+	}
+}
+
+// WithOverflow 异步订阅者缓冲区满时的处理策略
+func WithOverflow(policy OverflowPolicy) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.overflow = policy
+	}
+}
+
+// busItem 总线上的一条事件或消息
+type busItem struct {
+	event       *pb.Event
+	msg         *Message
+	receiverIDs []string
+}
+
+// Subscription 订阅句柄
+type Subscription struct {
+	bus     *eventBus
+	handler func(item busItem)
+	opts    subscribeOptions
+
+	ch        chan busItem  // 异步缓冲区（同步订阅为 nil）
+	stopped   chan struct{} // 异步投递循环退出时关闭
+	done      chan struct{}
+	closeOnce sync.Once
+	err       atomic.Value // 断开原因
+	dropped   atomic.Uint64
+}
+
+// Unsubscribe 取消订阅，之后不再投递（异步缓冲区中未投递的事件被丢弃），可重复调用
+func (s *Subscription) Unsubscribe() {
+	s.bus.remove(s)
+	s.close(nil)
+}
+
+// Done 订阅结束（取消订阅或因缓冲区溢出被断开）时关闭
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err 订阅被断开的原因，正常订阅或主动取消时返回 nil
+func (s *Subscription) Err() error {
+	err, _ := s.err.Load().(error)
+	return err
+}
+
+// Dropped OverflowDrop 策略下丢弃的事件和消息数量
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// close 结束订阅（只生效一次）
+func (s *Subscription) close(err error) {
+	s.closeOnce.Do(func() {
+		if err != nil {
+			s.err.Store(err)
+		}
+		close(s.done)
+	})
+}
+
+// closed 订阅是否已结束
+func (s *Subscription) closed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// enqueue 投递到订阅者（同步订阅直接执行处理器）
+func (s *Subscription) enqueue(item busItem) {
+	if s.ch == nil {
+		s.handle(item)
+		return
+	}
+
+	select {
+	case s.ch <- item:
+		return
+	case <-s.done:
+		return
+	default:
+	}
+
+	switch s.opts.overflow {
+	case OverflowDrop:
+		s.dropped.Add(1)
+	case OverflowDisconnect:
+		s.bus.remove(s)
+		s.close(ErrSubscriberOverflow)
+		s.bus.logger().Warn("subscriber disconnected on buffer overflow", F("buffer", s.opts.buffer))
+	default:
+		select {
+		case s.ch <- item:
+		case <-s.done:
+		case <-s.bus.backlogged():
+			s.bus.remove(s)
+			s.close(ErrSubscriberOverflow)
+			s.bus.logger().Warn("blocking subscriber disconnected on publish backlog",
+				F("buffer", s.opts.buffer), F("pending", MaxPendingItems))
+		}
+	}
+}
+
+// run 异步订阅者的投递循环
+func (s *Subscription) run() {
+	defer close(s.stopped)
+	for {
+		select {
+		case item := <-s.ch:
+			if s.closed() {
+				return
+			}
+			s.handle(item)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// handle 执行处理器，捕获 panic 并上报
+func (s *Subscription) handle(item busItem) {
+	defer func() {
+		if r := recover(); r != nil {
+			kind := "event"
+			fields := []Field{F("panic", fmt.Sprint(r))}
+			if item.event != nil {
+				fields = append(fields, EventField(item.event.Type))
+			} else {
+				kind = "message"
+				fields = append(fields, PlayerField(item.msg.SenderID))
+			}
+			s.bus.logger().Error(kind+" handler panicked", fields...)
+			s.bus.metrics().IncHandlerPanic(kind)
+		}
+	}()
+
+	s.handler(item)
+}
+
+// eventBus 事件总线
+type eventBus struct {
+	mu       sync.Mutex
+	seq      uint64
+	subs     []*Subscription // 写时复制
+	queue    []busItem       // 待投递
+	draining bool            // 是否有 goroutine 正在投递
+	full     chan struct{}   // 待投递队列达到 MaxPendingItems 时关闭
+	ended    *busItem        // 已开始投递的 GAME_ENDED
+	isFull   bool
+
+	log Logger
+	met Metrics
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{log: NewNopLogger(), met: NewNopMetrics(), full: make(chan struct{})}
+}
+
+// backlogged 待投递队列达到上限时关闭的 channel
+func (b *eventBus) backlogged() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.full
+}
+
+func (b *eventBus) logger() Logger {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.log
+}
+
+func (b *eventBus) metrics() Metrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.met
+}
+
+func (b *eventBus) setLogger(logger Logger) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.log = logger
+}
+
+func (b *eventBus) setMetrics(metrics Metrics) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.met = metrics
+}
+
+// subscribe 添加订阅者，handler 收到所有事件和消息
+func (b *eventBus) subscribe(handler func(item busItem), opts subscribeOptions) *Subscription {
+	sub := b.newSubscription(handler, opts)
+	b.add(sub)
+	return sub
+}
+
+// newSubscription 创建订阅者（尚未开始接收，处理器需要引用订阅句柄时先创建再 add）
+func (b *eventBus) newSubscription(handler func(item busItem), opts subscribeOptions) *Subscription {
+	sub := &Subscription{bus: b, handler: handler, opts: opts, done: make(chan struct{})}
+	if opts.buffer > 0 {
+		sub.ch = make(chan busItem, opts.buffer)
+		sub.stopped = make(chan struct{})
+	}
+	return sub
+}
+
+// add 开始投递给订阅者
+func (b *eventBus) add(sub *Subscription) {
+	if sub.ch != nil {
+		go sub.run()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := make([]*Subscription, len(b.subs), len(b.subs)+1)
+	copy(subs, b.subs)
+	b.subs = append(subs, sub)
+}
+
+// addUntilEnded 开始投递给随 GAME_ENDED 结束的订阅者（见 Engine.Subscribe）
+// 与开始投递 GAME_ENDED 在同一把锁下判断：之前加入的订阅者按序收到 GAME_ENDED，
+// 之后加入的订阅者不再加入，只补发 GAME_ENDED
+func (b *eventBus) addUntilEnded(sub *Subscription) {
+	if sub.ch != nil {
+		go sub.run()
+	}
+
+	b.mu.Lock()
+	ended := b.ended
+	if ended == nil {
+		subs := make([]*Subscription, len(b.subs), len(b.subs)+1)
+		copy(subs, b.subs)
+		b.subs = append(subs, sub)
+	}
+	b.mu.Unlock()
+
+	if ended != nil {
+		sub.enqueue(*ended)
+	}
+}
+
+// remove 移除订阅者
+func (b *eventBus) remove(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := make([]*Subscription, 0, len(b.subs))
+	for _, s := range b.subs {
+		if s != sub {
+			subs = append(subs, s)
+		}
+	}
+	b.subs = subs
+}
+
+// publish 分配序号并按顺序投递
+// 已有 goroutine 在投递时（包括处理器中再次发布）只入队，由正在投递的 goroutine 按序投递，
+// 这时 publish 在处理器执行之前返回
+func (b *eventBus) publish(item busItem) {
+	b.mu.Lock()
+	b.seq++
+	if item.event != nil {
+		item.event.Sequence = b.seq
+	} else {
+		item.msg.Sequence = b.seq
+	}
+	b.queue = append(b.queue, item)
+	if len(b.queue) >= MaxPendingItems && !b.isFull {
+		b.isFull = true
+		close(b.full)
+	}
+	if b.draining {
+		b.mu.Unlock()
+		return
+	}
+	b.draining = true
+
+	for len(b.queue) > 0 {
+		next := b.queue[0]
+		b.queue = b.queue[1:]
+		if b.isFull && len(b.queue) < MaxPendingItems {
+			b.isFull = false
+			b.full = make(chan struct{})
+		}
+		if next.event != nil && next.event.Type == pb.EventType_EVENT_TYPE_GAME_ENDED {
+			b.ended = &next
+		}
+		subs := b.subs
+		b.mu.Unlock()
+
+		for _, sub := range subs {
+			if !sub.closed() {
+				sub.enqueue(next)
+			}
+		}
+
+		b.mu.Lock()
+	}
+	b.queue = nil
+	b.draining = false
+	b.mu.Unlock()
+}
@@ -0,0 +1,266 @@
+package werewolf
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// panicRecorder 记录 Error 日志和处理器 panic 计数
+type panicRecorder struct {
+	NopLogger
+	NopMetrics
+
+	mu     sync.Mutex
+	errors []string
+	panics map[string]int
+}
+
+func (r *panicRecorder) Error(msg string, fields ...Field) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, msg)
+}
+
+func (r *panicRecorder) With(fields ...Field) Logger { return r }
+
+func (r *panicRecorder) IncHandlerPanic(kind string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.panics == nil {
+		r.panics = make(map[string]int)
+	}
+	r.panics[kind]++
+}
+
+func newBusTestEngine(t *testing.T) *Engine {
+	t.Helper()
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v3", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	return engine
+}
+
+func TestSubscribeEvents_SequenceShared(t *testing.T) {
+	engine := newBusTestEngine(t)
+
+	var seqs []uint64
+	engine.SubscribeEvents(func(event *pb.Event) { seqs = append(seqs, event.Sequence) })
+	engine.SubscribeMessages(func(msg *Message, receiverIDs []string) {
+		seqs = append(seqs, msg.Sequence)
+		if msg.ToProto(receiverIDs).GetSequence() != msg.Sequence {
+			t.Errorf("expected proto sequence %d", msg.Sequence)
+		}
+	})
+
+	engine.Start()
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+	if err := engine.SendMessage("v1", "hello"); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+
+	if len(seqs) == 0 {
+		t.Fatal("expected events")
+	}
+	for i, seq := range seqs {
+		if seq != uint64(i+1) {
+			t.Fatalf("expected consecutive sequence numbers, got %v", seqs)
+		}
+	}
+}
+
+func TestSubscribeEvents_Unsubscribe(t *testing.T) {
+	engine := newBusTestEngine(t)
+
+	count := 0
+	sub := engine.SubscribeEvents(func(event *pb.Event) { count++ })
+	engine.Start()
+	if count != 3 {
+		t.Fatalf("expected 3 events on start, got %d", count)
+	}
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	engine.EndPhase()
+	if count != 3 {
+		t.Errorf("expected no events after unsubscribe, got %d", count)
+	}
+	select {
+	case <-sub.Done():
+	default:
+		t.Error("expected Done to be closed")
+	}
+	if sub.Err() != nil {
+		t.Errorf("expected nil Err after unsubscribe, got %v", sub.Err())
+	}
+}
+
+func TestSubscribeEvents_ReentrantOrder(t *testing.T) {
+	engine := newBusTestEngine(t)
+
+	// 处理器中推进阶段：新事件排在当前事件之后，所有订阅者看到相同的顺序
+	var first, second []uint64
+	ended := false
+	engine.SubscribeEvents(func(event *pb.Event) {
+		first = append(first, event.Sequence)
+		if event.Type == pb.EventType_EVENT_TYPE_PHASE_STARTED && !ended {
+			ended = true
+			engine.EndPhase()
+		}
+	})
+	engine.SubscribeEvents(func(event *pb.Event) { second = append(second, event.Sequence) })
+
+	engine.Start()
+	if len(first) != 5 || len(second) != 5 {
+		t.Fatalf("expected 5 events for both subscribers, got %v and %v", first, second)
+	}
+	for i := range first {
+		if first[i] != uint64(i+1) || second[i] != first[i] {
+			t.Fatalf("expected ordered delivery, got %v and %v", first, second)
+		}
+	}
+}
+
+func TestSubscribeEvents_Async(t *testing.T) {
+	engine := newBusTestEngine(t)
+
+	received := make(chan uint64, 100)
+	sub := engine.SubscribeEvents(func(event *pb.Event) { received <- event.Sequence }, WithBuffer(1))
+	defer sub.Unsubscribe()
+
+	engine.Start()
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+
+	// 默认阻塞策略不丢事件，且按序号顺序投递
+	want := engine.bus.seq
+	for i := uint64(1); i <= want; i++ {
+		select {
+		case seq := <-received:
+			if seq != i {
+				t.Fatalf("expected sequence %d, got %d", i, seq)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for sequence %d", i)
+		}
+	}
+}
+
+func TestSubscribeEvents_Overflow(t *testing.T) {
+	engine := newBusTestEngine(t)
+
+	release := make(chan struct{})
+	blocked := func(event *pb.Event) { <-release }
+	drop := engine.SubscribeEvents(blocked, WithBuffer(1), WithOverflow(OverflowDrop))
+	disconnect := engine.SubscribeEvents(blocked, WithBuffer(1), WithOverflow(OverflowDisconnect))
+	defer drop.Unsubscribe()
+	defer close(release)
+
+	// 处理器阻塞在第一个事件，缓冲区容纳第二个，之后的事件溢出
+	engine.Start()
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WITCH)
+
+	if drop.Dropped() == 0 {
+		t.Error("expected dropped events with OverflowDrop")
+	}
+	select {
+	case <-disconnect.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected subscriber to be disconnected")
+	}
+	if !errors.Is(disconnect.Err(), ErrSubscriberOverflow) {
+		t.Errorf("expected ErrSubscriberOverflow, got %v", disconnect.Err())
+	}
+}
+
+func TestSubscribeEvents_BlockingBacklog(t *testing.T) {
+	engine := newBusTestEngine(t)
+	bus := engine.bus
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	sub := engine.SubscribeEvents(func(event *pb.Event) {
+		once.Do(func() { close(started) })
+		<-release
+	}, WithBuffer(1))
+	defer close(release)
+
+	// 处理器阻塞在第一个事件，缓冲区容纳第二个，第三个阻塞投递的 goroutine
+	bus.mu.Lock()
+	base := bus.seq
+	bus.mu.Unlock()
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 0; i < 3; i++ {
+			bus.publish(busItem{event: &pb.Event{Type: pb.EventType_EVENT_TYPE_PHASE_STARTED}})
+		}
+	}()
+	<-started
+	// 第三个事件入队后它无法投递，投递的 goroutine 一直停在它上面（它可能还在队列中）
+	deadline := time.Now().Add(time.Second)
+	for {
+		bus.mu.Lock()
+		blocked := bus.seq == base+3 && bus.draining
+		bus.mu.Unlock()
+		if blocked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected publisher to block on the subscriber")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// 其他 goroutine 的发布只入队，积压达到上限时断开阻塞的订阅者
+	for i := 0; i < MaxPendingItems; i++ {
+		bus.publish(busItem{event: &pb.Event{Type: pb.EventType_EVENT_TYPE_PHASE_STARTED}})
+	}
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("expected blocked publisher to resume")
+	}
+	if !errors.Is(sub.Err(), ErrSubscriberOverflow) {
+		t.Errorf("expected ErrSubscriberOverflow, got %v", sub.Err())
+	}
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	if len(bus.queue) != 0 || bus.isFull {
+		t.Errorf("expected drained queue, got %d pending", len(bus.queue))
+	}
+}
+
+func TestSubscribeEvents_PanicReported(t *testing.T) {
+	engine := newBusTestEngine(t)
+	recorder := &panicRecorder{}
+	engine.SetLogger(recorder)
+	engine.SetMetrics(recorder)
+
+	count := 0
+	engine.OnEvent(func(event *pb.Event) { panic("boom") })
+	engine.OnEvent(func(event *pb.Event) { count++ })
+	engine.OnMessage(func(msg *Message, receiverIDs []string) { panic("boom") })
+
+	engine.Start()
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+	engine.SendMessage("v1", "hello")
+
+	// panic 不影响其他处理器
+	if count == 0 {
+		t.Fatal("expected second handler to receive events")
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if recorder.panics["event"] != count || recorder.panics["message"] != 1 {
+		t.Errorf("expected %d event and 1 message panics, got %v", count, recorder.panics)
+	}
+	if len(recorder.errors) != count+1 {
+		t.Errorf("expected a log entry per panic, got %d", len(recorder.errors))
+	}
+}
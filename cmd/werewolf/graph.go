@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/Zereker/werewolf"
+	"github.com/Zereker/werewolf/configfile"
+)
+
+// runGraph graph 子命令：将阶段流程导出为 Graphviz DOT 或 Mermaid，便于向玩家讲解规则变体
+//
+//	werewolf graph [-config rules.yaml] [-format dot|mermaid] | dot -Tsvg > flow.svg
+func runGraph(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	fs.SetOutput(out)
+	configPath := fs.String("config", "", "YAML/JSON 配置文件（为空时使用默认配置）")
+	format := fs.String("format", "dot", "输出格式：dot 或 mermaid")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	config := werewolf.DefaultGameConfig()
+	if *configPath != "" {
+		loaded, err := configfile.Load(*configPath)
+		if err != nil {
+			return err
+		}
+		config = loaded
+	}
+
+	switch *format {
+	case "dot":
+		return config.WriteDOT(out)
+	case "mermaid":
+		return config.WriteMermaid(out)
+	default:
+		return fmt.Errorf("unknown format %q (expected dot or mermaid)", *format)
+	}
+}
@@ -0,0 +1,150 @@
+// Command werewolf 终端版狼人杀
+//
+// 在终端中运行一局完整的游戏，用于规则测试：
+//   - 选择板子，指定哪些座位由真人操作，其余座位由机器人操作
+//   - 真人座位采用传递设备（hot-seat）方式依次提示夜晚行动、白天发言和投票
+//   - 任意提示处输入 g 切换上帝视角（显示所有身份和私密事件），输入 q 退出
+//   - 按阶段打印叙述化的事件日志
+//
+// simulate 子命令用机器人批量模拟对局，输出各板子和规则变体的胜率、对局长度和死亡原因（CSV/JSON）。
+// graph 子命令将（默认或配置文件中的）阶段流程导出为 Graphviz DOT 或 Mermaid。
+//
+// 用法：
+//
+//	werewolf [-board 9p-standard] [-humans 1,3|all|none] [-seed 42] [-god]
+//	werewolf simulate [-games 1000] [-boards 9p-standard] [-rules "default;guard-repeat=true"] [-format csv|json]
+//	werewolf graph [-config rules.yaml] [-format dot|mermaid]
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zereker/werewolf"
+)
+
+// subcommands 子命令
+var subcommands = map[string]func(args []string, out io.Writer) error{
+	"simulate": func(args []string, out io.Writer) error {
+		return runSimulate(context.Background(), args, out)
+	},
+	"graph": runGraph,
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:], os.Stdout); err != nil {
+				if err != flag.ErrHelp {
+					fmt.Fprintln(os.Stderr, err)
+				}
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	boardName := flag.String("board", "", "板子名称（为空时交互选择）")
+	humans := flag.String("humans", "", "真人座位：逗号分隔的座位号、all 或 none（为空时交互选择）")
+	seed := flag.Int64("seed", 0, "随机种子（0 表示使用当前时间）")
+	god := flag.Bool("god", false, "开局即打开上帝视角")
+	flag.Parse()
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	opts, err := setup(in, os.Stdout, *boardName, *humans)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	opts.seed = *seed
+	opts.god = *god
+	opts.clearScreen = true
+
+	if err := newSession(in, os.Stdout, opts).run(); err != nil && err != errQuit {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// options 对局选项
+type options struct {
+	board       werewolf.Board
+	humanSeats  map[int]bool // 真人座位号
+	seed        int64
+	god         bool
+	clearScreen bool // 传递设备时清屏，避免下一位玩家看到上一位的信息
+}
+
+// setup 解析板子和真人座位，缺省时交互选择
+func setup(in *bufio.Reader, out io.Writer, boardName, humans string) (options, error) {
+	var opts options
+
+	if boardName == "" {
+		boards := werewolf.PresetBoards()
+		fmt.Fprintln(out, "选择板子：")
+		for i, board := range boards {
+			fmt.Fprintf(out, "  %d) %s（%d 人）：%s\n", i+1, board.Name, board.Size(), describeRoles(board.Roles))
+		}
+		choice, err := promptNumber(in, out, "板子编号: ", 1, len(boards))
+		if err != nil {
+			return opts, err
+		}
+		opts.board = boards[choice-1]
+	} else {
+		board, ok := werewolf.LookupBoard(boardName)
+		if !ok {
+			return opts, fmt.Errorf("unknown board %q", boardName)
+		}
+		opts.board = board
+	}
+
+	if humans == "" {
+		fmt.Fprintf(out, "真人座位（1-%d，逗号分隔；all 全部真人；none 全部机器人）: ", opts.board.Size())
+		line, err := readLine(in)
+		if err != nil {
+			return opts, err
+		}
+		humans = line
+	}
+
+	seats, err := parseSeats(humans, opts.board.Size())
+	if err != nil {
+		return opts, err
+	}
+	opts.humanSeats = seats
+	return opts, nil
+}
+
+// parseSeats 解析真人座位列表
+func parseSeats(spec string, size int) (map[int]bool, error) {
+	seats := make(map[int]bool)
+	switch strings.TrimSpace(spec) {
+	case "", "none":
+		return seats, nil
+	case "all":
+		for i := 1; i <= size; i++ {
+			seats[i] = true
+		}
+		return seats, nil
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		seat, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || seat < 1 || seat > size {
+			return nil, fmt.Errorf("invalid seat %q (expected 1-%d)", part, size)
+		}
+		seats[seat] = true
+	}
+	return seats, nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Zereker/werewolf"
+)
+
+func TestParseSeats(t *testing.T) {
+	seats, err := parseSeats("1, 3", 6)
+	if err != nil || len(seats) != 2 || !seats[1] || !seats[3] {
+		t.Errorf("unexpected seats: %v (%v)", seats, err)
+	}
+	if seats, _ := parseSeats("all", 6); len(seats) != 6 {
+		t.Errorf("expected all 6 seats, got %v", seats)
+	}
+	if seats, _ := parseSeats("none", 6); len(seats) != 0 {
+		t.Errorf("expected no seats, got %v", seats)
+	}
+	if _, err := parseSeats("7", 6); err == nil {
+		t.Error("expected error for out-of-range seat")
+	}
+}
+
+func TestSetup_Interactive(t *testing.T) {
+	in := bufio.NewReader(strings.NewReader("9\n2\n1,2\n"))
+	var out bytes.Buffer
+
+	opts, err := setup(in, &out, "", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if opts.board.Name != werewolf.BoardNinePlayers {
+		t.Errorf("expected board %s, got %s", werewolf.BoardNinePlayers, opts.board.Name)
+	}
+	if len(opts.humanSeats) != 2 {
+		t.Errorf("expected 2 human seats, got %v", opts.humanSeats)
+	}
+	if !strings.Contains(out.String(), "请输入 1-3") {
+		t.Error("expected invalid board choice to be re-prompted")
+	}
+}
+
+func TestSession_AllBots(t *testing.T) {
+	for _, board := range werewolf.PresetBoards() {
+		t.Run(board.Name, func(t *testing.T) {
+			var out bytes.Buffer
+			opts := options{board: board, humanSeats: map[int]bool{}, seed: 1, god: true}
+
+			if err := newSession(bufio.NewReader(strings.NewReader("")), &out, opts).run(); err != nil {
+				t.Fatalf("expected game to finish, got %v", err)
+			}
+			if !strings.Contains(out.String(), "游戏结束") {
+				t.Errorf("expected game over narration, got:\n%s", out.String())
+			}
+			if !strings.Contains(out.String(), "[上帝视角]") {
+				t.Error("expected god view to be printed")
+			}
+			// 每个天亮都只由夜晚总结宣布一次死讯或平安夜
+			dawns := strings.Count(out.String(), "天亮了")
+			announcements := strings.Count(out.String(), "倒牌了") + strings.Count(out.String(), "平安夜")
+			if dawns == 0 || announcements != dawns {
+				t.Errorf("expected one night announcement per dawn, got %d for %d", announcements, dawns)
+			}
+		})
+	}
+}
+
+func TestSession_HumanSeatPassive(t *testing.T) {
+	board, _ := werewolf.LookupBoard(werewolf.BoardSixPlayers)
+	opts := options{board: board, humanSeats: map[int]bool{1: true}, seed: 3}
+
+	// 真人玩家对所有提示直接回车（跳过行动）
+	in := bufio.NewReader(strings.NewReader(strings.Repeat("\n", 1000)))
+	var out bytes.Buffer
+	if err := newSession(in, &out, opts).run(); err != nil {
+		t.Fatalf("expected game to finish, got %v", err)
+	}
+	if !strings.Contains(out.String(), "身份公布") {
+		t.Errorf("expected roles to be revealed at the end, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "[上帝]") {
+		t.Error("expected private events to stay hidden without god view")
+	}
+}
+
+func TestSession_QuitAndGodToggle(t *testing.T) {
+	board, _ := werewolf.LookupBoard(werewolf.BoardSixPlayers)
+	opts := options{board: board, humanSeats: map[int]bool{1: true, 2: true, 3: true, 4: true, 5: true, 6: true}, seed: 1}
+
+	in := bufio.NewReader(strings.NewReader("g\nq\n"))
+	var out bytes.Buffer
+	if err := newSession(in, &out, opts).run(); err != errQuit {
+		t.Fatalf("expected errQuit, got %v", err)
+	}
+	if !strings.Contains(out.String(), "[上帝视角]") {
+		t.Error("expected god view after toggling")
+	}
+}
+
+func TestRunSimulate(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"-games", "4", "-boards", "6p-basic,9p-standard", "-rules", "default;guard-repeat=true", "-workers", "2"}
+	if err := runSimulate(context.Background(), args, &out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], "scenario,board,rules") {
+		t.Errorf("expected header and 4 scenario rows, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := runSimulate(context.Background(), []string{"-games", "2", "-boards", "6p-basic", "-format", "json"}, &out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), `"good_win_rate"`) {
+		t.Errorf("expected JSON report, got:\n%s", out.String())
+	}
+
+	for _, args := range [][]string{
+		{"-format", "xml"},
+		{"-boards", "unknown"},
+		{"-rules", "fly=true"},
+	} {
+		if err := runSimulate(context.Background(), args, &out); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestRunGraph(t *testing.T) {
+	var out bytes.Buffer
+	if err := runGraph(nil, &out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "START -> NIGHT_GUARD;") {
+		t.Errorf("expected DOT output, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := runGraph([]string{"-format", "mermaid", "-config", "../../configfile/testdata/default.yaml"}, &out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "flowchart TD") {
+		t.Errorf("expected Mermaid output, got:\n%s", out.String())
+	}
+
+	for _, args := range [][]string{
+		{"-format", "svg"},
+		{"-config", "missing.yaml"},
+	} {
+		if err := runGraph(args, &out); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// roleNames 角色中文名
+var roleNames = map[pb.RoleType]string{
+	pb.RoleType_ROLE_TYPE_WEREWOLF: "狼人",
+	pb.RoleType_ROLE_TYPE_SEER:     "预言家",
+	pb.RoleType_ROLE_TYPE_WITCH:    "女巫",
+	pb.RoleType_ROLE_TYPE_HUNTER:   "猎人",
+	pb.RoleType_ROLE_TYPE_VILLAGER: "村民",
+	pb.RoleType_ROLE_TYPE_GUARD:    "守卫",
+}
+
+// skillNames 技能中文名
+var skillNames = map[pb.SkillType]string{
+	pb.SkillType_SKILL_TYPE_KILL:     "击杀",
+	pb.SkillType_SKILL_TYPE_CHECK:    "查验",
+	pb.SkillType_SKILL_TYPE_PROTECT:  "守护",
+	pb.SkillType_SKILL_TYPE_ANTIDOTE: "解药",
+	pb.SkillType_SKILL_TYPE_POISON:   "毒药",
+	pb.SkillType_SKILL_TYPE_VOTE:     "投票",
+	pb.SkillType_SKILL_TYPE_SPEAK:    "发言",
+	pb.SkillType_SKILL_TYPE_SHOOT:    "开枪",
+	pb.SkillType_SKILL_TYPE_SKIP:     "放弃",
+}
+
+// campNames 阵营中文名
+var campNames = map[pb.Camp]string{
+	pb.Camp_CAMP_GOOD: "好人阵营",
+	pb.Camp_CAMP_EVIL: "狼人阵营",
+}
+
+func roleName(role pb.RoleType) string {
+	if name, ok := roleNames[role]; ok {
+		return name
+	}
+	return role.String()
+}
+
+func skillName(skill pb.SkillType) string {
+	if name, ok := skillNames[skill]; ok {
+		return name
+	}
+	return skill.String()
+}
+
+// describeRoles 角色列表描述（如 "狼人×2 预言家 女巫 村民×2"）
+func describeRoles(roles []pb.RoleType) string {
+	counts := make(map[pb.RoleType]int)
+	var order []pb.RoleType
+	for _, role := range roles {
+		if counts[role] == 0 {
+			order = append(order, role)
+		}
+		counts[role]++
+	}
+
+	parts := make([]string, 0, len(order))
+	for _, role := range order {
+		if counts[role] > 1 {
+			parts = append(parts, fmt.Sprintf("%s×%d", roleName(role), counts[role]))
+		} else {
+			parts = append(parts, roleName(role))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// phaseTitle 阶段标题
+func phaseTitle(phase pb.PhaseType, round int) string {
+	switch phase {
+	case pb.PhaseType_PHASE_TYPE_NIGHT_GUARD:
+		return fmt.Sprintf("第 %d 夜 · 守卫请睁眼", round)
+	case pb.PhaseType_PHASE_TYPE_NIGHT_WOLF:
+		return fmt.Sprintf("第 %d 夜 · 狼人请睁眼", round)
+	case pb.PhaseType_PHASE_TYPE_NIGHT_WITCH:
+		return fmt.Sprintf("第 %d 夜 · 女巫请睁眼", round)
+	case pb.PhaseType_PHASE_TYPE_NIGHT_SEER:
+		return fmt.Sprintf("第 %d 夜 · 预言家请睁眼", round)
+	case pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE:
+		return fmt.Sprintf("第 %d 天 · 天亮了", round)
+	case pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER, pb.PhaseType_PHASE_TYPE_DAY_HUNTER:
+		return fmt.Sprintf("第 %d 天 · 猎人发动技能", round)
+	case pb.PhaseType_PHASE_TYPE_DAY:
+		return fmt.Sprintf("第 %d 天 · 自由发言", round)
+	case pb.PhaseType_PHASE_TYPE_VOTE:
+		return fmt.Sprintf("第 %d 天 · 放逐投票", round)
+	default:
+		return phase.String()
+	}
+}
+
+// describeEvent 事件的叙述文本（name 将玩家ID转换为显示名称）
+// 返回空字符串表示该事件不需要叙述
+func describeEvent(event *pb.Event, name func(string) string) string {
+	switch event.Type {
+	case pb.EventType_EVENT_TYPE_POISON:
+		// 夜晚死亡统一由夜晚总结叙述，这里只叙述女巫的行动
+		if event.SourceId != "" {
+			return fmt.Sprintf("%s 对 %s 使用了毒药", name(event.SourceId), name(event.TargetId))
+		}
+	case pb.EventType_EVENT_TYPE_PROTECT:
+		return fmt.Sprintf("%s 守护了 %s", name(event.SourceId), name(event.TargetId))
+	case pb.EventType_EVENT_TYPE_SAVE:
+		return fmt.Sprintf("%s 对 %s 使用了解药", name(event.SourceId), name(event.TargetId))
+	case pb.EventType_EVENT_TYPE_CHECK:
+		result := "狼人"
+		if event.GetCheck().GetIsGood() {
+			result = "好人"
+		}
+		return fmt.Sprintf("%s 查验 %s：%s", name(event.SourceId), name(event.TargetId), result)
+	case pb.EventType_EVENT_TYPE_VOTE_RESULT:
+		return describeVoteResult(event.GetVoteResult(), name)
+	case pb.EventType_EVENT_TYPE_WOLF_TALLY:
+		return describeWolfTally(event.GetWolfTally(), name)
+	case pb.EventType_EVENT_TYPE_ELIMINATE:
+		return fmt.Sprintf("%s 被放逐出局", name(event.TargetId))
+	case pb.EventType_EVENT_TYPE_SHOOT:
+		return fmt.Sprintf("猎人 %s 开枪带走了 %s", name(event.SourceId), name(event.TargetId))
+	case pb.EventType_EVENT_TYPE_SKIP:
+		return fmt.Sprintf("%s 放弃了行动", name(event.SourceId))
+	case pb.EventType_EVENT_TYPE_GAME_ENDED:
+		winner := event.GetGameEnded().GetWinner()
+		return fmt.Sprintf("游戏结束，%s获胜", campNames[winner])
+	case pb.EventType_EVENT_TYPE_NIGHT_SUMMARY:
+		return describeNightSummary(event.GetNightSummary(), name)
+	}
+	return ""
+}
+
+// describeNightSummary 夜晚总结的叙述文本（昨夜死亡的玩家或平安夜）
+func describeNightSummary(summary *pb.NightSummaryEvent, name func(string) string) string {
+	if summary.GetPeaceful() || len(summary.GetDeadPlayerIds()) == 0 {
+		return "昨夜是平安夜"
+	}
+	names := make([]string, 0, len(summary.GetDeadPlayerIds()))
+	for _, id := range summary.GetDeadPlayerIds() {
+		names = append(names, name(id))
+	}
+	return fmt.Sprintf("昨夜 %s 倒牌了", strings.Join(names, "、"))
+}
+
+// describeVoteResult 投票结果的叙述文本（各玩家得票和弃票）
+func describeVoteResult(result *pb.VoteResultEvent, name func(string) string) string {
+	parts := make([]string, 0, len(result.GetTallies())+1)
+	for _, tally := range result.GetTallies() {
+		parts = append(parts, fmt.Sprintf("%s %d 票", name(tally.GetTargetId()), tally.GetVotes()))
+	}
+	if n := len(result.GetAbstainedIds()); n > 0 {
+		parts = append(parts, fmt.Sprintf("弃票 %d 人", n))
+	}
+
+	text := "投票结果：" + strings.Join(parts, "，")
+	if len(parts) == 0 {
+		text = "投票结果：无人投票"
+	}
+	if result.GetTied() || result.GetEliminatedId() == "" {
+		text += "。平票，无人出局"
+	}
+	return text
+}
+
+// describeWolfTally 狼人实时票型的叙述文本（每只狼人的选择）
+func describeWolfTally(tally *pb.WolfTallyEvent, name func(string) string) string {
+	parts := make([]string, 0, len(tally.GetPicks()))
+	for _, pick := range tally.GetPicks() {
+		target := "未选择"
+		if pick.GetTargetId() != "" {
+			target = name(pick.GetTargetId())
+		}
+		parts = append(parts, fmt.Sprintf("%s → %s", name(pick.GetWolfId()), target))
+	}
+	return "狼队票型：" + strings.Join(parts, "，")
+}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Zereker/werewolf"
+	"github.com/Zereker/werewolf/bot"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// errQuit 玩家输入 q 主动退出
+var errQuit = errors.New("quit")
+
+// clearScreenSeq 清屏（ANSI）
+const clearScreenSeq = "\033[H\033[2J"
+
+// session 一局终端游戏
+type session struct {
+	engine *werewolf.Engine
+	in     *bufio.Reader
+	out    io.Writer
+	opts   options
+	driver *bot.Driver
+
+	players []string        // 按座位号排列的玩家ID
+	humans  map[string]bool // 真人玩家
+	god     bool            // 上帝视角
+
+	pending []*pb.Event         // 本阶段产生、尚未叙述的事件
+	notes   map[string][]string // 每个玩家的私密信息（查验结果等）
+}
+
+func newSession(in *bufio.Reader, out io.Writer, opts options) *session {
+	return &session{
+		in:     in,
+		out:    out,
+		opts:   opts,
+		humans: make(map[string]bool),
+		god:    opts.god,
+		notes:  make(map[string][]string),
+	}
+}
+
+// run 通过大厅入座、发牌，然后驱动整局游戏
+func (s *session) run() error {
+	engine, err := werewolf.NewEngine(nil)
+	if err != nil {
+		return err
+	}
+	s.engine = engine
+	s.engine.SetSeed(s.opts.seed)
+	s.driver = bot.NewDriver(s.engine)
+	if err := s.engine.SetBoard("", s.opts.board); err != nil {
+		return err
+	}
+
+	for seat := 1; seat <= s.opts.board.Size(); seat++ {
+		id := fmt.Sprintf("p%d", seat)
+		if _, err := s.engine.Join(id); err != nil {
+			return err
+		}
+		if err := s.engine.SetReady(id, true); err != nil {
+			return err
+		}
+		s.players = append(s.players, id)
+		if s.opts.humanSeats[seat] {
+			s.humans[id] = true
+		} else {
+			s.driver.Add(id, bot.NewStrategyAgent(id, s.opts.seed+int64(seat)))
+		}
+	}
+
+	s.engine.OnEvent(func(event *pb.Event) {
+		s.pending = append(s.pending, event)
+	})
+	s.engine.OnMessage(s.relay)
+
+	if err := s.engine.Start(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(s.out, "\n板子 %s：%s\n", s.opts.board.Name, describeRoles(s.opts.board.Roles))
+	if s.god {
+		s.printGodView()
+	}
+
+	for !s.engine.IsGameOver() {
+		info := s.engine.GetPhaseInfo()
+		if len(bot.Actors(s.engine)) > 0 || info.Phase == pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE {
+			fmt.Fprintf(s.out, "\n=== %s ===\n", phaseTitle(info.Phase, info.Round))
+		}
+
+		if err := s.playPhase(); err != nil {
+			return err
+		}
+		if _, err := s.engine.EndSubStep(); err != nil {
+			return err
+		}
+		s.narrate()
+	}
+
+	fmt.Fprintln(s.out, "\n身份公布：")
+	for _, id := range s.players {
+		view, _ := s.engine.GetPlayerView(id)
+		fmt.Fprintf(s.out, "  %s：%s\n", s.name(id), roleName(view.Role))
+	}
+	return nil
+}
+
+// playPhase 依座位号让本阶段的行动玩家依次行动
+func (s *session) playPhase() error {
+	for _, id := range bot.Actors(s.engine) {
+		view, err := s.engine.GetPlayerView(id)
+		if err != nil {
+			return err
+		}
+		if len(view.AllowedSkills) == 0 {
+			continue
+		}
+
+		if !s.humans[id] {
+			if err := s.driver.Prompt(id); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.humanTurn(view); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relay 转述消息：白天发言公开打印，狼人夜间交流记入狼人的笔记
+func (s *session) relay(msg *werewolf.Message, receiverIDs []string) {
+	if msg.Phase == pb.PhaseType_PHASE_TYPE_DAY {
+		fmt.Fprintf(s.out, "  %s：%s\n", s.name(msg.SenderID), msg.Content)
+		return
+	}
+
+	text := fmt.Sprintf("%s（狼人频道）：%s", s.name(msg.SenderID), msg.Content)
+	for _, id := range receiverIDs {
+		s.notes[id] = append(s.notes[id], text)
+	}
+	if s.god {
+		fmt.Fprintf(s.out, "  [上帝] %s\n", text)
+	}
+}
+
+// humanTurn 真人玩家回合
+// 白天发言和投票直接提示；夜晚和猎人技能需要传递设备并在结束后清屏
+func (s *session) humanTurn(view *pb.PlayerView) error {
+	id := view.PlayerId
+
+	switch view.Phase {
+	case pb.PhaseType_PHASE_TYPE_DAY:
+		line, err := s.prompt(fmt.Sprintf("%s 发言（直接回车跳过）: ", s.name(id)))
+		if err != nil {
+			return err
+		}
+		if line != "" {
+			return s.engine.SendMessage(id, line)
+		}
+		return nil
+
+	case pb.PhaseType_PHASE_TYPE_VOTE:
+		return s.chooseAndSubmit(view, pb.SkillType_SKILL_TYPE_VOTE)
+	}
+
+	if _, err := s.prompt(fmt.Sprintf("请把设备交给 %s，准备好后按回车", s.name(id))); err != nil {
+		return err
+	}
+	s.printPrivate(view)
+
+	skills := append([]pb.SkillType(nil), view.AllowedSkills...)
+	for len(skills) > 0 {
+		fmt.Fprintln(s.out, "可用技能：")
+		for i, skill := range skills {
+			fmt.Fprintf(s.out, "  %d) %s\n", i+1, skillName(skill))
+		}
+		choice, err := s.promptChoice("选择技能（0 结束）: ", len(skills))
+		if err != nil {
+			return err
+		}
+		if choice == 0 {
+			break
+		}
+
+		skill := skills[choice-1]
+		if err := s.chooseAndSubmit(view, skill); err != nil {
+			return err
+		}
+		skills = append(skills[:choice-1], skills[choice:]...)
+	}
+
+	if _, err := s.prompt("行动结束，按回车后交还设备"); err != nil {
+		return err
+	}
+	if s.opts.clearScreen {
+		fmt.Fprint(s.out, clearScreenSeq)
+	}
+	return nil
+}
+
+// chooseAndSubmit 选择目标并提交技能，引擎拒绝时重新选择
+func (s *session) chooseAndSubmit(view *pb.PlayerView, skill pb.SkillType) error {
+	for {
+		use := &werewolf.SkillUse{PlayerID: view.PlayerId, Skill: skill}
+
+		switch skill {
+		case pb.SkillType_SKILL_TYPE_SKIP:
+		case pb.SkillType_SKILL_TYPE_ANTIDOTE:
+			if view.NightKillTarget == "" {
+				fmt.Fprintln(s.out, "今晚没有人被杀，无需使用解药")
+				return nil
+			}
+			use.TargetID = view.NightKillTarget
+		default:
+			target, err := s.promptTarget(fmt.Sprintf("%s 目标座位号（0 取消）: ", skillName(skill)))
+			if err != nil {
+				return err
+			}
+			if target == "" {
+				return nil
+			}
+			use.TargetID = target
+		}
+
+		if err := s.engine.SubmitSkillUse(use); err != nil {
+			fmt.Fprintf(s.out, "无效操作：%v\n", err)
+			if use.TargetID == "" || skill == pb.SkillType_SKILL_TYPE_ANTIDOTE {
+				return nil
+			}
+			continue
+		}
+		fmt.Fprintf(s.out, "已提交：%s %s\n", skillName(skill), s.name(use.TargetID))
+		return nil
+	}
+}
+
+// printPrivate 打印玩家的私密信息
+func (s *session) printPrivate(view *pb.PlayerView) {
+	fmt.Fprintf(s.out, "你是 %s，身份：%s\n", s.name(view.PlayerId), roleName(view.Role))
+	if len(view.Teammates) > 0 {
+		names := make([]string, len(view.Teammates))
+		for i, id := range view.Teammates {
+			names[i] = s.name(id)
+		}
+		fmt.Fprintf(s.out, "狼人队友：%s\n", strings.Join(names, "、"))
+	}
+	if view.Role == pb.RoleType_ROLE_TYPE_WITCH {
+		fmt.Fprintf(s.out, "解药：%s，毒药：%s\n", have(view.HasAntidote), have(view.HasPoison))
+		if view.NightKillTarget != "" {
+			fmt.Fprintf(s.out, "今晚 %s 被杀\n", s.name(view.NightKillTarget))
+		}
+	}
+	for _, note := range s.notes[view.PlayerId] {
+		fmt.Fprintf(s.out, "  · %s\n", note)
+	}
+}
+
+// printGodView 打印上帝视角：所有玩家身份和存活状态
+func (s *session) printGodView() {
+	fmt.Fprintln(s.out, "[上帝视角]")
+	for _, id := range s.players {
+		info, _ := s.engine.GetPlayerInfo(id)
+		status := "存活"
+		if !info.Alive {
+			status = "出局"
+		}
+		fmt.Fprintf(s.out, "  %s：%s（%s）\n", s.name(id), roleName(info.Role), status)
+	}
+	if target := s.engine.GetNightKillTarget(); target != "" {
+		fmt.Fprintf(s.out, "  今晚刀口：%s\n", s.name(target))
+	}
+}
+
+// narrate 叙述本阶段产生的事件
+// 公开事件直接打印；私密事件记入可见玩家的笔记，上帝视角下一并打印
+func (s *session) narrate() {
+	events := s.pending
+	s.pending = nil
+
+	for _, event := range events {
+		text := describeEvent(event, s.name)
+		if text == "" {
+			continue
+		}
+
+		var viewers []string
+		for _, id := range s.players {
+			if s.engine.IsEventVisibleTo(event, id) {
+				viewers = append(viewers, id)
+			}
+		}
+		if len(viewers) == len(s.players) {
+			fmt.Fprintf(s.out, "  %s\n", text)
+			continue
+		}
+		for _, id := range viewers {
+			s.notes[id] = append(s.notes[id], text)
+		}
+		if s.god {
+			fmt.Fprintf(s.out, "  [上帝] %s\n", text)
+		}
+	}
+}
+
+// ==================== 输入 ====================
+
+// prompt 读取一行输入；g 切换上帝视角后重新提示，q 退出
+func (s *session) prompt(text string) (string, error) {
+	for {
+		fmt.Fprint(s.out, text)
+		line, err := readLine(s.in)
+		if err != nil {
+			return "", err
+		}
+		switch line {
+		case "g":
+			s.god = !s.god
+			if s.god {
+				s.printGodView()
+			} else {
+				fmt.Fprintln(s.out, "[上帝视角已关闭]")
+			}
+			continue
+		case "q":
+			return "", errQuit
+		}
+		return line, nil
+	}
+}
+
+// promptChoice 读取 0..max 的编号，直接回车视为 0
+func (s *session) promptChoice(text string, max int) (int, error) {
+	for {
+		line, err := s.prompt(text)
+		if err != nil {
+			return 0, err
+		}
+		if line == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(line)
+		if err == nil && n >= 0 && n <= max {
+			return n, nil
+		}
+		fmt.Fprintf(s.out, "请输入 0-%d\n", max)
+	}
+}
+
+// promptTarget 读取目标座位号，0 或直接回车表示取消
+func (s *session) promptTarget(text string) (string, error) {
+	var alive []string
+	for _, id := range s.players {
+		if info, _ := s.engine.GetPlayerInfo(id); info.Alive {
+			alive = append(alive, s.name(id))
+		}
+	}
+	fmt.Fprintf(s.out, "存活玩家：%s\n", strings.Join(alive, " "))
+
+	seat, err := s.promptChoice(text, len(s.players))
+	if err != nil || seat == 0 {
+		return "", err
+	}
+	return s.players[seat-1], nil
+}
+
+// ==================== 工具函数 ====================
+
+// seat 玩家座位号
+func (s *session) seat(id string) int {
+	for i, p := range s.players {
+		if p == id {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// name 玩家显示名称（如 "3号"）
+func (s *session) name(id string) string {
+	if seat := s.seat(id); seat > 0 {
+		return fmt.Sprintf("%d号", seat)
+	}
+	return id
+}
+
+func have(ok bool) string {
+	if ok {
+		return "有"
+	}
+	return "无"
+}
+
+// readLine 读取一行并去除首尾空白
+func readLine(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// promptNumber 读取 min..max 的编号（用于开局设置）
+func promptNumber(in *bufio.Reader, out io.Writer, text string, min, max int) (int, error) {
+	for {
+		fmt.Fprint(out, text)
+		line, err := readLine(in)
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(line)
+		if err == nil && n >= min && n <= max {
+			return n, nil
+		}
+		fmt.Fprintf(out, "请输入 %d-%d\n", min, max)
+	}
+}
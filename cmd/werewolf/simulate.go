@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Zereker/werewolf"
+	"github.com/Zereker/werewolf/simulation"
+)
+
+// runSimulate simulate 子命令：批量模拟对局并输出 CSV/JSON 报告
+//
+//	werewolf simulate [-games 1000] [-boards 6p-basic,9p-standard] \
+//	    [-rules "default;same-guard-kill-empty=false"] [-seed 1] [-workers 0] [-format csv|json]
+func runSimulate(ctx context.Context, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	fs.SetOutput(out)
+	games := fs.Int("games", 1000, "每个场景的对局数")
+	boards := fs.String("boards", "", "逗号分隔的板子名称（为空时使用所有预设板子）")
+	rules := fs.String("rules", "default", "分号分隔的规则变体，每个变体为逗号分隔的 name=bool")
+	seed := fs.Int64("seed", 1, "起始随机种子")
+	workers := fs.Int("workers", 0, "并行数（0 表示 CPU 核数）")
+	maxRounds := fs.Int("max-rounds", simulation.DefaultMaxRounds, "单局最大回合数")
+	format := fs.String("format", "csv", "输出格式：csv 或 json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown format %q (expected csv or json)", *format)
+	}
+	if *games <= 0 {
+		return fmt.Errorf("games must be positive, got %d", *games)
+	}
+
+	scenarios, err := parseScenarios(*boards, *rules)
+	if err != nil {
+		return err
+	}
+
+	reports, err := simulation.Run(ctx, scenarios, simulation.Config{
+		Games:     *games,
+		Seed:      *seed,
+		Workers:   *workers,
+		MaxRounds: *maxRounds,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		return simulation.WriteJSON(out, reports)
+	}
+	return simulation.WriteCSV(out, reports)
+}
+
+// parseScenarios 组合板子和规则变体生成场景
+func parseScenarios(boardSpec, rulesSpec string) ([]simulation.Scenario, error) {
+	var boards []werewolf.Board
+	if strings.TrimSpace(boardSpec) == "" {
+		boards = werewolf.PresetBoards()
+	} else {
+		for _, name := range strings.Split(boardSpec, ",") {
+			board, ok := werewolf.LookupBoard(strings.TrimSpace(name))
+			if !ok {
+				return nil, fmt.Errorf("unknown board %q", name)
+			}
+			boards = append(boards, board)
+		}
+	}
+
+	var variants []werewolf.Rules
+	for _, spec := range strings.Split(rulesSpec, ";") {
+		rules, err := simulation.ParseRules(spec)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, rules)
+	}
+
+	var scenarios []simulation.Scenario
+	for _, board := range boards {
+		for _, rules := range variants {
+			scenarios = append(scenarios, simulation.NewScenario(board, rules))
+		}
+	}
+	return scenarios, nil
+}
@@ -1,6 +1,9 @@
 package werewolf
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	pb "github.com/Zereker/werewolf/proto"
@@ -23,6 +26,10 @@ type GameConfig struct {
 	GuardCanRepeat       bool // 守卫能否连续守同一人
 	SameGuardKillIsEmpty bool // 同守同杀是否空刀
 
+	// 狼人刀人规则
+	WolfKillUnanimous bool          // 狼人必须全票一致才能击杀（否则空刀）
+	WolfTiePolicy     WolfTiePolicy // 狼人刀人平票时的处理方式
+
 	// 阶段配置
 	Phases map[pb.PhaseType]*PhaseConfig
 
@@ -30,12 +37,79 @@ type GameConfig struct {
 	DefaultTimeout time.Duration
 }
 
+// WolfTiePolicy 狼人刀人平票时的处理方式
+type WolfTiePolicy int
+
+const (
+	WolfTieNoKill   WolfTiePolicy = iota // 空刀（默认）
+	WolfTieRandom                        // 在平票目标中随机选择
+	WolfTieLeadWolf                      // 由狼队长（座位号最小的存活狼人）决定，其选择不在平票目标中时空刀
+)
+
+var wolfTiePolicyNames = map[WolfTiePolicy]string{
+	WolfTieNoKill:   "no_kill",
+	WolfTieRandom:   "random",
+	WolfTieLeadWolf: "lead_wolf",
+}
+
+// String 策略名称（no_kill、random、lead_wolf）
+func (p WolfTiePolicy) String() string {
+	if name, ok := wolfTiePolicyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("WolfTiePolicy(%d)", int(p))
+}
+
+// ParseWolfTiePolicy 按名称解析平票策略
+func ParseWolfTiePolicy(name string) (WolfTiePolicy, bool) {
+	for policy, n := range wolfTiePolicyNames {
+		if n == name {
+			return policy, true
+		}
+	}
+	return WolfTieNoKill, false
+}
+
+// Rules 规则变体（可由房主在大厅中选择）
+type Rules struct {
+	WitchCanSaveSelf     bool // 女巫能否自救
+	GuardCanProtectSelf  bool // 守卫能否自守
+	GuardCanRepeat       bool // 守卫能否连续守同一人
+	SameGuardKillIsEmpty bool // 同守同杀是否空刀
+
+	WolfKillUnanimous bool          // 狼人必须全票一致才能击杀
+	WolfTiePolicy     WolfTiePolicy // 狼人刀人平票时的处理方式
+}
+
+// GetRules 获取配置中的规则变体
+func (c *GameConfig) GetRules() Rules {
+	return Rules{
+		WitchCanSaveSelf:     c.WitchCanSaveSelf,
+		GuardCanProtectSelf:  c.GuardCanProtectSelf,
+		GuardCanRepeat:       c.GuardCanRepeat,
+		SameGuardKillIsEmpty: c.SameGuardKillIsEmpty,
+		WolfKillUnanimous:    c.WolfKillUnanimous,
+		WolfTiePolicy:        c.WolfTiePolicy,
+	}
+}
+
+// ApplyRules 应用规则变体
+func (c *GameConfig) ApplyRules(rules Rules) {
+	c.WitchCanSaveSelf = rules.WitchCanSaveSelf
+	c.GuardCanProtectSelf = rules.GuardCanProtectSelf
+	c.GuardCanRepeat = rules.GuardCanRepeat
+	c.SameGuardKillIsEmpty = rules.SameGuardKillIsEmpty
+	c.WolfKillUnanimous = rules.WolfKillUnanimous
+	c.WolfTiePolicy = rules.WolfTiePolicy
+}
+
 // PhaseConfig 阶段配置
 type PhaseConfig struct {
-	Type      pb.PhaseType  // 阶段类型
-	Steps     []PhaseStep   // 步骤列表
-	Timeout   time.Duration // 超时时间
-	NextPhase pb.PhaseType  // 下一阶段（声明式配置）
+	Type        pb.PhaseType  // 阶段类型
+	Steps       []PhaseStep   // 步骤列表
+	Timeout     time.Duration // 超时时间
+	NextPhase   pb.PhaseType  // 下一阶段（声明式配置）
+	Transitions []Transition  // 条件转换（按顺序检查，优先于 NextPhase）
 }
 
 // PhaseStep 阶段步骤
@@ -77,19 +151,8 @@ func DefaultGameConfig() *GameConfig {
 		GuardCanRepeat:       false,
 		SameGuardKillIsEmpty: true,
 		DefaultTimeout:       DefaultPhaseTimeout,
-		Phases: map[pb.PhaseType]*PhaseConfig{
-			// 白天和投票阶段
-			pb.PhaseType_PHASE_TYPE_DAY:        StandardDayPhase(),
-			pb.PhaseType_PHASE_TYPE_VOTE:       StandardVotePhase(),
-			pb.PhaseType_PHASE_TYPE_DAY_HUNTER: DayHunterPhase(),
-			// 夜晚子阶段
-			pb.PhaseType_PHASE_TYPE_NIGHT_GUARD:   NightGuardPhase(),
-			pb.PhaseType_PHASE_TYPE_NIGHT_WOLF:    NightWolfPhase(),
-			pb.PhaseType_PHASE_TYPE_NIGHT_WITCH:   NightWitchPhase(),
-			pb.PhaseType_PHASE_TYPE_NIGHT_SEER:    NightSeerPhase(),
-			pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE: NightResolvePhase(),
-			pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER:  NightHunterPhase(),
-		},
+		// 公共阶段加上已注册角色带来的阶段
+		Phases: registeredPhases(),
 	}
 }
 
@@ -117,6 +180,10 @@ func StandardVotePhase() *PhaseConfig {
 		},
 		Timeout:   VotePhaseTimeout,
 		NextPhase: pb.PhaseType_PHASE_TYPE_NIGHT_GUARD, // 进入下一夜
+		Transitions: []Transition{
+			// 被投票出局的是猎人时先让猎人开枪
+			{When: ConditionHunterTriggered, Target: pb.PhaseType_PHASE_TYPE_DAY_HUNTER},
+		},
 	}
 }
 
@@ -194,7 +261,11 @@ func NightResolvePhase() *PhaseConfig {
 			{Role: pb.RoleType_ROLE_TYPE_GOD, Skill: pb.SkillType_SKILL_TYPE_ANNOUNCE, Order: 0, Required: true},
 		},
 		Timeout:   NightPhaseTimeout,
-		NextPhase: pb.PhaseType_PHASE_TYPE_DAY, // 默认进入白天，如有猎人死亡则动态改为猎人阶段
+		NextPhase: pb.PhaseType_PHASE_TYPE_DAY, // 默认进入白天
+		Transitions: []Transition{
+			// 夜晚有猎人死亡时先进入猎人阶段
+			{When: ConditionHunterTriggered, Target: pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER},
+		},
 	}
 }
 
@@ -210,3 +281,237 @@ func NightHunterPhase() *PhaseConfig {
 		NextPhase: pb.PhaseType_PHASE_TYPE_DAY,
 	}
 }
+
+// FirstPhase 游戏开始后进入的第一个阶段（START 之后的入口）
+const FirstPhase = pb.PhaseType_PHASE_TYPE_NIGHT_GUARD
+
+// Validate 静态检查阶段图
+// 检查入口阶段已配置、每个阶段的 NextPhase 和条件转换指向已配置的阶段、条件已注册、
+// 所有阶段都能从 START 到达并沿 NextPhase 回到夜晚、步骤引用的角色和技能有效、有玩家步骤的阶段注册了解析器；
+// 条件转换的目标阶段（如猎人阶段）同样需要有效出口。
+// 返回的错误码为 ERROR_CODE_INVALID_CONFIG，消息中列出所有问题
+func (c *GameConfig) Validate() error {
+	var problems []string
+	addf := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.DefaultTimeout < 0 {
+		addf("default timeout must not be negative, got %v", c.DefaultTimeout)
+	}
+	if _, ok := wolfTiePolicyNames[c.WolfTiePolicy]; !ok {
+		addf("unknown wolf tie policy %v", c.WolfTiePolicy)
+	}
+	if c.Phases[FirstPhase] == nil {
+		addf("first phase %s is not configured", phaseName(FirstPhase))
+		return invalidConfig(problems)
+	}
+
+	resolvers := registeredResolvers()
+	for _, phase := range c.sortedPhases() {
+		config := c.Phases[phase]
+		if config == nil {
+			addf("phase %s has no config", phaseName(phase))
+			continue
+		}
+		if config.Type != phase {
+			addf("phase %s: type is %s", phaseName(phase), phaseName(config.Type))
+		}
+		if config.Timeout < 0 {
+			addf("phase %s: timeout must not be negative, got %v", phaseName(phase), config.Timeout)
+		}
+
+		switch {
+		case config.NextPhase == pb.PhaseType_PHASE_TYPE_UNSPECIFIED:
+			addf("phase %s: next phase is not set", phaseName(phase))
+		case config.NextPhase == pb.PhaseType_PHASE_TYPE_END:
+			addf("phase %s: next phase must not be END (the game ends through the victory check)", phaseName(phase))
+		case c.Phases[config.NextPhase] == nil:
+			addf("phase %s: next phase %s is not configured", phaseName(phase), phaseName(config.NextPhase))
+		}
+
+		for i, transition := range config.Transitions {
+			if _, ok := LookupCondition(transition.When); !ok {
+				addf("phase %s transition %d: unknown condition %q", phaseName(phase), i, transition.When)
+			}
+			if c.Phases[transition.Target] == nil {
+				addf("phase %s transition %d: target %s is not configured", phaseName(phase), i, phaseName(transition.Target))
+			}
+		}
+
+		hasPlayerStep := false
+		for i, step := range config.Steps {
+			if _, ok := pb.SkillType_name[int32(step.Skill)]; !ok || step.Skill == pb.SkillType_SKILL_TYPE_UNSPECIFIED {
+				addf("phase %s step %d: unknown skill %d", phaseName(phase), i, step.Skill)
+			}
+			// 所有玩家和上帝步骤之外，角色必须已注册且拥有该技能
+			if step.Role != pb.RoleType_ROLE_TYPE_UNSPECIFIED && step.Role != pb.RoleType_ROLE_TYPE_GOD {
+				if def, ok := LookupRole(step.Role); !ok {
+					addf("phase %s step %d: unknown role %s", phaseName(phase), i, roleName(step.Role))
+				} else if !def.hasSkill(step.Skill) {
+					addf("phase %s step %d: role %s has no skill %s", phaseName(phase), i, roleName(step.Role), skillName(step.Skill))
+				}
+			}
+			if step.Role != pb.RoleType_ROLE_TYPE_GOD {
+				hasPlayerStep = true
+			}
+		}
+		if hasPlayerStep && resolvers[phase] == nil {
+			addf("phase %s has player steps but no resolver", phaseName(phase))
+		}
+	}
+	if len(problems) > 0 {
+		return invalidConfig(problems)
+	}
+
+	// 图检查：从入口可达，且每个可达阶段沿 NextPhase 都能回到入口（形成昼夜循环）
+	reachable := c.reachableFrom(FirstPhase)
+	reachable[FirstPhase] = true
+	for _, phase := range c.sortedPhases() {
+		if !reachable[phase] {
+			addf("phase %s is unreachable from START", phaseName(phase))
+		} else if !c.loopsBack(phase) {
+			addf("phase %s never loops back to %s", phaseName(phase), phaseName(FirstPhase))
+		}
+	}
+	return invalidConfig(problems)
+}
+
+// ValidateBoard 检查板子与配置是否匹配
+// 板子上的角色必须已注册（见 RegisterRole），有行动阶段的角色（村民之外）都要在某个阶段有行动步骤；
+// 角色依赖的转换条件（如猎人的 hunter_triggered）必须在配置中使用。
+// 配置中引用了但板子上没有的角色（如 6 人局的守卫）在对局中会被跳过，不视为错误
+func (c *GameConfig) ValidateBoard(board Board) error {
+	var problems []string
+	addf := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	acting := make(map[pb.RoleType]bool)
+	for _, config := range c.Phases {
+		if config == nil {
+			continue
+		}
+		for _, step := range config.Steps {
+			acting[step.Role] = true
+		}
+	}
+
+	seen := make(map[pb.RoleType]bool)
+	for _, role := range board.Roles {
+		if seen[role] {
+			continue
+		}
+		seen[role] = true
+
+		def, ok := LookupRole(role)
+		if !ok {
+			addf("board role %s cannot be dealt", roleName(role))
+			continue
+		}
+		if len(def.phases) > 0 && !acting[role] {
+			addf("board role %s has no phase step", roleName(role))
+		}
+		// 如猎人死亡后需要通过 hunter_triggered 条件转换进入开枪阶段
+		for _, condition := range def.Conditions {
+			if !c.usesCondition(condition) {
+				addf("board role %s needs a transition on condition %s", roleName(role), condition)
+			}
+		}
+	}
+	return invalidConfig(problems)
+}
+
+// reachableFrom 沿 NextPhase 和条件转换计算至少一步可达的已配置阶段
+func (c *GameConfig) reachableFrom(start pb.PhaseType) map[pb.PhaseType]bool {
+	reached := make(map[pb.PhaseType]bool)
+	queue := c.successors(start)
+	for len(queue) > 0 {
+		phase := queue[0]
+		queue = queue[1:]
+		if reached[phase] {
+			continue
+		}
+		reached[phase] = true
+		queue = append(queue, c.successors(phase)...)
+	}
+	return reached
+}
+
+// loopsBack 沿 NextPhase（不考虑条件转换）能否回到入口阶段
+// 条件转换只在条件满足时发生，常规流程必须自行回到夜晚
+func (c *GameConfig) loopsBack(phase pb.PhaseType) bool {
+	visited := make(map[pb.PhaseType]bool)
+	for config := c.Phases[phase]; config != nil && !visited[config.Type]; config = c.Phases[config.NextPhase] {
+		if config.NextPhase == FirstPhase {
+			return true
+		}
+		visited[config.Type] = true
+	}
+	return false
+}
+
+// successors 阶段的后继（仅包含已配置的阶段）
+func (c *GameConfig) successors(phase pb.PhaseType) []pb.PhaseType {
+	var next []pb.PhaseType
+	if config := c.Phases[phase]; config != nil && c.Phases[config.NextPhase] != nil {
+		next = append(next, config.NextPhase)
+	}
+	if config := c.Phases[phase]; config != nil {
+		for _, transition := range config.Transitions {
+			if c.Phases[transition.Target] != nil {
+				next = append(next, transition.Target)
+			}
+		}
+	}
+	return next
+}
+
+// usesCondition 是否有阶段使用了指定的转换条件
+func (c *GameConfig) usesCondition(name string) bool {
+	for _, config := range c.Phases {
+		if config == nil {
+			continue
+		}
+		for _, transition := range config.Transitions {
+			if transition.When == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortedPhases 按枚举值排序的已配置阶段（保证错误信息顺序稳定）
+func (c *GameConfig) sortedPhases() []pb.PhaseType {
+	return sortedKeys(c.Phases)
+}
+
+func sortedKeys[V any](m map[pb.PhaseType]V) []pb.PhaseType {
+	keys := make([]pb.PhaseType, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// invalidConfig 将问题列表合并为配置错误，没有问题时返回 nil
+func invalidConfig(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return WrapError(pb.ErrorCode_ERROR_CODE_INVALID_CONFIG, "invalid game config: %s", strings.Join(problems, "; "))
+}
+
+func phaseName(phase pb.PhaseType) string {
+	return strings.TrimPrefix(phase.String(), "PHASE_TYPE_")
+}
+
+func roleName(role pb.RoleType) string {
+	return strings.TrimPrefix(role.String(), "ROLE_TYPE_")
+}
+
+func skillName(skill pb.SkillType) string {
+	return strings.TrimPrefix(skill.String(), "SKILL_TYPE_")
+}
@@ -1,6 +1,7 @@
 package werewolf
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -135,3 +136,155 @@ func TestSkillUse_Fields(t *testing.T) {
 		t.Errorf("expected Round=1, got %d", use.Round)
 	}
 }
+
+func TestGameConfig_Validate_Default(t *testing.T) {
+	if err := DefaultGameConfig().Validate(); err != nil {
+		t.Errorf("expected default config to be valid, got %v", err)
+	}
+	for _, board := range PresetBoards() {
+		if err := DefaultGameConfig().ValidateBoard(board); err != nil {
+			t.Errorf("expected board %s to be valid, got %v", board.Name, err)
+		}
+	}
+}
+
+func TestGameConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *GameConfig)
+		want   string
+	}{
+		{
+			name:   "missing first phase",
+			modify: func(c *GameConfig) { delete(c.Phases, pb.PhaseType_PHASE_TYPE_NIGHT_GUARD) },
+			want:   "first phase NIGHT_GUARD is not configured",
+		},
+		{
+			name: "next phase not set",
+			modify: func(c *GameConfig) {
+				c.Phases[pb.PhaseType_PHASE_TYPE_DAY].NextPhase = pb.PhaseType_PHASE_TYPE_UNSPECIFIED
+			},
+			want: "phase DAY: next phase is not set",
+		},
+		{
+			name:   "next phase is end",
+			modify: func(c *GameConfig) { c.Phases[pb.PhaseType_PHASE_TYPE_VOTE].NextPhase = pb.PhaseType_PHASE_TYPE_END },
+			want:   "phase VOTE: next phase must not be END",
+		},
+		{
+			name: "next phase not configured",
+			modify: func(c *GameConfig) {
+				c.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_WOLF].NextPhase = pb.PhaseType_PHASE_TYPE_NIGHT
+			},
+			want: "phase NIGHT_WOLF: next phase NIGHT is not configured",
+		},
+		{
+			name: "mismatched type",
+			modify: func(c *GameConfig) {
+				c.Phases[pb.PhaseType_PHASE_TYPE_DAY].Type = pb.PhaseType_PHASE_TYPE_VOTE
+			},
+			want: "phase DAY: type is VOTE",
+		},
+		{
+			name: "unknown skill",
+			modify: func(c *GameConfig) {
+				c.Phases[pb.PhaseType_PHASE_TYPE_DAY].Steps[1].Skill = pb.SkillType_SKILL_TYPE_UNSPECIFIED
+			},
+			want: "phase DAY step 1: unknown skill 0",
+		},
+		{
+			name: "player steps without resolver",
+			modify: func(c *GameConfig) {
+				c.Phases[pb.PhaseType_PHASE_TYPE_NIGHT] = &PhaseConfig{
+					Type:      pb.PhaseType_PHASE_TYPE_NIGHT,
+					Steps:     []PhaseStep{{Role: pb.RoleType_ROLE_TYPE_WEREWOLF, Skill: pb.SkillType_SKILL_TYPE_KILL}},
+					NextPhase: pb.PhaseType_PHASE_TYPE_DAY,
+				}
+			},
+			want: "phase NIGHT has player steps but no resolver",
+		},
+		{
+			name: "unknown condition",
+			modify: func(c *GameConfig) {
+				c.Phases[pb.PhaseType_PHASE_TYPE_VOTE].Transitions[0].When = "sheriff_died"
+			},
+			want: `phase VOTE transition 0: unknown condition "sheriff_died"`,
+		},
+		{
+			name: "transition target not configured",
+			modify: func(c *GameConfig) {
+				delete(c.Phases, pb.PhaseType_PHASE_TYPE_DAY_HUNTER)
+			},
+			want: "phase VOTE transition 0: target DAY_HUNTER is not configured",
+		},
+		{
+			name: "unreachable phase",
+			modify: func(c *GameConfig) {
+				c.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_WITCH].NextPhase = pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE
+			},
+			want: "phase NIGHT_SEER is unreachable from START",
+		},
+		{
+			name: "no loop back to night",
+			modify: func(c *GameConfig) {
+				c.Phases[pb.PhaseType_PHASE_TYPE_VOTE].NextPhase = pb.PhaseType_PHASE_TYPE_DAY
+			},
+			want: "phase VOTE never loops back to NIGHT_GUARD",
+		},
+		{
+			name: "hunter phase without exit",
+			modify: func(c *GameConfig) {
+				c.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER].NextPhase = pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER
+			},
+			want: "phase NIGHT_HUNTER never loops back to NIGHT_GUARD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultGameConfig()
+			tt.modify(config)
+
+			err := config.Validate()
+			if !IsErrorCode(err, pb.ErrorCode_ERROR_CODE_INVALID_CONFIG) {
+				t.Fatalf("expected INVALID_CONFIG error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error to contain %q, got %q", tt.want, err.Error())
+			}
+		})
+	}
+}
+
+func TestGameConfig_ValidateBoard(t *testing.T) {
+	config := DefaultGameConfig()
+	delete(config.Phases, pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER)
+	delete(config.Phases, pb.PhaseType_PHASE_TYPE_DAY_HUNTER)
+	config.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE].Transitions = nil
+	config.Phases[pb.PhaseType_PHASE_TYPE_VOTE].Transitions = nil
+	if err := config.Validate(); err != nil {
+		t.Fatalf("expected config without hunter phases to be valid, got %v", err)
+	}
+
+	// 没有猎人的板子不需要猎人阶段
+	board, _ := LookupBoard(BoardSixPlayers)
+	if err := config.ValidateBoard(board); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	board, _ = LookupBoard(BoardNinePlayers)
+	err := config.ValidateBoard(board)
+	for _, want := range []string{
+		"board role HUNTER has no phase step",
+		"board role HUNTER needs a transition on condition hunter_triggered",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %v", want, err)
+		}
+	}
+
+	err = DefaultGameConfig().ValidateBoard(Board{Roles: []pb.RoleType{pb.RoleType_ROLE_TYPE_GOD}})
+	if err == nil || !strings.Contains(err.Error(), "board role GOD cannot be dealt") {
+		t.Errorf("expected GOD to be rejected, got %v", err)
+	}
+}
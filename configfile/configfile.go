@@ -0,0 +1,179 @@
+// Package configfile 提供游戏配置的声明式文件格式
+//
+// 支持 YAML 和 JSON 两种格式，覆盖规则变体、超时、阶段步骤（角色、技能、是否必须、是否多人）
+// 以及阶段之间的 NextPhase 链接和条件转换。加载时做结构校验，错误信息指出出错字段的路径
+// （如 phases[2].steps[0].skill）；Export 可将任意 GameConfig（如 DefaultGameConfig）导出为文件。
+//
+// 文件示例（YAML）：
+//
+//	rules:
+//	  guard_can_repeat: true
+//	default_timeout: 30s
+//	phases:
+//	  - type: NIGHT_GUARD
+//	    timeout: 15s
+//	    next_phase: NIGHT_WOLF
+//	    steps:
+//	      - {role: GOD, skill: ANNOUNCE, order: 0, required: true}
+//	      - {role: GUARD, skill: PROTECT, order: 1}
+//	  - type: VOTE
+//	    next_phase: NIGHT_GUARD
+//	    transitions:
+//	      - {when: hunter_triggered, target: DAY_HUNTER}
+//	    steps: [...]
+//
+// 省略的规则取默认值；省略 phases 时使用默认阶段配置，给出 phases 时完整替换。
+package configfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Zereker/werewolf"
+)
+
+// Format 文件格式
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// FormatFromPath 根据扩展名判断文件格式（.yaml/.yml/.json）
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported config file extension %q (expected .yaml, .yml or .json)", filepath.Ext(path))
+	}
+}
+
+// File 配置文件结构
+type File struct {
+	Rules          Rules   `json:"rules,omitempty" yaml:"rules,omitempty"`
+	DefaultTimeout string  `json:"default_timeout,omitempty" yaml:"default_timeout,omitempty"`
+	Phases         []Phase `json:"phases,omitempty" yaml:"phases,omitempty"`
+}
+
+// Rules 规则变体（省略的字段取默认值）
+type Rules struct {
+	WitchCanSaveSelf     *bool `json:"witch_can_save_self,omitempty" yaml:"witch_can_save_self,omitempty"`
+	GuardCanProtectSelf  *bool `json:"guard_can_protect_self,omitempty" yaml:"guard_can_protect_self,omitempty"`
+	GuardCanRepeat       *bool `json:"guard_can_repeat,omitempty" yaml:"guard_can_repeat,omitempty"`
+	SameGuardKillIsEmpty *bool `json:"same_guard_kill_is_empty,omitempty" yaml:"same_guard_kill_is_empty,omitempty"`
+
+	WolfKillUnanimous *bool  `json:"wolf_kill_unanimous,omitempty" yaml:"wolf_kill_unanimous,omitempty"`
+	WolfTiePolicy     string `json:"wolf_tie_policy,omitempty" yaml:"wolf_tie_policy,omitempty"` // no_kill、random、lead_wolf
+}
+
+// Phase 阶段配置
+type Phase struct {
+	Type        string       `json:"type" yaml:"type"`
+	Timeout     string       `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	NextPhase   string       `json:"next_phase" yaml:"next_phase"`
+	Transitions []Transition `json:"transitions,omitempty" yaml:"transitions,omitempty"`
+	Steps       []Step       `json:"steps" yaml:"steps"`
+}
+
+// Transition 条件转换，when 为已注册的条件名称（见 werewolf.ConditionNames）
+type Transition struct {
+	When   string `json:"when" yaml:"when"`
+	Target string `json:"target" yaml:"target"`
+}
+
+// Step 阶段步骤，省略 role 表示所有存活玩家
+type Step struct {
+	Role     string `json:"role,omitempty" yaml:"role,omitempty"`
+	Skill    string `json:"skill" yaml:"skill"`
+	Order    int    `json:"order" yaml:"order"`
+	Required bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	Multiple bool   `json:"multiple,omitempty" yaml:"multiple,omitempty"`
+}
+
+// Load 从文件加载游戏配置，格式由扩展名决定
+func Load(path string) (*werewolf.GameConfig, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Parse 解析并校验配置内容，未知字段视为错误，阶段图需通过 GameConfig.Validate
+func Parse(data []byte, format Format) (*werewolf.GameConfig, error) {
+	var file File
+
+	switch format {
+	case FormatYAML:
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&file); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	config, err := file.GameConfig()
+	if err != nil {
+		return nil, err
+	}
+	// 字段合法后再检查阶段图（可达性、回到夜晚、解析器等）
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Export 将游戏配置导出为文件内容
+func Export(config *werewolf.GameConfig, format Format) ([]byte, error) {
+	file := FromGameConfig(config)
+
+	switch format {
+	case FormatYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(file); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(file, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
@@ -0,0 +1,249 @@
+package configfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+func TestExport_RoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatYAML, FormatJSON} {
+		data, err := Export(werewolf.DefaultGameConfig(), format)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", format, err)
+		}
+		config, err := Parse(data, format)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", format, err)
+		}
+		if !reflect.DeepEqual(config, werewolf.DefaultGameConfig()) {
+			t.Errorf("%s: round trip changed config:\n%s", format, data)
+		}
+	}
+}
+
+func TestLoad_Testdata(t *testing.T) {
+	// testdata 中的文件由 Export(DefaultGameConfig()) 生成，默认配置变化时需要重新导出
+	for _, name := range []string{"default.yaml", "default.json"} {
+		path := filepath.Join("testdata", name)
+		config, err := Load(path)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+		if !reflect.DeepEqual(config, werewolf.DefaultGameConfig()) {
+			t.Errorf("%s: expected default config", name)
+		}
+
+		format, _ := FormatFromPath(path)
+		want, _ := Export(werewolf.DefaultGameConfig(), format)
+		got, _ := os.ReadFile(path)
+		if string(got) != string(want) {
+			t.Errorf("%s: out of date, regenerate with Export", name)
+		}
+	}
+}
+
+func TestParse_Overrides(t *testing.T) {
+	config, err := Parse([]byte(`
+rules:
+  guard_can_repeat: true
+  wolf_kill_unanimous: true
+  wolf_tie_policy: lead_wolf
+default_timeout: 45s
+`), FormatYAML)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := werewolf.DefaultGameConfig()
+	want.GuardCanRepeat = true
+	want.WolfKillUnanimous = true
+	want.WolfTiePolicy = werewolf.WolfTieLeadWolf
+	want.DefaultTimeout = 45 * time.Second
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("expected defaults with overrides, got %+v", config)
+	}
+}
+
+func TestParse_Phases(t *testing.T) {
+	config, err := Parse([]byte(`{
+  "phases": [
+    {"type": "NIGHT_GUARD", "next_phase": "PHASE_TYPE_NIGHT_WOLF", "steps": []},
+    {"type": "PHASE_TYPE_NIGHT_WOLF", "next_phase": "day", "steps": [
+      {"role": "WEREWOLF", "skill": "SKILL_TYPE_KILL", "order": 1, "required": true, "multiple": true}
+    ]},
+    {"type": "DAY", "timeout": "2m", "next_phase": "NIGHT_GUARD", "transitions": [
+      {"when": "first_round", "target": "NIGHT_GUARD"}
+    ], "steps": [
+      {"skill": "SPEAK", "order": 1, "multiple": true}
+    ]}
+  ]
+}`), FormatJSON)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(config.Phases) != 3 {
+		t.Fatalf("expected phases to be replaced, got %d", len(config.Phases))
+	}
+	wolf := config.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_WOLF]
+	if wolf.NextPhase != pb.PhaseType_PHASE_TYPE_DAY || wolf.Timeout != 0 {
+		t.Errorf("unexpected wolf phase %+v", wolf)
+	}
+	step := werewolf.PhaseStep{Role: pb.RoleType_ROLE_TYPE_WEREWOLF, Skill: pb.SkillType_SKILL_TYPE_KILL, Order: 1, Required: true, Multiple: true}
+	if len(wolf.Steps) != 1 || wolf.Steps[0] != step {
+		t.Errorf("unexpected wolf steps %+v", wolf.Steps)
+	}
+	day := config.Phases[pb.PhaseType_PHASE_TYPE_DAY]
+	if day.Timeout != 2*time.Minute {
+		t.Errorf("expected 2m day timeout, got %v", day.Timeout)
+	}
+	want := []werewolf.Transition{{When: werewolf.ConditionFirstRound, Target: pb.PhaseType_PHASE_TYPE_NIGHT_GUARD}}
+	if !reflect.DeepEqual(day.Transitions, want) {
+		t.Errorf("expected first_round transition, got %+v", day.Transitions)
+	}
+	if day.Steps[0].Role != pb.RoleType_ROLE_TYPE_UNSPECIFIED {
+		t.Errorf("expected omitted role to mean all players, got %v", day.Steps[0].Role)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   []string
+	}{
+		{
+			name:   "unknown field",
+			format: FormatYAML,
+			input:  "rules:\n  witch_can_save: true\n",
+			want:   []string{"line 2", "witch_can_save"},
+		},
+		{
+			name:   "wrong type",
+			format: FormatJSON,
+			input:  `{"rules": {"guard_can_repeat": "yes"}}`,
+			want:   []string{"rules.guard_can_repeat"},
+		},
+		{
+			name:   "unknown wolf tie policy",
+			format: FormatYAML,
+			input:  "rules:\n  wolf_tie_policy: coin_flip\n",
+			want:   []string{`rules.wolf_tie_policy: unknown policy "coin_flip"`},
+		},
+		{
+			name:   "bad timeout",
+			format: FormatYAML,
+			input:  "default_timeout: soon\n",
+			want:   []string{`default_timeout: invalid duration "soon"`},
+		},
+		{
+			name:   "unknown role and skill",
+			format: FormatYAML,
+			input: `
+phases:
+  - type: DAY
+    next_phase: END
+    steps:
+      - {role: GOD, skill: ANNOUNCE}
+      - {role: WIZARD, skill: FLY, order: -1}
+`,
+			want: []string{
+				`phases[0].steps[1].role: unknown role "WIZARD"`,
+				`phases[0].steps[1].skill: unknown skill "FLY"`,
+				`phases[0].steps[1].order: must not be negative`,
+			},
+		},
+		{
+			name:   "dangling next phase",
+			format: FormatYAML,
+			input: `
+phases:
+  - {type: DAY, next_phase: VOTE}
+  - {type: NIGHT, next_phase: DAY, timeout: -1s}
+  - {type: DAY, next_phase: END}
+  - {type: DUSK}
+`,
+			want: []string{
+				"phases[0].next_phase: phase VOTE is not defined in phases",
+				"phases[1].timeout: must not be negative",
+				"phases[2].type: duplicate phase DAY (already defined at phases[0])",
+				`phases[3].type: unknown phase "DUSK"`,
+				"phases[3].next_phase: missing next phase",
+			},
+		},
+		{
+			name:   "bad transition",
+			format: FormatYAML,
+			input: `
+phases:
+  - type: VOTE
+    next_phase: NIGHT_GUARD
+    transitions:
+      - {when: vote_tied, target: DAY}
+      - {when: hunter_triggered, target: DAY_HUNTER}
+`,
+			want: []string{
+				`phases[0].transitions[0].when: unknown condition "vote_tied" (registered: first_round, hunter_triggered`,
+				"phases[0].transitions[0].target: phase DAY is not defined in phases",
+				"phases[0].transitions[1].target: phase DAY_HUNTER is not defined in phases",
+			},
+		},
+		{
+			name:   "phase graph",
+			format: FormatYAML,
+			input: `
+phases:
+  - {type: NIGHT_GUARD, next_phase: DAY}
+  - {type: DAY, next_phase: VOTE}
+  - {type: VOTE, next_phase: DAY}
+`,
+			want: []string{"phase DAY never loops back to NIGHT_GUARD", "phase VOTE never loops back to NIGHT_GUARD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse([]byte(tt.input), tt.format)
+			if err == nil {
+				t.Fatalf("expected error, got config %+v", config)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to contain %q, got:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParse_FieldError(t *testing.T) {
+	_, err := Parse([]byte(`{"phases": [{"type": "DAY", "next_phase": "MIDNIGHT"}]}`), FormatJSON)
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("expected *FieldError, got %v", err)
+	}
+	if fieldErr.Field != "phases[0].next_phase" {
+		t.Errorf("expected field phases[0].next_phase, got %s", fieldErr.Field)
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	for path, want := range map[string]Format{"a.yaml": FormatYAML, "b.YML": FormatYAML, "c.json": FormatJSON} {
+		if got, err := FormatFromPath(path); err != nil || got != want {
+			t.Errorf("%s: expected %s, got %s (%v)", path, want, got, err)
+		}
+	}
+	if _, err := FormatFromPath("config.toml"); err == nil {
+		t.Error("expected error for unsupported extension")
+	}
+}
@@ -0,0 +1,270 @@
+package configfile
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// FieldError 指向出错字段的校验错误
+type FieldError struct {
+	Field   string // 字段路径，如 phases[2].steps[0].skill
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// GameConfig 校验文件内容并转换为游戏配置
+// 所有校验错误会一并返回（errors.Join），每个错误均为 *FieldError
+func (f *File) GameConfig() (*werewolf.GameConfig, error) {
+	config := werewolf.DefaultGameConfig()
+	var errs []error
+	fail := func(field, format string, args ...any) {
+		errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	applyBool(&config.WitchCanSaveSelf, f.Rules.WitchCanSaveSelf)
+	applyBool(&config.GuardCanProtectSelf, f.Rules.GuardCanProtectSelf)
+	applyBool(&config.GuardCanRepeat, f.Rules.GuardCanRepeat)
+	applyBool(&config.SameGuardKillIsEmpty, f.Rules.SameGuardKillIsEmpty)
+	applyBool(&config.WolfKillUnanimous, f.Rules.WolfKillUnanimous)
+
+	if f.Rules.WolfTiePolicy != "" {
+		policy, ok := werewolf.ParseWolfTiePolicy(f.Rules.WolfTiePolicy)
+		if !ok {
+			fail("rules.wolf_tie_policy", "unknown policy %q (valid: no_kill, random, lead_wolf)", f.Rules.WolfTiePolicy)
+		}
+		config.WolfTiePolicy = policy
+	}
+
+	if f.DefaultTimeout != "" {
+		timeout, err := parseTimeout(f.DefaultTimeout)
+		if err != nil {
+			fail("default_timeout", "%v", err)
+		}
+		config.DefaultTimeout = timeout
+	}
+
+	if f.Phases == nil {
+		return config, errors.Join(errs...)
+	}
+
+	config.Phases = make(map[pb.PhaseType]*werewolf.PhaseConfig, len(f.Phases))
+	defined := make(map[pb.PhaseType]int, len(f.Phases))
+	for i, phase := range f.Phases {
+		if phaseType, ok := parsePhase(phase.Type); ok {
+			if first, dup := defined[phaseType]; dup {
+				fail(fmt.Sprintf("phases[%d].type", i), "duplicate phase %s (already defined at phases[%d])", phase.Type, first)
+			} else {
+				defined[phaseType] = i
+			}
+		}
+	}
+
+	for i, phase := range f.Phases {
+		path := fmt.Sprintf("phases[%d]", i)
+		pc := &werewolf.PhaseConfig{}
+
+		phaseType, ok := parsePhase(phase.Type)
+		if !ok {
+			fail(path+".type", "unknown phase %q (valid: %s)", phase.Type, validNames(pb.PhaseType_name, "PHASE_TYPE_"))
+		}
+		pc.Type = phaseType
+
+		if phase.Timeout != "" {
+			timeout, err := parseTimeout(phase.Timeout)
+			if err != nil {
+				fail(path+".timeout", "%v", err)
+			}
+			pc.Timeout = timeout
+		}
+
+		next, ok := parsePhase(phase.NextPhase)
+		switch {
+		case phase.NextPhase == "":
+			fail(path+".next_phase", "missing next phase")
+		case !ok:
+			fail(path+".next_phase", "unknown phase %q (valid: %s)", phase.NextPhase, validNames(pb.PhaseType_name, "PHASE_TYPE_"))
+		default:
+			if _, exists := defined[next]; !exists && next != pb.PhaseType_PHASE_TYPE_END {
+				fail(path+".next_phase", "phase %s is not defined in phases", shortName(next.String(), "PHASE_TYPE_"))
+			}
+		}
+		pc.NextPhase = next
+
+		for j, transition := range phase.Transitions {
+			transitionPath := fmt.Sprintf("%s.transitions[%d]", path, j)
+
+			if _, ok := werewolf.LookupCondition(transition.When); !ok {
+				fail(transitionPath+".when", "unknown condition %q (registered: %s)", transition.When, strings.Join(werewolf.ConditionNames(), ", "))
+			}
+			target, ok := parsePhase(transition.Target)
+			if !ok {
+				fail(transitionPath+".target", "unknown phase %q (valid: %s)", transition.Target, validNames(pb.PhaseType_name, "PHASE_TYPE_"))
+			} else if _, exists := defined[target]; !exists {
+				fail(transitionPath+".target", "phase %s is not defined in phases", shortName(target.String(), "PHASE_TYPE_"))
+			}
+			pc.Transitions = append(pc.Transitions, werewolf.Transition{When: transition.When, Target: target})
+		}
+
+		for j, step := range phase.Steps {
+			stepPath := fmt.Sprintf("%s.steps[%d]", path, j)
+
+			// 省略角色表示所有存活玩家（如白天发言、投票）
+			role, ok := int32(0), true
+			if step.Role != "" {
+				role, ok = parseEnum(step.Role, "ROLE_TYPE_", pb.RoleType_value)
+			}
+			if !ok {
+				fail(stepPath+".role", "unknown role %q (valid: %s)", step.Role, validNames(pb.RoleType_name, "ROLE_TYPE_"))
+			}
+			skill, ok := parseEnum(step.Skill, "SKILL_TYPE_", pb.SkillType_value)
+			if !ok {
+				fail(stepPath+".skill", "unknown skill %q (valid: %s)", step.Skill, validNames(pb.SkillType_name, "SKILL_TYPE_"))
+			}
+			if step.Order < 0 {
+				fail(stepPath+".order", "must not be negative, got %d", step.Order)
+			}
+
+			pc.Steps = append(pc.Steps, werewolf.PhaseStep{
+				Role:     pb.RoleType(role),
+				Skill:    pb.SkillType(skill),
+				Order:    step.Order,
+				Required: step.Required,
+				Multiple: step.Multiple,
+			})
+		}
+
+		if _, dup := config.Phases[phaseType]; !dup {
+			config.Phases[phaseType] = pc
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// FromGameConfig 将游戏配置转换为文件结构，阶段按枚举值排序以保证输出稳定
+func FromGameConfig(config *werewolf.GameConfig) *File {
+	file := &File{
+		Rules: Rules{
+			WitchCanSaveSelf:     boolPtr(config.WitchCanSaveSelf),
+			GuardCanProtectSelf:  boolPtr(config.GuardCanProtectSelf),
+			GuardCanRepeat:       boolPtr(config.GuardCanRepeat),
+			SameGuardKillIsEmpty: boolPtr(config.SameGuardKillIsEmpty),
+			WolfKillUnanimous:    boolPtr(config.WolfKillUnanimous),
+			WolfTiePolicy:        config.WolfTiePolicy.String(),
+		},
+		DefaultTimeout: config.DefaultTimeout.String(),
+	}
+
+	types := make([]pb.PhaseType, 0, len(config.Phases))
+	for phaseType := range config.Phases {
+		types = append(types, phaseType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	file.Phases = make([]Phase, 0, len(types))
+	for _, phaseType := range types {
+		pc := config.Phases[phaseType]
+		phase := Phase{
+			Type:      shortName(phaseType.String(), "PHASE_TYPE_"),
+			NextPhase: shortName(pc.NextPhase.String(), "PHASE_TYPE_"),
+			Steps:     make([]Step, 0, len(pc.Steps)),
+		}
+		if pc.Timeout != 0 {
+			phase.Timeout = pc.Timeout.String()
+		}
+		for _, transition := range pc.Transitions {
+			phase.Transitions = append(phase.Transitions, Transition{
+				When:   transition.When,
+				Target: shortName(transition.Target.String(), "PHASE_TYPE_"),
+			})
+		}
+		for _, step := range pc.Steps {
+			var role string
+			if step.Role != pb.RoleType_ROLE_TYPE_UNSPECIFIED {
+				role = shortName(step.Role.String(), "ROLE_TYPE_")
+			}
+			phase.Steps = append(phase.Steps, Step{
+				Role:     role,
+				Skill:    shortName(step.Skill.String(), "SKILL_TYPE_"),
+				Order:    step.Order,
+				Required: step.Required,
+				Multiple: step.Multiple,
+			})
+		}
+		file.Phases = append(file.Phases, phase)
+	}
+	return file
+}
+
+// parsePhase 解析阶段名称，接受简写（NIGHT_GUARD）和完整枚举名（PHASE_TYPE_NIGHT_GUARD）
+func parsePhase(name string) (pb.PhaseType, bool) {
+	value, ok := parseEnum(name, "PHASE_TYPE_", pb.PhaseType_value)
+	return pb.PhaseType(value), ok
+}
+
+// parseEnum 解析枚举名称，大小写不敏感，不接受 UNSPECIFIED
+func parseEnum(name, prefix string, values map[string]int32) (int32, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, prefix) {
+		name = prefix + name
+	}
+	value, ok := values[name]
+	if !ok || value == 0 {
+		return 0, false
+	}
+	return value, true
+}
+
+// validNames 列出合法的枚举简写（按枚举值排序，不含 UNSPECIFIED）
+func validNames(names map[int32]string, prefix string) string {
+	values := make([]int32, 0, len(names))
+	for value := range names {
+		if value != 0 {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		list = append(list, shortName(names[value], prefix))
+	}
+	return strings.Join(list, ", ")
+}
+
+func shortName(name, prefix string) string {
+	return strings.TrimPrefix(name, prefix)
+}
+
+func parseTimeout(s string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (expected e.g. 30s, 1m30s)", s)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("must not be negative, got %s", s)
+	}
+	return timeout, nil
+}
+
+func applyBool(dst *bool, src *bool) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -3,6 +3,10 @@ package werewolf
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
 
 	pb "github.com/Zereker/werewolf/proto"
 )
@@ -15,6 +19,7 @@ type Effect struct {
 	Data     map[string]interface{} // 附加数据
 	Canceled bool                   // 是否被取消（如被保护）
 	Reason   string                 // 取消原因
+	Payload  proto.Message          // 类型化的事件负载（*pb.KillEvent、*pb.CheckEvent 等），ToEvent 时写入 Event 的 payload
 }
 
 // NewEffect 创建效果
@@ -39,8 +44,51 @@ func (e *Effect) WithData(key string, value interface{}) *Effect {
 	return e
 }
 
+// EffectHandler 自定义效果处理器，由 State.ApplyEffect 对未被取消的效果调用
+// 调用时引擎持有锁、State 未加锁：只应通过 State 的公开方法和扩展数据（PlayerKey、RoundKey）修改状态，不能调用 Engine 方法
+type EffectHandler func(state *State, effect *Effect)
+
+var (
+	effectHandlersMu sync.RWMutex
+	effectHandlers   = make(map[pb.EventType]EffectHandler)
+)
+
+// RegisterEffectHandler 为新的效果类型注册处理器，插件解析器产生该类型的效果时由处理器修改状态
+// 效果类型不能是 proto 中已定义的事件类型；与内置效果一样，类型值 >= 100 的效果视为内部效果不对外发布。
+// 处理器为 nil、类型已在 proto 中定义或重复注册时 panic（与 RegisterCondition 一致，应在 init 中调用）
+func RegisterEffectHandler(effectType pb.EventType, handler EffectHandler) {
+	effectHandlersMu.Lock()
+	defer effectHandlersMu.Unlock()
+
+	if handler == nil {
+		panic("werewolf: RegisterEffectHandler requires a handler")
+	}
+	if _, builtin := pb.EventType_name[int32(effectType)]; builtin {
+		panic(fmt.Sprintf("werewolf: RegisterEffectHandler cannot override built-in effect type %s", effectType))
+	}
+	if _, dup := effectHandlers[effectType]; dup {
+		panic(fmt.Sprintf("werewolf: RegisterEffectHandler called twice for effect type %d", effectType))
+	}
+	effectHandlers[effectType] = handler
+}
+
+// LookupEffectHandler 查找效果类型的自定义处理器
+func LookupEffectHandler(effectType pb.EventType) (EffectHandler, bool) {
+	effectHandlersMu.RLock()
+	defer effectHandlersMu.RUnlock()
+
+	handler, ok := effectHandlers[effectType]
+	return handler, ok
+}
+
+// WithPayload 设置类型化的事件负载
+func (e *Effect) WithPayload(payload proto.Message) *Effect {
+	e.Payload = payload
+	return e
+}
+
 // ToEvent 转换为事件（用于通知外部）
-// 将 Effect.Data (map[string]interface{}) 转换为 Event.Data (map[string]string)
+// 将 Effect.Data (map[string]interface{}) 转换为 Event.Data (map[string]string)，Payload 写入 Event 的 payload
 func (e *Effect) ToEvent() *pb.Event {
 	event := &pb.Event{
 		Type:     e.Type,
@@ -54,9 +102,63 @@ func (e *Effect) ToEvent() *pb.Event {
 		event.Data[k] = convertToString(v)
 	}
 
+	setEventPayload(event, e.Payload)
 	return event
 }
 
+// setEventPayload 将负载消息写入事件的 oneof 字段，不支持的消息类型忽略
+func setEventPayload(event *pb.Event, payload proto.Message) {
+	switch p := payload.(type) {
+	case *pb.KillEvent:
+		event.Payload = &pb.Event_Kill{Kill: p}
+	case *pb.CheckEvent:
+		event.Payload = &pb.Event_Check{Check: p}
+	case *pb.VoteResultEvent:
+		event.Payload = &pb.Event_VoteResult{VoteResult: p}
+	case *pb.GameEndedEvent:
+		event.Payload = &pb.Event_GameEnded{GameEnded: p}
+	case *pb.GameStartedEvent:
+		event.Payload = &pb.Event_GameStarted{GameStarted: p}
+	case *pb.PhaseEvent:
+		event.Payload = &pb.Event_Phase{Phase: p}
+	case *pb.RoundStartedEvent:
+		event.Payload = &pb.Event_RoundStarted{RoundStarted: p}
+	case *pb.NightSummaryEvent:
+		event.Payload = &pb.Event_NightSummary{NightSummary: p}
+	case *pb.WolfTallyEvent:
+		event.Payload = &pb.Event_WolfTally{WolfTally: p}
+	}
+}
+
+// killPayload 死亡事件负载
+func killPayload(cause pb.EventType, playerID, killerID string) *pb.KillEvent {
+	return &pb.KillEvent{PlayerId: playerID, Cause: cause, KillerId: killerID}
+}
+
+// voteResultPayload 投票结果负载，按得票数从高到低排列，同票按目标 ID 排序
+func voteResultPayload(result VoteResult, abstained []string) *pb.VoteResultEvent {
+	payload := &pb.VoteResultEvent{
+		EliminatedId: result.Winner,
+		Tied:         result.Tied,
+		AbstainedIds: abstained,
+	}
+	for target, votes := range result.Votes {
+		payload.Tallies = append(payload.Tallies, &pb.VoteTally{
+			TargetId: target,
+			Votes:    int32(votes),
+			VoterIds: append([]string(nil), result.Voters[target]...),
+		})
+	}
+	sort.Slice(payload.Tallies, func(i, j int) bool {
+		a, b := payload.Tallies[i], payload.Tallies[j]
+		if a.Votes != b.Votes {
+			return a.Votes > b.Votes
+		}
+		return a.TargetId < b.TargetId
+	})
+	return payload
+}
+
 // convertToString 将 interface{} 转换为 string
 func convertToString(v interface{}) string {
 	switch val := v.(type) {
@@ -86,6 +86,25 @@ func TestEffect_ToEvent_Kill(t *testing.T) {
 	}
 }
 
+func TestEffect_ToEvent_Payload(t *testing.T) {
+	event := NewEffect(pb.EventType_EVENT_TYPE_SHOOT, "hunter", "wolf").
+		WithPayload(killPayload(pb.EventType_EVENT_TYPE_SHOOT, "wolf", "hunter")).
+		ToEvent()
+
+	kill := event.GetKill()
+	if kill.GetPlayerId() != "wolf" || kill.GetKillerId() != "hunter" || kill.GetCause() != pb.EventType_EVENT_TYPE_SHOOT {
+		t.Errorf("expected kill payload, got %v", kill)
+	}
+	if event.GetCheck() != nil || event.GetVoteResult() != nil {
+		t.Error("expected a single payload")
+	}
+
+	// 没有负载的效果不设置 payload
+	if event := NewEffect(pb.EventType_EVENT_TYPE_PROTECT, "guard", "p1").ToEvent(); event.Payload != nil {
+		t.Errorf("expected no payload, got %v", event.Payload)
+	}
+}
+
 func TestEffect_ToEvent_Poison(t *testing.T) {
 	effect := NewEffect(pb.EventType_EVENT_TYPE_POISON, "witch", "victim")
 
@@ -242,3 +261,55 @@ func TestConvertToString(t *testing.T) {
 		})
 	}
 }
+
+// 测试用的自定义效果类型（内部效果）
+const testEffectDuel = pb.EventType(1001)
+
+// registerTestEffectHandler 注册测试效果处理器（重复运行测试时跳过已注册的类型）
+func registerTestEffectHandler(effectType pb.EventType, handler EffectHandler) {
+	if _, ok := LookupEffectHandler(effectType); !ok {
+		RegisterEffectHandler(effectType, handler)
+	}
+}
+
+var testDuels = NewPlayerKey[int]("test.duels")
+
+func TestRegisterEffectHandler(t *testing.T) {
+	registerTestEffectHandler(testEffectDuel, func(state *State, effect *Effect) {
+		n, _ := testDuels.Get(state, effect.SourceID)
+		testDuels.Set(state, effect.SourceID, n+1)
+	})
+
+	state := NewState()
+	state.AddPlayer("knight", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+
+	state.ApplyEffect(NewEffect(testEffectDuel, "knight", ""))
+	state.ApplyEffect(NewEffect(testEffectDuel, "knight", ""))
+	canceled := NewEffect(testEffectDuel, "knight", "")
+	canceled.Cancel("no duel today")
+	state.ApplyEffect(canceled)
+
+	if n, _ := testDuels.Get(state, "knight"); n != 2 {
+		t.Errorf("expected handler to count 2 duels, got %d", n)
+	}
+}
+
+func TestRegisterEffectHandler_Panics(t *testing.T) {
+	noop := func(*State, *Effect) {}
+	registerTestEffectHandler(testEffectDuel, noop)
+
+	for name, register := range map[string]func(){
+		"nil handler": func() { RegisterEffectHandler(pb.EventType(1002), nil) },
+		"built-in":    func() { RegisterEffectHandler(pb.EventType_EVENT_TYPE_KILL, noop) },
+		"duplicate":   func() { RegisterEffectHandler(testEffectDuel, noop) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			register()
+		})
+	}
+}
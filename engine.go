@@ -1,9 +1,14 @@
 package werewolf
 
 import (
+	"errors"
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	pb "github.com/Zereker/werewolf/proto"
 )
 
@@ -17,6 +22,20 @@ type Message struct {
 	Phase     pb.PhaseType // 发送时的阶段
 	Round     int          // 发送时的回合
 	Timestamp time.Time    // 发送时间
+	Sequence  uint64       // 本局内的发布序号（与事件共用）
+}
+
+// ToProto 转换为 protobuf 消息（用于传输）
+func (m *Message) ToProto(receiverIDs []string) *pb.ChatMessage {
+	return &pb.ChatMessage{
+		SenderId:    m.SenderID,
+		Content:     m.Content,
+		Phase:       m.Phase,
+		Round:       int32(m.Round),
+		TimestampMs: m.Timestamp.UnixMilli(),
+		ReceiverIds: receiverIDs,
+		Sequence:    m.Sequence,
+	}
 }
 
 // MessageHandler 消息处理器
@@ -74,38 +93,78 @@ type RolePhaseInfo struct {
 type Engine struct {
 	mu sync.RWMutex
 
+	id      string // 游戏ID（由 GameManager 分配，单独使用时为空）
 	config  *GameConfig
 	state   *State
 	phase   *Phase
-	logger  Logger
 	metrics Metrics
 
+	// 是否计入了进行中的游戏数（Start 成功时计入，游戏结束或被移除时扣除）
+	inProgress bool
+
+	// 链路追踪：gameSpan 覆盖整局，phaseSpan 覆盖当前阶段（子步骤）
+	tracer    Tracer
+	gameSpan  Span
+	phaseSpan Span
+
+	// 日志：baseLogger 为注入的日志，logger 是带 game_id/round/phase 上下文的子日志（阶段变化时重新派生）
+	baseLogger Logger
+	logger     Logger
+
+	// 开始前的大厅（座位、房主、板子）
+	lobby *lobby
+
+	// 随机源（发牌等），可通过 SetSeed 固定以复现对局
+	rng *rand.Rand
+
+	// 时钟（计算阶段超时时间，测试中可替换）
+	now func() time.Time
+
+	// 进入当前阶段的时间（阶段耗时和行动耗时指标）
+	phaseStartedAt time.Time
+
 	// 当前阶段收集的技能使用
 	pendingUses []*SkillUse
 
-	// 事件通知（可选）
-	eventHandlers []EventHandler
-
-	// 消息通知（可选）
-	messageHandlers []MessageHandler
+	// 事件和消息的订阅者
+	bus *eventBus
 }
 
 // NewEngine 创建游戏引擎
-func NewEngine(config *GameConfig) *Engine {
+// config 为 nil 时使用默认配置；配置未通过 Validate 时返回 ERROR_CODE_INVALID_CONFIG 错误
+func NewEngine(config *GameConfig) (*Engine, error) {
 	if config == nil {
 		config = DefaultGameConfig()
 	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	state := NewState()
+	state.rng = rng
 
 	return &Engine{
-		config:          config,
-		state:           NewState(),
-		phase:           NewPhase(config),
-		logger:          NewNopLogger(),
-		metrics:         NewNopMetrics(),
-		pendingUses:     make([]*SkillUse, 0),
-		eventHandlers:   make([]EventHandler, 0),
-		messageHandlers: make([]MessageHandler, 0),
-	}
+		config:      config,
+		state:       state,
+		phase:       NewPhase(config),
+		baseLogger:  NewNopLogger(),
+		logger:      NewNopLogger(),
+		metrics:     NewNopMetrics(),
+		tracer:      NewNopTracer(),
+		gameSpan:    nopSpan{},
+		phaseSpan:   nopSpan{},
+		lobby:       &lobby{},
+		rng:         rng,
+		now:         time.Now,
+		pendingUses: make([]*SkillUse, 0),
+		bus:         newEventBus(),
+	}, nil
+}
+
+// ID 获取游戏ID
+func (e *Engine) ID() string {
+	return e.id
 }
 
 // SetLogger 设置日志接口
@@ -113,8 +172,32 @@ func (e *Engine) SetLogger(logger Logger) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	if logger != nil {
-		e.logger = logger
+		e.baseLogger = logger
+		e.refreshLoggerLocked()
+	}
+}
+
+// setID 设置游戏ID（GameManager 分配）
+func (e *Engine) setID(id string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.id = id
+	e.refreshLoggerLocked()
+}
+
+// refreshLoggerLocked 按当前游戏ID、回合和阶段重新派生上下文日志（调用前需持有锁）
+func (e *Engine) refreshLoggerLocked() {
+	fields := make([]Field, 0, 3)
+	if e.id != "" {
+		fields = append(fields, GameIDField(e.id))
+	}
+	if e.state.Round > 0 {
+		fields = append(fields, RoundField(e.state.Round))
 	}
+	fields = append(fields, PhaseField(e.state.Phase))
+
+	e.logger = e.baseLogger.With(fields...)
+	e.bus.setLogger(e.logger)
 }
 
 // SetMetrics 设置指标收集器
@@ -123,47 +206,174 @@ func (e *Engine) SetMetrics(metrics Metrics) {
 	defer e.mu.Unlock()
 	if metrics != nil {
 		e.metrics = metrics
+		e.bus.setMetrics(metrics)
+	}
+}
+
+// releaseInProgressLocked 不再计入进行中的游戏数（只扣除一次，调用前需持有锁）
+func (e *Engine) releaseInProgressLocked() {
+	if e.inProgress {
+		e.inProgress = false
+		e.metrics.AddGamesInProgress(-1)
+	}
+}
+
+// abandon 游戏被管理器移除时调用：进行中的游戏不再计入进行中的游戏数
+func (e *Engine) abandon() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.releaseInProgressLocked()
+}
+
+// SetTracer 设置链路追踪（需在 Start 之前设置才能得到完整的 game span）
+func (e *Engine) SetTracer(tracer Tracer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if tracer != nil {
+		e.tracer = tracer
+	}
+}
+
+// startPhaseSpanLocked 为当前阶段打开 span（调用前需持有锁）
+func (e *Engine) startPhaseSpanLocked() {
+	e.phaseSpan = e.tracer.StartSpan("phase", e.gameSpan,
+		PhaseField(e.state.Phase),
+		RoundField(e.state.Round),
+		F("sub_step", e.state.SubStep))
+}
+
+// resolveLocked 调用解析器并记录 resolve span（调用前需持有锁）
+func (e *Engine) resolveLocked(resolver Resolver) []*Effect {
+	skills := make([]string, len(e.pendingUses))
+	for i, use := range e.pendingUses {
+		skills[i] = skillUseAttribute(use)
+	}
+	span := e.tracer.StartSpan("resolve", e.phaseSpan,
+		F("resolver", resolverAttribute(resolver)),
+		F("skills", skills))
+	defer span.End()
+
+	effects := resolver.Resolve(e.pendingUses, e.state, e.config)
+
+	produced := make([]string, 0, len(effects))
+	canceled := make([]string, 0)
+	for _, effect := range effects {
+		if effect.Canceled {
+			canceled = append(canceled, effectAttribute(effect))
+		} else {
+			produced = append(produced, effectAttribute(effect))
+		}
 	}
+	span.SetAttributes(F("effects", produced), F("canceled", canceled))
+	return effects
 }
 
-// AddPlayer 添加玩家
-func (e *Engine) AddPlayer(id string, role pb.RoleType, camp pb.Camp) {
-	e.state.AddPlayer(id, role, camp)
+// SetSeed 设置随机种子（相同种子和操作序列产生相同的发牌结果和随机规则结果）
+func (e *Engine) SetSeed(seed int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rng = rand.New(rand.NewSource(seed))
+	e.state.rng = e.rng
+}
+
+// AddPlayer 直接添加指定角色的玩家（不经过大厅发牌）
+// 玩家ID已存在（包括已在大厅入座）时返回 ErrPlayerExists
+func (e *Engine) AddPlayer(id string, role pb.RoleType, camp pb.Camp) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if seat, _ := e.lobby.seatOf(id); seat != nil {
+		return ErrPlayerExists
+	}
+	if !e.state.AddPlayerIfNotExists(id, role, camp) {
+		return ErrPlayerExists
+	}
+	return nil
 }
 
 // Start 开始游戏
+// 使用大厅时，座位数必须与板子人数一致且所有玩家已准备，随后随机发牌
+// 成功后依次发布 GAME_STARTED、ROUND_STARTED 和第一个阶段的 PHASE_STARTED 事件
 func (e *Engine) Start() error {
+	events, err := e.start()
+	if err != nil {
+		return err
+	}
+
+	// 释放锁后再发布事件
+	for _, event := range events {
+		e.publishEvent(event)
+	}
+	return nil
+}
+
+// start 开始游戏的状态变更，返回需要发布的事件
+func (e *Engine) start() ([]*pb.Event, error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	if e.state.Phase != pb.PhaseType_PHASE_TYPE_START {
-		return ErrGameNotStarted
+		return nil, ErrGameNotStarted
+	}
+
+	// 直接 AddPlayer 的对局同样检查角色与阶段图是否匹配（如猎人需要 hunter_triggered 转换）
+	if err := e.config.ValidateBoard(e.boardInPlayLocked()); err != nil {
+		return nil, err
+	}
+	if len(e.lobby.seats) > 0 {
+		if err := e.dealRolesLocked(); err != nil {
+			return nil, err
+		}
 	}
 
 	// 进入第一个夜晚（从守卫阶段开始）
-	e.state.Phase = pb.PhaseType_PHASE_TYPE_NIGHT_GUARD
+	e.state.Phase = FirstPhase
 	e.state.Round = 1
 	e.state.ResetRoundState()
+	e.phaseStartedAt = e.now()
+	e.refreshLoggerLocked()
 
-	e.logger.Info("game started", RoundField(1), PhaseField(pb.PhaseType_PHASE_TYPE_NIGHT_GUARD))
+	e.logger.Info("game started")
+	e.metrics.AddGamesInProgress(1)
+	e.inProgress = true
+	gameAttrs := []Field{F("player_count", len(e.state.players))}
+	if e.id != "" {
+		gameAttrs = append(gameAttrs, GameIDField(e.id))
+	}
+	e.gameSpan = e.tracer.StartSpan("game", nil, gameAttrs...)
+	e.startPhaseSpanLocked()
 
-	return nil
+	return []*pb.Event{
+		e.gameStartedEventLocked(),
+		e.roundStartedEventLocked(),
+		e.phaseStartedEventLocked(),
+	}, nil
 }
 
 // SubmitSkillUse 提交技能使用
+// 玩家本阶段已提交过同一技能时：目标相同返回 ErrDuplicateSkillUse，目标不同则以最新的提交为准
+// （发言、公告等可重复的技能除外）
 func (e *Engine) SubmitSkillUse(use *SkillUse) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	return e.updateIntents(use.Skill, func() error {
+		return e.submitSkillUseLocked(use)
+	})
+}
 
+// submitSkillUseLocked 校验并记录技能使用（调用前需持有锁）
+func (e *Engine) submitSkillUseLocked(use *SkillUse) error {
 	// 验证技能使用
-	if err := e.phase.ValidateSkillUse(use, e.state); err != nil {
-		e.logger.Debug("skill validation failed",
-			PlayerField(use.PlayerID),
-			SkillField(use.Skill),
-			F("error", err.Error()))
+	if err := e.validateSkillUseLocked(use); err != nil {
 		return err
 	}
 
+	if i := e.pendingIntentLocked(use.PlayerID, use.Skill); i >= 0 {
+		if e.pendingUses[i].TargetID == use.TargetID {
+			return ErrDuplicateSkillUse.WithSkillUse(use, e.state.Phase, e.state.Round)
+		}
+		e.replaceIntentLocked(i, use)
+		return nil
+	}
+
 	// 添加到待处理列表
 	use.Phase = e.state.Phase
 	use.Round = e.state.Round
@@ -174,10 +384,30 @@ func (e *Engine) SubmitSkillUse(use *SkillUse) error {
 		SkillField(use.Skill),
 		TargetField(use.TargetID))
 	e.metrics.IncSkillSubmitted(use.Skill)
+	e.metrics.ObserveTimeToAction(use.Skill, e.now().Sub(e.phaseStartedAt))
+	e.phaseSpan.AddEvent("skill submitted", PlayerField(use.PlayerID), SkillField(use.Skill), TargetField(use.TargetID))
 
 	return nil
 }
 
+// validateSkillUseLocked 校验技能使用并记录失败原因，返回的 GameError 带有技能使用上下文（调用前需持有锁）
+func (e *Engine) validateSkillUseLocked(use *SkillUse) error {
+	// 阶段管理器与引擎使用同一份配置（SetRules 时一起更换）
+	if err := e.phase.ValidateSkillUse(use, e.state); err != nil {
+		e.logger.Debug("skill validation failed",
+			PlayerField(use.PlayerID),
+			SkillField(use.Skill),
+			F("error", err.Error()))
+		e.metrics.IncValidationFailure(GetErrorCode(err))
+		var gameErr *GameError
+		if errors.As(err, &gameErr) {
+			return gameErr.WithSkillUse(use, e.state.Phase, e.state.Round)
+		}
+		return err
+	}
+	return nil
+}
+
 // nextPhaseFunc 定义计算下一阶段的函数类型
 type nextPhaseFunc func(current pb.PhaseType) pb.PhaseType
 
@@ -199,7 +429,7 @@ func (e *Engine) endPhaseInternal(calcNextPhase nextPhaseFunc) ([]*Effect, error
 		return nil, ErrGameEnded
 	}
 
-	e.logger.Debug("ending phase", PhaseField(currentPhase), RoundField(currentRound))
+	e.logger.Debug("ending phase")
 
 	// 1. 获取当前阶段的解析器
 	resolver := e.phase.GetResolver(currentPhase)
@@ -207,8 +437,8 @@ func (e *Engine) endPhaseInternal(calcNextPhase nextPhaseFunc) ([]*Effect, error
 	// 2. 解析技能，产生效果
 	var effects []*Effect
 	if resolver != nil {
-		effects = resolver.Resolve(e.pendingUses, e.state, e.config)
-		e.logger.Debug("resolved effects", PhaseField(currentPhase), F("effect_count", len(effects)))
+		effects = e.resolveLocked(resolver)
+		e.logger.Debug("resolved effects", F("effect_count", len(effects)))
 	}
 
 	// 3. 应用效果，收集外部事件
@@ -216,7 +446,9 @@ func (e *Engine) endPhaseInternal(calcNextPhase nextPhaseFunc) ([]*Effect, error
 		e.state.ApplyEffect(effect)
 		// 只发布外部可见事件（内部事件类型 >= 100）
 		if effect.Type < 100 {
-			eventsToPublish = append(eventsToPublish, effect.ToEvent())
+			event := effect.ToEvent()
+			event.PhaseType = currentPhase
+			eventsToPublish = append(eventsToPublish, event)
 			e.logger.Debug("effect applied",
 				EventField(effect.Type),
 				PlayerField(effect.SourceID),
@@ -226,25 +458,44 @@ func (e *Engine) endPhaseInternal(calcNextPhase nextPhaseFunc) ([]*Effect, error
 	}
 
 	// 4. 清空待处理列表
+	e.phaseSpan.SetAttributes(F("skill_count", len(e.pendingUses)), F("effect_count", len(effects)))
+	e.phaseSpan.End()
 	e.pendingUses = make([]*SkillUse, 0)
 	e.metrics.IncPhaseEnded(currentPhase)
+	e.metrics.ObservePhaseDuration(currentPhase, e.now().Sub(e.phaseStartedAt))
 
 	// 5. 检查胜利条件
-	if gameOver, winner := e.state.CheckVictory(); gameOver {
+	if gameOver, winner, reason := e.state.checkVictoryReason(); gameOver {
 		e.state.Phase = pb.PhaseType_PHASE_TYPE_END
-		e.logger.Info("game ended", F("winner", winner.String()))
+		e.refreshLoggerLocked()
+		e.logger.Info("game ended", F("winner", winner.String()), F("reason", reason.String()))
 		e.metrics.IncGameEnded(winner)
+		e.releaseInProgressLocked()
+		e.gameSpan.SetAttributes(F("winner", winner.String()), F("reason", reason.String()), RoundField(currentRound))
+		e.gameSpan.End()
 		gameEndEvent = &pb.Event{
-			Type: pb.EventType_EVENT_TYPE_GAME_ENDED,
-			Data: map[string]string{"winner": winner.String()},
+			Type:      pb.EventType_EVENT_TYPE_GAME_ENDED,
+			Data:      map[string]string{"winner": winner.String()},
+			PhaseType: pb.PhaseType_PHASE_TYPE_END,
 		}
+		setEventPayload(gameEndEvent, &pb.GameEndedEvent{Winner: winner, Reason: reason, Round: int32(currentRound)})
+		eventsToPublish = append(eventsToPublish, phaseEndedEvent(currentPhase, currentRound, pb.PhaseType_PHASE_TYPE_END))
 	} else {
 		// 6. 流转到下一阶段
 		nextPhase := calcNextPhase(currentPhase)
 		e.state.NextPhase(nextPhase)
+		e.phaseStartedAt = e.now()
+		e.refreshLoggerLocked()
+		e.startPhaseSpanLocked()
 		e.logger.Debug("phase transition",
 			F("from", currentPhase.String()),
 			F("to", nextPhase.String()))
+
+		eventsToPublish = append(eventsToPublish, phaseEndedEvent(currentPhase, currentRound, nextPhase))
+		if e.state.Round != currentRound {
+			eventsToPublish = append(eventsToPublish, e.roundStartedEventLocked())
+		}
+		eventsToPublish = append(eventsToPublish, e.phaseStartedEventLocked())
 	}
 
 	// 释放锁后再发布事件，避免用户回调中调用 Engine 方法导致死锁
@@ -362,116 +613,64 @@ func (e *Engine) GetPhaseInfo() *PhaseInfo {
 		info.Steps = phaseConfig.Steps
 	}
 
-	switch e.state.Phase {
-	case pb.PhaseType_PHASE_TYPE_NIGHT_GUARD:
-		info.ActiveRoles = []pb.RoleType{pb.RoleType_ROLE_TYPE_GUARD}
-		info.RoleInfos[pb.RoleType_ROLE_TYPE_GUARD] = e.buildGuardPhaseInfo()
-
-	case pb.PhaseType_PHASE_TYPE_NIGHT_WOLF:
-		info.ActiveRoles = []pb.RoleType{pb.RoleType_ROLE_TYPE_WEREWOLF}
-		info.RoleInfos[pb.RoleType_ROLE_TYPE_WEREWOLF] = e.buildWolfPhaseInfo()
-
-	case pb.PhaseType_PHASE_TYPE_NIGHT_WITCH:
-		info.ActiveRoles = []pb.RoleType{pb.RoleType_ROLE_TYPE_WITCH}
-		info.RoleInfos[pb.RoleType_ROLE_TYPE_WITCH] = e.buildWitchPhaseInfo()
-
-	case pb.PhaseType_PHASE_TYPE_NIGHT_SEER:
-		info.ActiveRoles = []pb.RoleType{pb.RoleType_ROLE_TYPE_SEER}
-		info.RoleInfos[pb.RoleType_ROLE_TYPE_SEER] = e.buildSeerPhaseInfo()
-
-	case pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE:
-		// 结算阶段只有上帝公告，没有玩家行动
-		info.ActiveRoles = []pb.RoleType{}
-
-	case pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER, pb.PhaseType_PHASE_TYPE_DAY_HUNTER:
-		info.ActiveRoles = []pb.RoleType{pb.RoleType_ROLE_TYPE_HUNTER}
-		info.RoleInfos[pb.RoleType_ROLE_TYPE_HUNTER] = e.buildHunterPhaseInfo()
-
-	case pb.PhaseType_PHASE_TYPE_DAY:
-		info.ActiveRoles = []pb.RoleType{pb.RoleType_ROLE_TYPE_UNSPECIFIED}
-		info.RoleInfos[pb.RoleType_ROLE_TYPE_UNSPECIFIED] = e.buildDayPhaseInfo()
+	// 按阶段步骤汇总各角色（不含上帝）能行动的玩家和技能，UNSPECIFIED 表示所有玩家（如白天发言、投票）
+	for _, step := range info.Steps {
+		if step.Role == pb.RoleType_ROLE_TYPE_GOD {
+			continue
+		}
+		roleInfo, ok := info.RoleInfos[step.Role]
+		if !ok {
+			roleInfo = &RolePhaseInfo{PlayerIDs: e.actorsLocked(step.Role)}
+			info.RoleInfos[step.Role] = roleInfo
+			info.ActiveRoles = append(info.ActiveRoles, step.Role)
+		}
+		roleInfo.AllowedSkills = append(roleInfo.AllowedSkills, step.Skill)
 
-	case pb.PhaseType_PHASE_TYPE_VOTE:
-		info.ActiveRoles = []pb.RoleType{pb.RoleType_ROLE_TYPE_UNSPECIFIED}
-		info.RoleInfos[pb.RoleType_ROLE_TYPE_UNSPECIFIED] = e.buildVotePhaseInfo()
+		// 同一角色多人共同行动（如狼人刀人）时互相知道队友
+		if step.Multiple && step.Role != pb.RoleType_ROLE_TYPE_UNSPECIFIED && roleInfo.Teammates == nil {
+			roleInfo.Teammates = e.teammatesLocked(step.Role, roleInfo.PlayerIDs)
+		}
+		// 解药需要知道当晚被杀目标
+		if step.Skill == pb.SkillType_SKILL_TYPE_ANTIDOTE {
+			roleInfo.KillTarget = e.state.RoundCtx.KillTarget
+		}
 	}
 
 	return info
 }
 
-// buildGuardPhaseInfo 构建守卫阶段信息
-func (e *Engine) buildGuardPhaseInfo() *RolePhaseInfo {
-	return &RolePhaseInfo{
-		PlayerIDs:     e.state.getAlivePlayerIDsByRole(pb.RoleType_ROLE_TYPE_GUARD),
-		AllowedSkills: []pb.SkillType{pb.SkillType_SKILL_TYPE_PROTECT},
-	}
-}
-
-// buildWolfPhaseInfo 构建狼人阶段信息
-func (e *Engine) buildWolfPhaseInfo() *RolePhaseInfo {
-	playerIDs := e.state.getAlivePlayerIDsByRole(pb.RoleType_ROLE_TYPE_WEREWOLF)
-	teammates := make(map[string][]string)
-	for _, id := range playerIDs {
-		teammates[id] = e.state.GetWolfTeammates(id)
-	}
-	return &RolePhaseInfo{
-		PlayerIDs:     playerIDs,
-		AllowedSkills: []pb.SkillType{pb.SkillType_SKILL_TYPE_KILL},
-		Teammates:     teammates,
-	}
-}
-
-// buildWitchPhaseInfo 构建女巫阶段信息
-func (e *Engine) buildWitchPhaseInfo() *RolePhaseInfo {
-	return &RolePhaseInfo{
-		PlayerIDs: e.state.getAlivePlayerIDsByRole(pb.RoleType_ROLE_TYPE_WITCH),
-		AllowedSkills: []pb.SkillType{
-			pb.SkillType_SKILL_TYPE_ANTIDOTE,
-			pb.SkillType_SKILL_TYPE_POISON,
-		},
-		KillTarget: e.state.RoundCtx.KillTarget,
-	}
-}
-
-// buildSeerPhaseInfo 构建预言家阶段信息
-func (e *Engine) buildSeerPhaseInfo() *RolePhaseInfo {
-	return &RolePhaseInfo{
-		PlayerIDs:     e.state.getAlivePlayerIDsByRole(pb.RoleType_ROLE_TYPE_SEER),
-		AllowedSkills: []pb.SkillType{pb.SkillType_SKILL_TYPE_CHECK},
-	}
-}
-
-// buildDayPhaseInfo 构建白天阶段信息
-func (e *Engine) buildDayPhaseInfo() *RolePhaseInfo {
-	return &RolePhaseInfo{
-		PlayerIDs:     e.state.getAlivePlayerIDs(),
-		AllowedSkills: []pb.SkillType{pb.SkillType_SKILL_TYPE_SPEAK},
+// actorsLocked 当前阶段能以该角色行动的玩家（按座位排序，UNSPECIFIED 表示所有角色，调用前需持有锁）
+// 死亡玩家由角色定义的 CanActWhenDead 决定（如被触发的猎人）
+func (e *Engine) actorsLocked(role pb.RoleType) []string {
+	ids := make([]string, 0)
+	for _, id := range e.state.getPlayerIDs() {
+		player, _ := e.state.GetPlayerInfo(id)
+		if (role == pb.RoleType_ROLE_TYPE_UNSPECIFIED || player.Role == role) && canAct(player, e.state) {
+			ids = append(ids, id)
+		}
 	}
+	return ids
 }
 
-// buildVotePhaseInfo 构建投票阶段信息
-func (e *Engine) buildVotePhaseInfo() *RolePhaseInfo {
-	return &RolePhaseInfo{
-		PlayerIDs:     e.state.getAlivePlayerIDs(),
-		AllowedSkills: []pb.SkillType{pb.SkillType_SKILL_TYPE_VOTE},
+// teammatesLocked 每个行动玩家的同角色队友（含已死亡的队友，调用前需持有锁）
+func (e *Engine) teammatesLocked(role pb.RoleType, playerIDs []string) map[string][]string {
+	var members []string
+	for _, id := range e.state.getPlayerIDs() {
+		if player, _ := e.state.GetPlayerInfo(id); player.Role == role {
+			members = append(members, id)
+		}
 	}
-}
 
-// buildHunterPhaseInfo 构建猎人阶段信息
-func (e *Engine) buildHunterPhaseInfo() *RolePhaseInfo {
-	// 获取被触发的猎人ID
-	hunterID := e.state.RoundCtx.TriggeredHunterID
-	playerIDs := []string{}
-	if hunterID != "" {
-		playerIDs = []string{hunterID}
-	}
-	return &RolePhaseInfo{
-		PlayerIDs: playerIDs,
-		AllowedSkills: []pb.SkillType{
-			pb.SkillType_SKILL_TYPE_SHOOT,
-			pb.SkillType_SKILL_TYPE_SKIP,
-		},
+	teammates := make(map[string][]string, len(playerIDs))
+	for _, id := range playerIDs {
+		teammates[id] = make([]string, 0, len(members))
+		for _, member := range members {
+			if member != id {
+				teammates[id] = append(teammates[id], member)
+			}
+		}
 	}
+	return teammates
 }
 
 // EndSubStep 结束当前子阶段（子步骤模式）
@@ -485,56 +684,117 @@ func (e *Engine) isValidPhase(phase pb.PhaseType) bool {
 	return e.phase.GetPhaseConfig(phase) != nil
 }
 
-// calculateNextPhase 计算下一阶段（考虑动态触发）
+// calculateNextPhase 计算下一阶段（按阶段配置的条件转换处理动态触发，如猎人开枪）
 func (e *Engine) calculateNextPhase(currentPhase pb.PhaseType) pb.PhaseType {
-	// 夜晚结算阶段后，检查是否有猎人被触发
-	if currentPhase == pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE {
-		if e.state.RoundCtx.HunterTriggered {
-			return pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER
+	return e.phase.NextPhaseFor(currentPhase, e.state)
+}
+
+// OnEvent 注册同步事件处理器（不可取消，需要取消或异步投递时使用 SubscribeEvents）
+// 单个 goroutine 驱动引擎时，方法返回前其事件的处理器已执行完；并发调用时的投递时机见事件总线说明
+func (e *Engine) OnEvent(handler EventHandler) {
+	e.SubscribeEvents(handler)
+}
+
+// SubscribeEvents 订阅事件，返回的句柄用于取消订阅
+// 默认同步投递，WithBuffer 开启异步投递，WithOverflow 设置缓冲区满时的策略
+func (e *Engine) SubscribeEvents(handler EventHandler, opts ...SubscribeOption) *Subscription {
+	return e.bus.subscribe(func(item busItem) {
+		if item.event != nil {
+			handler(item.event)
 		}
-	}
+	}, applySubscribeOptions(opts))
+}
 
-	// 投票阶段后，检查被投票出局的是否是猎人
-	if currentPhase == pb.PhaseType_PHASE_TYPE_VOTE {
-		// 检查是否有猎人刚刚死亡（通过 RoundContext 判断）
-		if e.state.RoundCtx.HunterTriggered {
-			return pb.PhaseType_PHASE_TYPE_DAY_HUNTER
+// ==================== 流程事件 ====================
+
+// gameStartedEventLocked 游戏开始事件（调用前需持有锁）
+// 板子按角色排序，不泄露座位与角色的对应关系
+func (e *Engine) gameStartedEventLocked() *pb.Event {
+	playerIDs := e.state.getPlayerIDs()
+	board := make([]pb.RoleType, 0, len(playerIDs))
+	for _, id := range playerIDs {
+		if player, ok := e.state.GetPlayerInfo(id); ok {
+			board = append(board, player.Role)
 		}
 	}
+	sort.Slice(board, func(i, j int) bool { return board[i] < board[j] })
 
-	// 使用声明式配置获取下一阶段
-	return e.phase.NextSubPhase(currentPhase)
+	event := &pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_GAME_STARTED,
+		Data:      map[string]string{"players": convertToString(len(playerIDs))},
+		PhaseType: e.state.Phase,
+	}
+	setEventPayload(event, &pb.GameStartedEvent{Board: board, PlayerIds: playerIDs})
+	return event
 }
 
-// OnEvent 注册事件处理器
-func (e *Engine) OnEvent(handler EventHandler) {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-	e.eventHandlers = append(e.eventHandlers, handler)
+// roundStartedEventLocked 回合开始事件（调用前需持有锁）
+func (e *Engine) roundStartedEventLocked() *pb.Event {
+	round := e.state.Round
+	event := &pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_ROUND_STARTED,
+		Data:      map[string]string{"round": convertToString(round)},
+		PhaseType: e.state.Phase,
+	}
+	setEventPayload(event, &pb.RoundStartedEvent{Round: int32(round), AlivePlayerIds: e.state.getAlivePlayerIDsBySeat()})
+	return event
 }
 
-// publishEvent 发布事件
-// 每个 handler 独立执行，单个 handler panic 不影响其他 handler
-func (e *Engine) publishEvent(event *pb.Event) {
-	for _, handler := range e.eventHandlers {
-		func() {
-			defer func() {
-				// 捕获 panic，防止单个 handler 影响其他 handler
-				_ = recover()
-			}()
-			handler(event)
-		}()
+// phaseStartedEventLocked 进入当前阶段的事件，包含按阶段超时计算的截止时间（调用前需持有锁）
+func (e *Engine) phaseStartedEventLocked() *pb.Event {
+	phase, round := e.state.Phase, e.state.Round
+	payload := &pb.PhaseEvent{Phase: phase, Round: int32(round)}
+
+	timeout := e.config.DefaultTimeout
+	if config := e.phase.GetPhaseConfig(phase); config != nil && config.Timeout > 0 {
+		timeout = config.Timeout
+	}
+	if timeout > 0 {
+		payload.Deadline = timestamppb.New(e.now().Add(timeout))
+	}
+
+	event := &pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_PHASE_STARTED,
+		Data:      map[string]string{"phase": phase.String(), "round": convertToString(round)},
+		PhaseType: phase,
+	}
+	setEventPayload(event, payload)
+	return event
+}
+
+// phaseEndedEvent 离开阶段的事件
+func phaseEndedEvent(phase pb.PhaseType, round int, next pb.PhaseType) *pb.Event {
+	event := &pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_PHASE_ENDED,
+		Data:      map[string]string{"phase": phase.String(), "round": convertToString(round), "next": next.String()},
+		PhaseType: phase,
 	}
+	setEventPayload(event, &pb.PhaseEvent{Phase: phase, Round: int32(round), NextPhase: next})
+	return event
+}
+
+// publishEvent 发布事件（锁外调用）
+// 事件获得本局内的序号，按序号顺序投递给所有订阅者
+func (e *Engine) publishEvent(event *pb.Event) {
+	e.bus.publish(busItem{event: event})
 }
 
 // ==================== 消息系统 ====================
 
-// OnMessage 注册消息处理器
+// OnMessage 注册同步消息处理器
 // 当玩家发送消息时，处理器会收到消息和接收者列表
 func (e *Engine) OnMessage(handler MessageHandler) {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-	e.messageHandlers = append(e.messageHandlers, handler)
+	e.SubscribeMessages(handler)
+}
+
+// SubscribeMessages 订阅消息，选项与 SubscribeEvents 相同
+// 消息与事件共用序号，同一个订阅者同时订阅两者时可以按序号合并
+func (e *Engine) SubscribeMessages(handler MessageHandler, opts ...SubscribeOption) *Subscription {
+	return e.bus.subscribe(func(item busItem) {
+		if item.msg != nil {
+			handler(item.msg, item.receiverIDs)
+		}
+	}, applySubscribeOptions(opts))
 }
 
 // SendMessage 发送消息
@@ -570,16 +830,14 @@ func (e *Engine) SendMessage(senderID, content string) error {
 		Timestamp: time.Now(),
 	}
 
-	// 复制 handlers 以避免在回调中死锁
-	handlers := make([]MessageHandler, len(e.messageHandlers))
-	copy(handlers, e.messageHandlers)
-
+	// 阶段流转会重新派生 e.logger，锁外使用锁内取得的副本
+	logger := e.logger
 	e.mu.RUnlock()
 
 	// 发布消息（锁外执行，避免死锁）
-	e.publishMessage(msg, receiverIDs, handlers)
+	e.publishMessage(msg, receiverIDs)
 
-	e.logger.Debug("message sent",
+	logger.Debug("message sent",
 		PlayerField(senderID),
 		PhaseField(msg.Phase),
 		F("receiver_count", len(receiverIDs)))
@@ -602,33 +860,31 @@ func (e *Engine) getMessageReceivers(senderID string) []string {
 		return nil
 	}
 
-	switch e.state.Phase {
-	case pb.PhaseType_PHASE_TYPE_NIGHT_WOLF:
-		// 狼人阶段：只有狼人能互相交流
-		if sender.Role != pb.RoleType_ROLE_TYPE_WEREWOLF {
-			return nil
-		}
-		// 返回所有存活的狼人（包括自己，方便处理）
-		return e.state.getAlivePlayerIDsByRole(pb.RoleType_ROLE_TYPE_WEREWOLF)
-
-	case pb.PhaseType_PHASE_TYPE_DAY:
-		// 白天阶段：所有存活玩家都能听到
-		return e.state.getAlivePlayerIDs()
-
-	default:
-		// 其他阶段不允许发言
+	config := e.phase.GetPhaseConfig(e.state.Phase)
+	if config == nil {
 		return nil
 	}
-}
 
-// publishMessage 发布消息
-func (e *Engine) publishMessage(msg *Message, receiverIDs []string, handlers []MessageHandler) {
-	for _, handler := range handlers {
-		func() {
-			defer func() {
-				_ = recover()
-			}()
-			handler(msg, receiverIDs)
-		}()
+	// 发言步骤：该角色（UNSPECIFIED 表示所有玩家）的存活玩家都能听到，如白天
+	// 同一角色多人共同行动的步骤：同角色存活玩家互相交流（包括自己），如狼人夜聊
+	for _, step := range config.Steps {
+		speak := step.Skill == pb.SkillType_SKILL_TYPE_SPEAK &&
+			(step.Role == pb.RoleType_ROLE_TYPE_UNSPECIFIED || step.Role == sender.Role)
+		team := step.Multiple && step.Role != pb.RoleType_ROLE_TYPE_UNSPECIFIED && step.Role == sender.Role
+		if !speak && !team {
+			continue
+		}
+		if step.Role == pb.RoleType_ROLE_TYPE_UNSPECIFIED {
+			return e.state.getAlivePlayerIDs()
+		}
+		return e.state.getAlivePlayerIDsByRole(step.Role)
 	}
+
+	// 其他阶段不允许发言
+	return nil
+}
+
+// publishMessage 发布消息（锁外调用）
+func (e *Engine) publishMessage(msg *Message, receiverIDs []string) {
+	e.bus.publish(busItem{msg: msg, receiverIDs: receiverIDs})
 }
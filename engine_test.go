@@ -1,14 +1,27 @@
 package werewolf
 
 import (
+	"errors"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	pb "github.com/Zereker/werewolf/proto"
 )
 
+// newTestEngine 创建引擎，配置无效时测试失败
+func newTestEngine(t testing.TB, config *GameConfig) *Engine {
+	t.Helper()
+	engine, err := NewEngine(config)
+	if err != nil {
+		t.Fatalf("expected no error creating engine, got %v", err)
+	}
+	return engine
+}
+
 func TestNewEngine_NilConfig(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	if engine.config == nil {
 		t.Error("expected default config to be set")
@@ -22,8 +35,21 @@ func TestNewEngine_NilConfig(t *testing.T) {
 	if engine.pendingUses == nil {
 		t.Error("expected pendingUses to be initialized")
 	}
-	if engine.eventHandlers == nil {
-		t.Error("expected eventHandlers to be initialized")
+	if engine.bus == nil {
+		t.Error("expected event bus to be initialized")
+	}
+}
+
+func TestNewEngine_InvalidConfig(t *testing.T) {
+	config := DefaultGameConfig()
+	config.Phases[pb.PhaseType_PHASE_TYPE_VOTE].NextPhase = pb.PhaseType_PHASE_TYPE_UNSPECIFIED
+
+	engine, err := NewEngine(config)
+	if engine != nil || !IsErrorCode(err, pb.ErrorCode_ERROR_CODE_INVALID_CONFIG) {
+		t.Fatalf("expected INVALID_CONFIG error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "phase VOTE: next phase is not set") {
+		t.Errorf("expected descriptive error, got %q", err.Error())
 	}
 }
 
@@ -32,7 +58,7 @@ func TestNewEngine_CustomConfig(t *testing.T) {
 		WitchCanSaveSelf: true,
 		Phases:           DefaultGameConfig().Phases,
 	}
-	engine := NewEngine(config)
+	engine := newTestEngine(t, config)
 
 	if engine.config != config {
 		t.Error("expected custom config to be set")
@@ -43,7 +69,7 @@ func TestNewEngine_CustomConfig(t *testing.T) {
 }
 
 func TestEngine_AddPlayer(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	engine.AddPlayer("p1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 
@@ -57,7 +83,7 @@ func TestEngine_AddPlayer(t *testing.T) {
 }
 
 func TestEngine_Start(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("w1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
 
@@ -75,7 +101,7 @@ func TestEngine_Start(t *testing.T) {
 }
 
 func TestEngine_Start_AlreadyStarted(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("w1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 
 	engine.Start()
@@ -86,8 +112,28 @@ func TestEngine_Start_AlreadyStarted(t *testing.T) {
 	}
 }
 
+func TestEngine_Start_ValidatesPlayerRoles(t *testing.T) {
+	config := DefaultGameConfig()
+	delete(config.Phases, pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER)
+	delete(config.Phases, pb.PhaseType_PHASE_TYPE_DAY_HUNTER)
+	config.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE].Transitions = nil
+	config.Phases[pb.PhaseType_PHASE_TYPE_VOTE].Transitions = nil
+	engine := newTestEngine(t, config)
+
+	// 不经过大厅直接加入的猎人同样要求阶段图中有开枪转换
+	engine.AddPlayer("w1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("h1", pb.RoleType_ROLE_TYPE_HUNTER, pb.Camp_CAMP_GOOD)
+	err := engine.Start()
+	if !IsErrorCode(err, pb.ErrorCode_ERROR_CODE_INVALID_CONFIG) || !strings.Contains(err.Error(), "hunter_triggered") {
+		t.Errorf("expected invalid config for hunter without transition, got %v", err)
+	}
+	if engine.GetCurrentPhase() != pb.PhaseType_PHASE_TYPE_START {
+		t.Errorf("expected game to stay in START, got %v", engine.GetCurrentPhase())
+	}
+}
+
 func TestEngine_SubmitSkillUse_Valid(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("victim", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
 	engine.Start()
@@ -117,7 +163,7 @@ func TestEngine_SubmitSkillUse_Valid(t *testing.T) {
 }
 
 func TestEngine_SubmitSkillUse_InvalidPlayer(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.Start()
 
@@ -127,13 +173,13 @@ func TestEngine_SubmitSkillUse_InvalidPlayer(t *testing.T) {
 		TargetID: "wolf",
 	})
 
-	if err != ErrPlayerNotFound {
+	if !errors.Is(err, ErrPlayerNotFound) {
 		t.Errorf("expected ErrPlayerNotFound, got %v", err)
 	}
 }
 
 func TestEngine_SubmitSkillUse_DeadPlayer(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("victim", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
 	engine.state.players["wolf"].Alive = false
@@ -145,13 +191,51 @@ func TestEngine_SubmitSkillUse_DeadPlayer(t *testing.T) {
 		TargetID: "victim",
 	})
 
-	if err != ErrPlayerDead {
+	if !errors.Is(err, ErrPlayerDead) {
 		t.Errorf("expected ErrPlayerDead, got %v", err)
 	}
 }
 
+func TestEngine_SubmitSkillUse_ErrorDetails(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"})
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WITCH)
+
+	// 解药只能救今晚被杀的玩家，错误带有技能使用上下文
+	err := engine.SubmitSkillUse(&SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v2"})
+	var gameErr *GameError
+	if !errors.Is(err, ErrTargetNotDying) || !errors.As(err, &gameErr) {
+		t.Fatalf("expected ErrTargetNotDying, got %v", err)
+	}
+	want := GameError{
+		Code:     pb.ErrorCode_ERROR_CODE_TARGET_NOT_DYING,
+		Message:  ErrTargetNotDying.Message,
+		PlayerID: "witch",
+		Skill:    pb.SkillType_SKILL_TYPE_ANTIDOTE,
+		TargetID: "v2",
+		Phase:    pb.PhaseType_PHASE_TYPE_NIGHT_WITCH,
+		Round:    1,
+	}
+	if *gameErr != want {
+		t.Errorf("expected %+v, got %+v", want, *gameErr)
+	}
+
+	// 重复提交同样带上下文
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v1"})
+	err = engine.SubmitSkillUse(&SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v1"})
+	if !errors.As(err, &gameErr) || gameErr.Code != pb.ErrorCode_ERROR_CODE_DUPLICATE_SKILL_USE || gameErr.PlayerID != "witch" {
+		t.Errorf("expected detailed ErrDuplicateSkillUse, got %v", err)
+	}
+}
+
 func TestEngine_SubmitSkillUse_InvalidSkill(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("villager", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("target", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
 	engine.Start()
@@ -163,13 +247,13 @@ func TestEngine_SubmitSkillUse_InvalidSkill(t *testing.T) {
 		TargetID: "target",
 	})
 
-	if err != ErrSkillNotAllowed {
+	if !errors.Is(err, ErrSkillNotAllowed) {
 		t.Errorf("expected ErrSkillNotAllowed, got %v", err)
 	}
 }
 
 func TestEngine_EndPhase(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -216,7 +300,7 @@ func TestEngine_EndPhase(t *testing.T) {
 }
 
 func TestEngine_EndPhase_GameOver_WolvesWin(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
 	engine.Start()
@@ -246,7 +330,7 @@ func TestEngine_EndPhase_GameOver_WolvesWin(t *testing.T) {
 }
 
 func TestEngine_EndPhase_GameOver_GoodWins(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -284,7 +368,7 @@ func TestEngine_EndPhase_GameOver_GoodWins(t *testing.T) {
 }
 
 func TestEngine_EndPhase_AlreadyEnded(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.state.Phase = pb.PhaseType_PHASE_TYPE_END
 
 	_, err := engine.EndPhase()
@@ -294,7 +378,7 @@ func TestEngine_EndPhase_AlreadyEnded(t *testing.T) {
 }
 
 func TestEngine_GetCurrentPhase(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	if engine.GetCurrentPhase() != pb.PhaseType_PHASE_TYPE_START {
 		t.Errorf("expected Phase=START, got %v", engine.GetCurrentPhase())
@@ -308,7 +392,7 @@ func TestEngine_GetCurrentPhase(t *testing.T) {
 }
 
 func TestEngine_GetCurrentRound(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	if engine.GetCurrentRound() != 0 {
 		t.Errorf("expected Round=0, got %d", engine.GetCurrentRound())
@@ -322,7 +406,7 @@ func TestEngine_GetCurrentRound(t *testing.T) {
 }
 
 func TestEngine_GetAllowedSkills(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.Start()
 
@@ -338,7 +422,7 @@ func TestEngine_GetAllowedSkills(t *testing.T) {
 }
 
 func TestEngine_GetAllowedSkills_Dead(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.state.players["wolf"].Alive = false
 	engine.Start()
@@ -351,7 +435,7 @@ func TestEngine_GetAllowedSkills_Dead(t *testing.T) {
 }
 
 func TestEngine_GetAllowedSkills_NotFound(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.Start()
 
 	skills := engine.GetAllowedSkills("nonexistent")
@@ -362,7 +446,7 @@ func TestEngine_GetAllowedSkills_NotFound(t *testing.T) {
 }
 
 func TestEngine_IsGameOver(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	if engine.IsGameOver() {
 		t.Error("expected IsGameOver=false initially")
@@ -376,7 +460,7 @@ func TestEngine_IsGameOver(t *testing.T) {
 }
 
 func TestEngine_OnEvent(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -395,14 +479,14 @@ func TestEngine_OnEvent(t *testing.T) {
 	})
 	engine.EndPhase()
 
-	// Should have 1 event (protect effect)
-	if eventCount != 1 {
-		t.Errorf("expected 1 event, got %d", eventCount)
+	// 开始时 GAME_STARTED、ROUND_STARTED、PHASE_STARTED，结束守卫阶段时 PROTECT、PHASE_ENDED、PHASE_STARTED
+	if eventCount != 6 {
+		t.Errorf("expected 6 events, got %d", eventCount)
 	}
 }
 
 func TestEngine_MultipleHandlers(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -426,16 +510,16 @@ func TestEngine_MultipleHandlers(t *testing.T) {
 	})
 	engine.EndPhase()
 
-	if count1 != 1 {
-		t.Errorf("expected handler1 called 1 time, got %d", count1)
+	if count1 != 6 {
+		t.Errorf("expected handler1 called 6 times, got %d", count1)
 	}
-	if count2 != 1 {
-		t.Errorf("expected handler2 called 1 time, got %d", count2)
+	if count2 != 6 {
+		t.Errorf("expected handler2 called 6 times, got %d", count2)
 	}
 }
 
 func TestEngine_Concurrency(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -467,7 +551,8 @@ func TestEngine_Concurrency(t *testing.T) {
 				Skill:    pb.SkillType_SKILL_TYPE_PROTECT,
 				TargetID: "v1",
 			})
-			if err != nil && err != ErrPlayerDead && err != ErrTargetDead {
+			// 同一意图只接受第一次提交，之后的重复提交被拒绝
+			if err != nil && !IsErrorCode(err, pb.ErrorCode_ERROR_CODE_PLAYER_DEAD) && !IsErrorCode(err, pb.ErrorCode_ERROR_CODE_TARGET_DEAD) && !IsErrorCode(err, pb.ErrorCode_ERROR_CODE_DUPLICATE_SKILL_USE) {
 				errors <- err
 			}
 		}()
@@ -482,7 +567,7 @@ func TestEngine_Concurrency(t *testing.T) {
 }
 
 func TestEngine_FullGameCycle(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	// Setup players
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -603,7 +688,7 @@ func TestEngine_FullGameCycle(t *testing.T) {
 }
 
 func TestEngine_GetPhaseInfo_NightGuard(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -635,7 +720,7 @@ func TestEngine_GetPhaseInfo_NightGuard(t *testing.T) {
 }
 
 func TestEngine_GetPhaseInfo_NightWolf(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("wolf2", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -672,7 +757,7 @@ func TestEngine_GetPhaseInfo_NightWolf(t *testing.T) {
 }
 
 func TestEngine_GetPhaseInfo_NightWitch(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -708,8 +793,46 @@ func TestEngine_GetPhaseInfo_NightWitch(t *testing.T) {
 	}
 }
 
+func TestEngine_GetPhaseInfo_NightHunter(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("wolf2", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("hunter", pb.RoleType_ROLE_TYPE_HUNTER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v3", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "hunter"})
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf2", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "hunter"})
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER)
+
+	// 死亡的猎人由角色定义放开行动
+	info := engine.GetPhaseInfo()
+	hunterInfo, ok := info.RoleInfos[pb.RoleType_ROLE_TYPE_HUNTER]
+	if !ok {
+		t.Fatal("expected hunter role info")
+	}
+	if len(hunterInfo.PlayerIDs) != 1 || hunterInfo.PlayerIDs[0] != "hunter" {
+		t.Errorf("expected triggered hunter, got %v", hunterInfo.PlayerIDs)
+	}
+	if view, _ := engine.GetPlayerView("hunter"); len(view.AllowedSkills) != 1 || view.AllowedSkills[0] != pb.SkillType_SKILL_TYPE_SHOOT {
+		t.Errorf("expected SHOOT for dead hunter, got %v", view.AllowedSkills)
+	}
+
+	// 开枪阶段之外死亡的猎人不能行动
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+	if ids := engine.GetPhaseInfo().RoleInfos[pb.RoleType_ROLE_TYPE_UNSPECIFIED].PlayerIDs; len(ids) != 5 {
+		t.Errorf("expected 5 alive speakers, got %v", ids)
+	}
+	if view, _ := engine.GetPlayerView("hunter"); len(view.AllowedSkills) != 0 {
+		t.Errorf("expected no skills for dead hunter during day, got %v", view.AllowedSkills)
+	}
+}
+
 func TestPhaseInfo_GodAnnouncement(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -748,3 +871,181 @@ func TestPhaseInfo_GodAnnouncement(t *testing.T) {
 		t.Errorf("expected GUARD role, got %v", actionSteps[0].Role)
 	}
 }
+
+func TestEngine_GameEndedPayload(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+
+	var ended *pb.GameEndedEvent
+	engine.OnEvent(func(event *pb.Event) {
+		if event.Type == pb.EventType_EVENT_TYPE_GAME_ENDED {
+			ended = event.GetGameEnded()
+		}
+	})
+	engine.Start()
+
+	// 第一夜狼人刀掉 v1，剩一狼一民，狼人胜利
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"})
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_END)
+
+	if ended.GetWinner() != pb.Camp_CAMP_EVIL || ended.GetReason() != pb.VictoryReason_VICTORY_REASON_WOLVES_OUTNUMBER || ended.GetRound() != 1 {
+		t.Errorf("expected evil win by outnumbering in round 1, got %v", ended)
+	}
+}
+
+func TestEngine_LifecycleEvents(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("seer", pb.RoleType_ROLE_TYPE_SEER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+
+	now := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	engine.now = func() time.Time { return now }
+
+	var events []*pb.Event
+	engine.OnEvent(func(event *pb.Event) {
+		events = append(events, event)
+	})
+	if err := engine.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// 开始时依次发布游戏开始、回合开始、第一个阶段开始
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events on start, got %d", len(events))
+	}
+	started := events[0].GetGameStarted()
+	if events[0].Type != pb.EventType_EVENT_TYPE_GAME_STARTED || len(started.GetBoard()) != 4 {
+		t.Fatalf("expected GAME_STARTED with 4 roles, got %v", events[0])
+	}
+	for i := 1; i < len(started.GetBoard()); i++ {
+		if started.GetBoard()[i-1] > started.GetBoard()[i] {
+			t.Errorf("expected sorted board, got %v", started.GetBoard())
+		}
+	}
+	if ids := started.GetPlayerIds(); len(ids) != 4 || ids[0] != "wolf1" || ids[3] != "v2" {
+		t.Errorf("expected player ids by seat, got %v", ids)
+	}
+	if round := events[1].GetRoundStarted(); round.GetRound() != 1 || len(round.GetAlivePlayerIds()) != 4 {
+		t.Errorf("expected round 1 with 4 alive players, got %v", events[1])
+	}
+	phase := events[2].GetPhase()
+	if events[2].Type != pb.EventType_EVENT_TYPE_PHASE_STARTED || phase.GetPhase() != FirstPhase {
+		t.Fatalf("expected PHASE_STARTED for %v, got %v", FirstPhase, events[2])
+	}
+	if !phase.GetDeadline().AsTime().Equal(now.Add(NightPhaseTimeout)) {
+		t.Errorf("expected deadline %v, got %v", now.Add(NightPhaseTimeout), phase.GetDeadline().AsTime())
+	}
+
+	// 切换阶段：先结束当前阶段，再开始下一阶段
+	events = nil
+	engine.EndPhase()
+	if len(events) != 2 {
+		t.Fatalf("expected PHASE_ENDED and PHASE_STARTED, got %v", events)
+	}
+	if ended := events[0].GetPhase(); events[0].Type != pb.EventType_EVENT_TYPE_PHASE_ENDED ||
+		ended.GetPhase() != FirstPhase || ended.GetNextPhase() != pb.PhaseType_PHASE_TYPE_NIGHT_WOLF {
+		t.Errorf("expected PHASE_ENDED %v -> NIGHT_WOLF, got %v", FirstPhase, events[0])
+	}
+	if events[1].GetPhase().GetPhase() != pb.PhaseType_PHASE_TYPE_NIGHT_WOLF {
+		t.Errorf("expected PHASE_STARTED for NIGHT_WOLF, got %v", events[1])
+	}
+
+	// 平安夜后天亮：夜晚汇总先于阶段切换
+	events = nil
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+	var summary *pb.NightSummaryEvent
+	for _, event := range events {
+		if event.Type == pb.EventType_EVENT_TYPE_NIGHT_SUMMARY {
+			summary = event.GetNightSummary()
+		}
+	}
+	if !summary.GetPeaceful() {
+		t.Errorf("expected peaceful night summary, got %v", summary)
+	}
+
+	// 新回合开始时在阶段开始之前发布回合开始
+	events = nil
+	advancePhase(t, engine, FirstPhase)
+	var types []pb.EventType
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	n := len(types)
+	if n < 3 || types[n-3] != pb.EventType_EVENT_TYPE_PHASE_ENDED ||
+		types[n-2] != pb.EventType_EVENT_TYPE_ROUND_STARTED || types[n-1] != pb.EventType_EVENT_TYPE_PHASE_STARTED {
+		t.Errorf("expected PHASE_ENDED, ROUND_STARTED, PHASE_STARTED at round change, got %v", types)
+	}
+	if round := events[n-2].GetRoundStarted().GetRound(); round != 2 {
+		t.Errorf("expected round 2, got %d", round)
+	}
+}
+
+// metricsRecorder 记录耗时和进行中游戏数等指标
+type metricsRecorder struct {
+	NopMetrics
+
+	phaseDurations map[pb.PhaseType]time.Duration
+	timeToAction   map[pb.SkillType]time.Duration
+	failures       map[pb.ErrorCode]int
+	inProgress     int
+}
+
+func (r *metricsRecorder) ObservePhaseDuration(phase pb.PhaseType, d time.Duration) {
+	r.phaseDurations[phase] = d
+}
+
+func (r *metricsRecorder) ObserveTimeToAction(skill pb.SkillType, d time.Duration) {
+	r.timeToAction[skill] = d
+}
+
+func (r *metricsRecorder) IncValidationFailure(code pb.ErrorCode) { r.failures[code]++ }
+func (r *metricsRecorder) AddGamesInProgress(delta int)           { r.inProgress += delta }
+
+func TestEngine_Metrics(t *testing.T) {
+	engine := newBusTestEngine(t)
+	recorder := &metricsRecorder{
+		phaseDurations: make(map[pb.PhaseType]time.Duration),
+		timeToAction:   make(map[pb.SkillType]time.Duration),
+		failures:       make(map[pb.ErrorCode]int),
+	}
+	engine.SetMetrics(recorder)
+
+	now := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	engine.now = func() time.Time { return now }
+	engine.Start()
+	if recorder.inProgress != 1 {
+		t.Fatalf("expected 1 game in progress, got %d", recorder.inProgress)
+	}
+
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	now = now.Add(7 * time.Second)
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "ghost"})
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"})
+	now = now.Add(3 * time.Second)
+	engine.EndPhase()
+
+	if got := recorder.timeToAction[pb.SkillType_SKILL_TYPE_KILL]; got != 7*time.Second {
+		t.Errorf("expected 7s to act, got %v", got)
+	}
+	if got := recorder.phaseDurations[pb.PhaseType_PHASE_TYPE_NIGHT_WOLF]; got != 10*time.Second {
+		t.Errorf("expected NIGHT_WOLF to last 10s, got %v", got)
+	}
+	if recorder.failures[pb.ErrorCode_ERROR_CODE_TARGET_NOT_FOUND] != 1 {
+		t.Errorf("expected a TARGET_NOT_FOUND failure, got %v", recorder.failures)
+	}
+
+	// 投出唯一的狼人，游戏结束
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_VOTE)
+	for _, id := range []string{"v2", "v3"} {
+		engine.SubmitSkillUse(&SkillUse{PlayerID: id, Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "wolf1"})
+	}
+	engine.EndPhase()
+	if !engine.IsGameOver() || recorder.inProgress != 0 {
+		t.Errorf("expected no games in progress after game end, got %d", recorder.inProgress)
+	}
+}
@@ -1,23 +1,103 @@
 package werewolf
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	pb "github.com/Zereker/werewolf/proto"
 )
 
 // GameError 游戏错误（实现 error 接口）
+// 引擎返回的技能校验错误带有上下文（玩家、技能、目标、阶段、回合），是预定义错误的副本：
+// 用 errors.Is(err, ErrTargetDead) 或 IsErrorCode 判断错误类型，不要用 == 比较。
+// 被 fmt.Errorf("%w") 包装后仍可以通过 errors.Is/errors.As 取得。
 type GameError struct {
 	Code    pb.ErrorCode
 	Message string
+
+	// 上下文（可选）
+	PlayerID string
+	Skill    pb.SkillType
+	TargetID string
+	Phase    pb.PhaseType
+	Round    int
 }
 
-// Error 实现 error 接口
+// Error 实现 error 接口，有上下文时附在消息之后
 func (e *GameError) Error() string {
-	if e.Message != "" {
-		return e.Message
+	msg := e.Message
+	if msg == "" {
+		msg = e.Code.String()
+	}
+	if details := e.details(); details != "" {
+		msg += " (" + details + ")"
+	}
+	return msg
+}
+
+// details 上下文描述，如 "player=wolf1 skill=SKILL_TYPE_KILL target=v1"
+func (e *GameError) details() string {
+	var parts []string
+	if e.PlayerID != "" {
+		parts = append(parts, "player="+e.PlayerID)
+	}
+	if e.Skill != pb.SkillType_SKILL_TYPE_UNSPECIFIED {
+		parts = append(parts, "skill="+e.Skill.String())
+	}
+	if e.TargetID != "" {
+		parts = append(parts, "target="+e.TargetID)
+	}
+	if e.Phase != pb.PhaseType_PHASE_TYPE_UNSPECIFIED {
+		parts = append(parts, "phase="+e.Phase.String())
+	}
+	if e.Round > 0 {
+		parts = append(parts, fmt.Sprintf("round=%d", e.Round))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Is 支持 errors.Is：错误码相同即匹配（与上下文无关）
+func (e *GameError) Is(target error) bool {
+	t, ok := target.(*GameError)
+	return ok && t.Code != pb.ErrorCode_ERROR_CODE_UNSPECIFIED && t.Code == e.Code
+}
+
+// WithSkillUse 返回带技能使用上下文的副本，原错误不变
+func (e *GameError) WithSkillUse(use *SkillUse, phase pb.PhaseType, round int) *GameError {
+	detailed := *e
+	detailed.PlayerID = use.PlayerID
+	detailed.Skill = use.Skill
+	detailed.TargetID = use.TargetID
+	detailed.Phase = phase
+	detailed.Round = round
+	return &detailed
+}
+
+// ToProto 转换为 protobuf 消息（用于传输）
+func (e *GameError) ToProto() *pb.ErrorDetail {
+	return &pb.ErrorDetail{
+		Code:     e.Code,
+		Message:  e.Message,
+		PlayerId: e.PlayerID,
+		Skill:    e.Skill,
+		TargetId: e.TargetID,
+		Phase:    e.Phase,
+		Round:    int32(e.Round),
+	}
+}
+
+// GameErrorFromProto 从 protobuf 消息还原游戏错误
+func GameErrorFromProto(detail *pb.ErrorDetail) *GameError {
+	return &GameError{
+		Code:     detail.GetCode(),
+		Message:  detail.GetMessage(),
+		PlayerID: detail.GetPlayerId(),
+		Skill:    detail.GetSkill(),
+		TargetID: detail.GetTargetId(),
+		Phase:    detail.GetPhase(),
+		Round:    int(detail.GetRound()),
 	}
-	return e.Code.String()
 }
 
 // NewGameError 创建游戏错误
@@ -30,28 +110,44 @@ func NewGameError(code pb.ErrorCode, message string) *GameError {
 
 // 预定义错误
 var (
-	ErrPlayerNotFound    = &GameError{Code: pb.ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND, Message: "player not found"}
-	ErrPlayerDead        = &GameError{Code: pb.ErrorCode_ERROR_CODE_PLAYER_DEAD, Message: "player is dead"}
-	ErrTargetNotFound    = &GameError{Code: pb.ErrorCode_ERROR_CODE_TARGET_NOT_FOUND, Message: "target not found"}
-	ErrTargetDead        = &GameError{Code: pb.ErrorCode_ERROR_CODE_TARGET_DEAD, Message: "target is dead"}
-	ErrSkillNotAllowed   = &GameError{Code: pb.ErrorCode_ERROR_CODE_SKILL_NOT_ALLOWED, Message: "skill not allowed in this phase"}
-	ErrGameNotStarted    = &GameError{Code: pb.ErrorCode_ERROR_CODE_GAME_NOT_STARTED, Message: "game not started"}
-	ErrGameEnded         = &GameError{Code: pb.ErrorCode_ERROR_CODE_GAME_ENDED, Message: "game has ended"}
-	ErrInvalidPhase      = &GameError{Code: pb.ErrorCode_ERROR_CODE_INVALID_PHASE, Message: "invalid phase"}
-	ErrMessageNotAllowed = &GameError{Code: pb.ErrorCode_ERROR_CODE_MESSAGE_NOT_ALLOWED, Message: "message not allowed in this phase"}
+	ErrPlayerNotFound     = &GameError{Code: pb.ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND, Message: "player not found"}
+	ErrPlayerDead         = &GameError{Code: pb.ErrorCode_ERROR_CODE_PLAYER_DEAD, Message: "player is dead"}
+	ErrTargetNotFound     = &GameError{Code: pb.ErrorCode_ERROR_CODE_TARGET_NOT_FOUND, Message: "target not found"}
+	ErrTargetDead         = &GameError{Code: pb.ErrorCode_ERROR_CODE_TARGET_DEAD, Message: "target is dead"}
+	ErrSkillNotAllowed    = &GameError{Code: pb.ErrorCode_ERROR_CODE_SKILL_NOT_ALLOWED, Message: "skill not allowed in this phase"}
+	ErrGameNotStarted     = &GameError{Code: pb.ErrorCode_ERROR_CODE_GAME_NOT_STARTED, Message: "game not started"}
+	ErrGameEnded          = &GameError{Code: pb.ErrorCode_ERROR_CODE_GAME_ENDED, Message: "game has ended"}
+	ErrInvalidPhase       = &GameError{Code: pb.ErrorCode_ERROR_CODE_INVALID_PHASE, Message: "invalid phase"}
+	ErrMessageNotAllowed  = &GameError{Code: pb.ErrorCode_ERROR_CODE_MESSAGE_NOT_ALLOWED, Message: "message not allowed in this phase"}
+	ErrGameNotFound       = &GameError{Code: pb.ErrorCode_ERROR_CODE_GAME_NOT_FOUND, Message: "game not found"}
+	ErrTooManyGames       = &GameError{Code: pb.ErrorCode_ERROR_CODE_TOO_MANY_GAMES, Message: "too many concurrent games"}
+	ErrShuttingDown       = &GameError{Code: pb.ErrorCode_ERROR_CODE_SHUTTING_DOWN, Message: "game manager is shutting down"}
+	ErrPlayerExists       = &GameError{Code: pb.ErrorCode_ERROR_CODE_PLAYER_EXISTS, Message: "player already exists"}
+	ErrLobbyFull          = &GameError{Code: pb.ErrorCode_ERROR_CODE_LOBBY_FULL, Message: "all seats are taken"}
+	ErrNotHost            = &GameError{Code: pb.ErrorCode_ERROR_CODE_NOT_HOST, Message: "only the host can do this"}
+	ErrPlayersNotReady    = &GameError{Code: pb.ErrorCode_ERROR_CODE_PLAYERS_NOT_READY, Message: "not all players are ready"}
+	ErrSeatMismatch       = &GameError{Code: pb.ErrorCode_ERROR_CODE_SEAT_MISMATCH, Message: "seat count does not match board"}
+	ErrGameStarted        = &GameError{Code: pb.ErrorCode_ERROR_CODE_GAME_STARTED, Message: "game already started"}
+	ErrSubscriberOverflow = &GameError{Code: pb.ErrorCode_ERROR_CODE_SUBSCRIBER_OVERFLOW, Message: "subscriber buffer overflow"}
+	ErrDuplicateSkillUse  = &GameError{Code: pb.ErrorCode_ERROR_CODE_DUPLICATE_SKILL_USE, Message: "skill use already submitted in this phase"}
+	ErrNoPendingSkillUse  = &GameError{Code: pb.ErrorCode_ERROR_CODE_NO_PENDING_SKILL_USE, Message: "no pending skill use in this phase"}
+	ErrGuardRepeat        = &GameError{Code: pb.ErrorCode_ERROR_CODE_GUARD_REPEAT, Message: "cannot protect same target consecutively"}
+	ErrNoAntidote         = &GameError{Code: pb.ErrorCode_ERROR_CODE_NO_ANTIDOTE, Message: "no antidote"}
+	ErrNoPoison           = &GameError{Code: pb.ErrorCode_ERROR_CODE_NO_POISON, Message: "no poison"}
+	ErrTargetNotDying     = &GameError{Code: pb.ErrorCode_ERROR_CODE_TARGET_NOT_DYING, Message: "target is not dying"}
+	ErrSelfTarget         = &GameError{Code: pb.ErrorCode_ERROR_CODE_SELF_TARGET, Message: "skill cannot target self"}
 )
 
-// IsErrorCode 检查错误是否匹配指定错误码
+// IsErrorCode 检查错误（或其包装链中的 GameError）是否匹配指定错误码
 func IsErrorCode(err error, code pb.ErrorCode) bool {
-	if gameErr, ok := err.(*GameError); ok {
-		return gameErr.Code == code
-	}
-	return false
+	var gameErr *GameError
+	return errors.As(err, &gameErr) && gameErr.Code == code
 }
 
-// GetErrorCode 从错误获取错误码
+// GetErrorCode 从错误（或其包装链中的 GameError）获取错误码
 func GetErrorCode(err error) pb.ErrorCode {
-	if gameErr, ok := err.(*GameError); ok {
+	var gameErr *GameError
+	if errors.As(err, &gameErr) {
 		return gameErr.Code
 	}
 	return pb.ErrorCode_ERROR_CODE_UNSPECIFIED
@@ -1,6 +1,8 @@
 package werewolf
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	pb "github.com/Zereker/werewolf/proto"
@@ -97,6 +99,11 @@ func TestPredefinedErrors(t *testing.T) {
 		{ErrGameNotStarted, pb.ErrorCode_ERROR_CODE_GAME_NOT_STARTED, "game not started"},
 		{ErrGameEnded, pb.ErrorCode_ERROR_CODE_GAME_ENDED, "game has ended"},
 		{ErrInvalidPhase, pb.ErrorCode_ERROR_CODE_INVALID_PHASE, "invalid phase"},
+		{ErrGuardRepeat, pb.ErrorCode_ERROR_CODE_GUARD_REPEAT, "cannot protect same target consecutively"},
+		{ErrNoAntidote, pb.ErrorCode_ERROR_CODE_NO_ANTIDOTE, "no antidote"},
+		{ErrNoPoison, pb.ErrorCode_ERROR_CODE_NO_POISON, "no poison"},
+		{ErrTargetNotDying, pb.ErrorCode_ERROR_CODE_TARGET_NOT_DYING, "target is not dying"},
+		{ErrSelfTarget, pb.ErrorCode_ERROR_CODE_SELF_TARGET, "skill cannot target self"},
 	}
 
 	for _, tt := range tests {
@@ -112,3 +119,42 @@ func TestPredefinedErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestGameError_IsAndAs(t *testing.T) {
+	use := &SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"}
+	detailed := ErrTargetDead.WithSkillUse(use, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF, 2)
+	wrapped := fmt.Errorf("submit failed: %w", detailed)
+
+	if !errors.Is(wrapped, ErrTargetDead) || errors.Is(wrapped, ErrPlayerDead) {
+		t.Error("expected errors.Is to match the sentinel by code through wrapping")
+	}
+	if !IsErrorCode(wrapped, pb.ErrorCode_ERROR_CODE_TARGET_DEAD) || GetErrorCode(wrapped) != pb.ErrorCode_ERROR_CODE_TARGET_DEAD {
+		t.Error("expected IsErrorCode/GetErrorCode to see through wrapping")
+	}
+
+	var gameErr *GameError
+	if !errors.As(wrapped, &gameErr) || gameErr.PlayerID != "wolf1" || gameErr.TargetID != "v1" || gameErr.Round != 2 {
+		t.Errorf("expected errors.As to return the detailed error, got %+v", gameErr)
+	}
+	if want := "target is dead (player=wolf1 skill=SKILL_TYPE_KILL target=v1 phase=PHASE_TYPE_NIGHT_WOLF round=2)"; detailed.Error() != want {
+		t.Errorf("expected %q, got %q", want, detailed.Error())
+	}
+
+	// 预定义错误不被修改
+	if ErrTargetDead.PlayerID != "" || ErrTargetDead.Error() != "target is dead" {
+		t.Errorf("expected sentinel to stay unchanged, got %+v", ErrTargetDead)
+	}
+}
+
+func TestGameError_Proto(t *testing.T) {
+	use := &SkillUse{PlayerID: "guard", Skill: pb.SkillType_SKILL_TYPE_PROTECT, TargetID: "v1"}
+	original := ErrGuardRepeat.WithSkillUse(use, pb.PhaseType_PHASE_TYPE_NIGHT_GUARD, 3)
+
+	restored := GameErrorFromProto(original.ToProto())
+	if *restored != *original {
+		t.Errorf("expected round trip to keep all fields, got %+v", restored)
+	}
+	if !errors.Is(restored, ErrGuardRepeat) {
+		t.Error("expected restored error to match its sentinel")
+	}
+}
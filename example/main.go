@@ -60,7 +60,10 @@ func basicGameSetup() {
 	config.SameGuardKillIsEmpty = true // 同守同杀为空刀
 
 	// 2. 创建游戏引擎
-	engine := werewolf.NewEngine(config)
+	engine, err := werewolf.NewEngine(config)
+	if err != nil {
+		log.Fatalf("创建引擎失败: %v", err)
+	}
 
 	// 3. 设置日志（可选）
 	engine.SetLogger(&SimpleLogger{})
@@ -97,7 +100,10 @@ func basicGameSetup() {
 func godNarratorDemo() {
 	fmt.Println("【示例2: 上帝（主持人）引导游戏】")
 
-	engine := werewolf.NewEngine(nil)
+	engine, err := werewolf.NewEngine(nil)
+	if err != nil {
+		log.Fatalf("创建引擎失败: %v", err)
+	}
 
 	// 添加玩家
 	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -238,7 +244,10 @@ func fullGameFlow() {
 	fmt.Println("【示例3: 完整游戏流程】")
 
 	// 创建引擎和玩家
-	engine := werewolf.NewEngine(nil) // 使用默认配置
+	engine, err := werewolf.NewEngine(nil) // 使用默认配置
+	if err != nil {
+		log.Fatalf("创建引擎失败: %v", err)
+	}
 
 	// 添加玩家
 	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -264,7 +273,7 @@ func fullGameFlow() {
 		case pb.EventType_EVENT_TYPE_ELIMINATE:
 			fmt.Printf("  [投票] %s 被投票出局\n", event.TargetId)
 		case pb.EventType_EVENT_TYPE_GAME_ENDED:
-			fmt.Printf("  [游戏结束] 获胜方: %s\n", event.Data["winner"])
+			fmt.Printf("  [游戏结束] 获胜方: %s\n", event.GetGameEnded().GetWinner())
 		}
 	})
 
@@ -282,7 +291,7 @@ func fullGameFlow() {
 	fmt.Printf("  当前阶段: %s\n", engine.GetCurrentPhase())
 
 	// 守卫保护村民
-	err := engine.SubmitSkillUse(&werewolf.SkillUse{
+	err = engine.SubmitSkillUse(&werewolf.SkillUse{
 		PlayerID: "guard",
 		Skill:    pb.SkillType_SKILL_TYPE_PROTECT,
 		TargetID: "villager",
@@ -422,7 +431,10 @@ func fullGameFlow() {
 func messagingDemo() {
 	fmt.Println("【示例4: 消息系统演示】")
 
-	engine := werewolf.NewEngine(nil)
+	engine, err := werewolf.NewEngine(nil)
+	if err != nil {
+		log.Fatalf("创建引擎失败: %v", err)
+	}
 
 	// 添加玩家（需要足够多的好人防止游戏过早结束）
 	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -447,7 +459,7 @@ func messagingDemo() {
 	fmt.Printf("\n  当前阶段: %s (狼人交流阶段)\n", engine.GetCurrentPhase())
 
 	// 狼人之间交流（只有狼人能收到）
-	err := engine.SendMessage("wolf1", "杀 villager1 吧")
+	err = engine.SendMessage("wolf1", "杀 villager1 吧")
 	if err != nil {
 		fmt.Printf("  发送消息失败: %v\n", err)
 	}
@@ -503,3 +515,8 @@ func (l *SimpleLogger) Warn(msg string, fields ...werewolf.Field) {
 func (l *SimpleLogger) Error(msg string, fields ...werewolf.Field) {
 	fmt.Printf("  [ERROR] %s\n", msg)
 }
+
+// With 只打印消息，忽略上下文字段
+func (l *SimpleLogger) With(fields ...werewolf.Field) werewolf.Logger {
+	return l
+}
@@ -0,0 +1,206 @@
+package werewolf
+
+import (
+	"sort"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// ==================== 扩展数据 ====================
+//
+// 第三方角色可以通过类型化的键在 State 上保存自己的计数和标记，无需修改 PlayerState：
+//
+//	var guesses = werewolf.NewPlayerKey[int]("knight.duels")
+//
+//	n, _ := guesses.Get(state, playerID)
+//	guesses.Set(state, playerID, n+1)
+//
+// 玩家数据在整局游戏中保留，回合数据在每回合开始时随 RoundContext 一起清空。
+// 扩展数据会包含在 Snapshot 中。
+
+// PlayerKey 玩家扩展数据的键，T 为值类型
+// 名称在所有扩展中应唯一（建议带角色前缀），同名但类型不同的键读不到对方的值
+type PlayerKey[T any] struct {
+	name string
+}
+
+// NewPlayerKey 创建玩家扩展数据的键
+func NewPlayerKey[T any](name string) PlayerKey[T] {
+	return PlayerKey[T]{name: name}
+}
+
+// Name 键的名称
+func (k PlayerKey[T]) Name() string {
+	return k.name
+}
+
+// Get 读取玩家的扩展数据，玩家不存在或未设置时返回零值和 false
+func (k PlayerKey[T]) Get(s *State, playerID string) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var zero T
+	player, ok := s.players[playerID]
+	if !ok {
+		return zero, false
+	}
+	value, ok := player.ext[k.name].(T)
+	return value, ok
+}
+
+// Set 设置玩家的扩展数据，玩家不存在时返回 false
+func (k PlayerKey[T]) Set(s *State, playerID string, value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	player, ok := s.players[playerID]
+	if !ok {
+		return false
+	}
+	if player.ext == nil {
+		player.ext = make(map[string]any)
+	}
+	player.ext[k.name] = value
+	return true
+}
+
+// Delete 删除玩家的扩展数据
+func (k PlayerKey[T]) Delete(s *State, playerID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if player, ok := s.players[playerID]; ok {
+		delete(player.ext, k.name)
+	}
+}
+
+// RoundKey 回合扩展数据的键，T 为值类型（每回合开始时清空）
+type RoundKey[T any] struct {
+	name string
+}
+
+// NewRoundKey 创建回合扩展数据的键
+func NewRoundKey[T any](name string) RoundKey[T] {
+	return RoundKey[T]{name: name}
+}
+
+// Name 键的名称
+func (k RoundKey[T]) Name() string {
+	return k.name
+}
+
+// Get 读取本回合的扩展数据，未设置时返回零值和 false
+func (k RoundKey[T]) Get(s *State) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var zero T
+	if s.RoundCtx == nil {
+		return zero, false
+	}
+	value, ok := s.RoundCtx.ext[k.name].(T)
+	return value, ok
+}
+
+// Set 设置本回合的扩展数据
+func (k RoundKey[T]) Set(s *State, value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.RoundCtx == nil {
+		s.RoundCtx = NewRoundContext()
+	}
+	if s.RoundCtx.ext == nil {
+		s.RoundCtx.ext = make(map[string]any)
+	}
+	s.RoundCtx.ext[k.name] = value
+}
+
+// Delete 删除本回合的扩展数据
+func (k RoundKey[T]) Delete(s *State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.RoundCtx != nil {
+		delete(s.RoundCtx.ext, k.name)
+	}
+}
+
+// ExtensionCloner 扩展数据的值可以实现该接口，复制回合上下文和生成快照时深拷贝；
+// 未实现时按值复制，map、切片、指针等引用类型会与状态共享
+type ExtensionCloner interface {
+	CloneExtension() any
+}
+
+// copyExtensions 复制扩展数据
+func copyExtensions(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]any, len(m))
+	for k, v := range m {
+		if cloner, ok := v.(ExtensionCloner); ok {
+			v = cloner.CloneExtension()
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// ==================== 快照 ====================
+
+// StateSnapshot 游戏状态快照（深拷贝，不随后续状态变化）
+type StateSnapshot struct {
+	Phase    pb.PhaseType
+	SubStep  int
+	Round    int
+	Players  []PlayerSnapshot // 按座位排序
+	RoundCtx *RoundContext    // 回合上下文副本
+
+	RoundExtensions map[string]any // 回合扩展数据（按键名称）
+}
+
+// PlayerSnapshot 玩家状态快照
+type PlayerSnapshot struct {
+	PlayerInfo
+	LastProtectedTarget string
+	Extensions          map[string]any       // 玩家扩展数据（按键名称）
+	Knowledge           []*pb.KnowledgeEntry // 私有信息记录
+}
+
+// Snapshot 生成游戏状态快照
+func (s *State) Snapshot() *StateSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot := &StateSnapshot{
+		Phase:    s.Phase,
+		SubStep:  s.SubStep,
+		Round:    s.Round,
+		Players:  make([]PlayerSnapshot, 0, len(s.players)),
+		RoundCtx: s.RoundCtx.clone(),
+	}
+	if s.RoundCtx != nil {
+		snapshot.RoundExtensions = copyExtensions(s.RoundCtx.ext)
+	}
+	for _, p := range s.players {
+		snapshot.Players = append(snapshot.Players, PlayerSnapshot{
+			PlayerInfo:          s.playerInfoLocked(p),
+			LastProtectedTarget: p.LastProtectedTarget,
+			Extensions:          copyExtensions(p.ext),
+			Knowledge:           cloneKnowledge(p.knowledge),
+		})
+	}
+	sort.Slice(snapshot.Players, func(i, j int) bool {
+		return snapshot.Players[i].Seat < snapshot.Players[j].Seat
+	})
+	return snapshot
+}
+
+// Snapshot 生成游戏状态快照（包含扩展数据）
+func (e *Engine) Snapshot() *StateSnapshot {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.state.Snapshot()
+}
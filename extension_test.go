@@ -0,0 +1,93 @@
+package werewolf
+
+import (
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// clonedList 实现 ExtensionCloner 的测试值
+type clonedList []string
+
+func (l clonedList) CloneExtension() any {
+	return append(clonedList(nil), l...)
+}
+
+func TestPlayerKey(t *testing.T) {
+	state := NewState()
+	state.AddPlayer("p1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+
+	counter := NewPlayerKey[int]("test.counter")
+	if _, ok := counter.Get(state, "p1"); ok {
+		t.Error("expected unset value")
+	}
+	if counter.Set(state, "ghost", 1) {
+		t.Error("expected Set to fail for unknown player")
+	}
+	counter.Set(state, "p1", 3)
+	if n, ok := counter.Get(state, "p1"); !ok || n != 3 {
+		t.Errorf("expected 3, got %d (%v)", n, ok)
+	}
+
+	// 同名但类型不同的键读不到值
+	if _, ok := NewPlayerKey[string]("test.counter").Get(state, "p1"); ok {
+		t.Error("expected key with a different type to miss")
+	}
+
+	counter.Delete(state, "p1")
+	if _, ok := counter.Get(state, "p1"); ok {
+		t.Error("expected value to be deleted")
+	}
+}
+
+func TestRoundKey_ResetEachRound(t *testing.T) {
+	state := NewState()
+	flag := NewRoundKey[bool]("test.flag")
+
+	flag.Set(state, true)
+	if v, ok := flag.Get(state); !ok || !v {
+		t.Fatalf("expected flag to be set, got %v (%v)", v, ok)
+	}
+	if _, ok := state.GetRoundContext().ext[flag.Name()]; !ok {
+		t.Error("expected GetRoundContext copy to include round data")
+	}
+
+	state.NextPhase(pb.PhaseType_PHASE_TYPE_NIGHT_GUARD)
+	if _, ok := flag.Get(state); ok {
+		t.Error("expected round data to be cleared on a new round")
+	}
+}
+
+func TestState_Snapshot(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("p2", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("p1", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	notes := NewPlayerKey[clonedList]("test.notes")
+	marks := NewRoundKey[int]("test.marks")
+	notes.Set(engine.state, "p1", clonedList{"a"})
+	marks.Set(engine.state, 7)
+
+	snapshot := engine.Snapshot()
+	if snapshot.Phase != FirstPhase || snapshot.Round != 1 {
+		t.Errorf("unexpected phase %v round %d", snapshot.Phase, snapshot.Round)
+	}
+	if len(snapshot.Players) != 2 || snapshot.Players[0].ID != "p2" || !snapshot.Players[1].HasAntidote {
+		t.Fatalf("expected players by seat, got %+v", snapshot.Players)
+	}
+	if snapshot.RoundExtensions[marks.Name()] != 7 {
+		t.Errorf("expected round extension in snapshot, got %v", snapshot.RoundExtensions)
+	}
+
+	// 快照是深拷贝
+	list := snapshot.Players[1].Extensions[notes.Name()].(clonedList)
+	list[0] = "changed"
+	if got, _ := notes.Get(engine.state, "p1"); got[0] != "a" {
+		t.Errorf("expected snapshot to clone extension values, state has %v", got)
+	}
+	engine.state.players["p2"].Alive = false
+	if !snapshot.Players[0].Alive {
+		t.Error("expected snapshot not to follow later state changes")
+	}
+}
@@ -0,0 +1,243 @@
+// Package gamehost 托管 GameManager 中的游戏，供 gRPC 和 HTTP 服务共用
+//
+// Host 登记管理器创建的游戏，管理器移除游戏时关闭对应的 Removed channel；
+// Game.Stream 基于 Engine.Subscribe 按玩家（迷雾规则）推送事件和消息，
+// 首条推送当前玩家视角，消费过慢的连接被断开。传输层只负责把推送项编码成各自的格式。
+//
+// 玩家通过 Game.Join 入座时签发座位令牌，之后以该玩家身份进行的操作（准备、提交技能、
+// 查看视角、订阅事件流、房主操作等）都应先用 Game.Authorize 校验令牌；
+// 开始游戏、推进阶段等不带玩家参数的房主操作用 Game.AuthorizeHost 同时校验房主身份。
+package gamehost
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// StreamBufferSize 每个事件流的缓冲大小，写满视为客户端过慢并断开
+const StreamBufferSize = 256
+
+var (
+	// ErrNotSeated 玩家不在游戏中
+	ErrNotSeated = errors.New("player not in game")
+	// ErrStreamTooSlow 事件流消费过慢被断开
+	ErrStreamTooSlow = errors.New("event stream too slow, disconnected")
+	// ErrGameRemoved 游戏已被管理器移除
+	ErrGameRemoved = errors.New("game was removed")
+	// ErrInvalidToken 座位令牌与玩家不匹配（或玩家未通过 Join 入座）
+	ErrInvalidToken = errors.New("invalid seat token")
+)
+
+// Host 托管的游戏集合（传输层状态随管理器移除游戏而清理）
+type Host struct {
+	manager *werewolf.GameManager
+
+	mu    sync.RWMutex
+	games map[string]*Game
+}
+
+// New 创建游戏托管
+// manager 为 nil 时使用默认配置创建游戏管理器
+func New(manager *werewolf.GameManager) *Host {
+	if manager == nil {
+		manager = werewolf.NewGameManager(werewolf.DefaultGameManagerConfig())
+	}
+
+	h := &Host{
+		manager: manager,
+		games:   make(map[string]*Game),
+	}
+	manager.OnGameRemoved(h.removeGame)
+	return h
+}
+
+// Manager 返回游戏管理器
+func (h *Host) Manager() *werewolf.GameManager {
+	return h.manager
+}
+
+// CreateGame 通过管理器创建游戏并登记
+func (h *Host) CreateGame(config *werewolf.GameConfig) (*Game, error) {
+	engine, err := h.manager.CreateGame(config)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Game{
+		ID:      engine.ID(),
+		Engine:  engine,
+		tokens:  make(map[string]string),
+		removed: make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	h.games[g.ID] = g
+	h.mu.Unlock()
+	return g, nil
+}
+
+// RemoveGame 移除游戏（通过管理器，触发移除回调）
+func (h *Host) RemoveGame(id string) {
+	h.manager.RemoveGame(id)
+}
+
+// Lookup 按ID查找游戏
+func (h *Host) Lookup(id string) (*Game, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	g, ok := h.games[id]
+	return g, ok
+}
+
+// removeGame 管理器移除游戏时清理登记并关闭事件流
+func (h *Host) removeGame(id string, _ *werewolf.Engine) {
+	h.mu.Lock()
+	g, ok := h.games[id]
+	delete(h.games, id)
+	h.mu.Unlock()
+
+	if ok {
+		close(g.removed)
+	}
+}
+
+// Game 托管的单局游戏（座位、房主等大厅状态由引擎维护）
+type Game struct {
+	ID     string
+	Engine *werewolf.Engine
+
+	mu     sync.Mutex
+	tokens map[string]string // 玩家ID -> 座位令牌
+
+	removed chan struct{} // 游戏被管理器移除时关闭
+}
+
+// Removed 游戏被管理器移除时关闭
+func (g *Game) Removed() <-chan struct{} {
+	return g.removed
+}
+
+// Join 入座并签发座位令牌（令牌只在这里返回一次）
+func (g *Game) Join(playerID string) (seat int, token string, err error) {
+	seat, err = g.Engine.Join(playerID)
+	if err != nil {
+		return 0, "", err
+	}
+	token = newToken()
+
+	g.mu.Lock()
+	g.tokens[playerID] = token
+	g.mu.Unlock()
+	return seat, token, nil
+}
+
+// Leave 离座并作废座位令牌
+func (g *Game) Leave(playerID string) error {
+	if err := g.Engine.Leave(playerID); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	delete(g.tokens, playerID)
+	g.mu.Unlock()
+	return nil
+}
+
+// Authorize 校验玩家的座位令牌，不匹配时返回 ErrInvalidToken
+func (g *Game) Authorize(playerID, token string) error {
+	g.mu.Lock()
+	expected, ok := g.tokens[playerID]
+	g.mu.Unlock()
+
+	if !ok || subtle.ConstantTimeCompare([]byte(expected), []byte(token)) != 1 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// AuthorizeHost 校验房主的座位令牌：令牌不匹配返回 ErrInvalidToken，不是房主返回 werewolf.ErrNotHost
+// 用于不带玩家参数的房主操作（开始游戏、推进阶段）
+func (g *Game) AuthorizeHost(hostID, token string) error {
+	if err := g.Authorize(hostID, token); err != nil {
+		return err
+	}
+	if g.Engine.GetLobby().HostID != hostID {
+		return werewolf.ErrNotHost
+	}
+	return nil
+}
+
+// newToken 生成随机座位令牌
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic("gamehost: crypto/rand failed: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
+
+// StreamItem 事件流推送项（三者之一）
+type StreamItem struct {
+	View    *pb.PlayerView
+	Event   *pb.Event
+	Message *pb.ChatMessage
+}
+
+// Stream 向玩家推送可见的事件和消息，首条为当前视角（开始前玩家尚未发牌，只有座位信息）
+// 游戏结束（GAME_ENDED 送出之后）返回 nil；ctx 结束返回 ctx.Err()；
+// 玩家不在游戏中返回 ErrNotSeated，消费过慢返回 ErrStreamTooSlow，游戏被移除返回 ErrGameRemoved；
+// send 返回错误时原样返回。
+func (g *Game) Stream(ctx context.Context, playerID string, send func(StreamItem) error) error {
+	if !g.Engine.HasPlayer(playerID) {
+		return ErrNotSeated
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// 先订阅再取视角，视角之后发布的事件不会遗漏
+	items := g.Engine.Subscribe(ctx, werewolf.StreamFilter{PlayerID: playerID},
+		werewolf.WithBuffer(StreamBufferSize), werewolf.WithOverflow(werewolf.OverflowDisconnect))
+
+	initial := &pb.PlayerView{PlayerId: playerID, Phase: pb.PhaseType_PHASE_TYPE_START}
+	if view, err := g.Engine.GetPlayerView(playerID); err == nil {
+		initial = view
+	}
+	if err := send(StreamItem{View: initial}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-g.removed:
+			return ErrGameRemoved
+		case item, ok := <-items:
+			if !ok {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return ErrStreamTooSlow
+			}
+			next := StreamItem{Event: item.Event}
+			if item.Message != nil {
+				next = StreamItem{Message: item.Message.ToProto(item.ReceiverIDs)}
+			}
+			if err := send(next); err != nil {
+				return err
+			}
+			if item.Event != nil && item.Event.Type == pb.EventType_EVENT_TYPE_GAME_ENDED {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package gamehost
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// startGame 创建 1 狼 1 预言家 1 村民的游戏并直接指定角色开始
+func startGame(t *testing.T, host *Host) *Game {
+	t.Helper()
+
+	g, err := host.CreateGame(nil)
+	if err != nil {
+		t.Fatalf("CreateGame failed: %v", err)
+	}
+	g.Engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	g.Engine.AddPlayer("seer", pb.RoleType_ROLE_TYPE_SEER, pb.Camp_CAMP_GOOD)
+	g.Engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	if err := g.Engine.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	return g
+}
+
+// collect 在后台运行 Stream，返回推送项和 Stream 的返回值
+func collect(ctx context.Context, g *Game, playerID string) (<-chan StreamItem, <-chan error) {
+	items := make(chan StreamItem, StreamBufferSize)
+	result := make(chan error, 1)
+	go func() {
+		result <- g.Stream(ctx, playerID, func(item StreamItem) error {
+			items <- item
+			return nil
+		})
+	}()
+	return items, result
+}
+
+func TestGame_StreamFiltersByPlayer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	g := startGame(t, New(nil))
+
+	seerItems, seerDone := collect(ctx, g, "seer")
+	villagerItems, villagerDone := collect(ctx, g, "v1")
+	for _, items := range []<-chan StreamItem{seerItems, villagerItems} {
+		if first := <-items; first.View == nil {
+			t.Fatalf("expected initial view, got %+v", first)
+		}
+	}
+
+	// 预言家查验只有自己可见；投出狼人后两条流都在 GAME_ENDED 之后正常结束
+	for g.Engine.GetCurrentPhase() != pb.PhaseType_PHASE_TYPE_NIGHT_SEER {
+		g.Engine.EndSubStep()
+	}
+	g.Engine.SubmitSkillUse(&werewolf.SkillUse{PlayerID: "seer", Skill: pb.SkillType_SKILL_TYPE_CHECK, TargetID: "wolf1"})
+	for g.Engine.GetCurrentPhase() != pb.PhaseType_PHASE_TYPE_VOTE {
+		g.Engine.EndSubStep()
+	}
+	g.Engine.SubmitSkillUse(&werewolf.SkillUse{PlayerID: "seer", Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "wolf1"})
+	g.Engine.SubmitSkillUse(&werewolf.SkillUse{PlayerID: "v1", Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "wolf1"})
+	g.Engine.EndPhase()
+
+	for _, done := range []<-chan error{seerDone, villagerDone} {
+		if err := <-done; err != nil {
+			t.Fatalf("expected stream to end cleanly, got %v", err)
+		}
+	}
+	checks := func(items <-chan StreamItem) int {
+		n := 0
+		for len(items) > 0 {
+			if item := <-items; item.Event.GetType() == pb.EventType_EVENT_TYPE_CHECK {
+				n++
+			}
+		}
+		return n
+	}
+	if got := checks(seerItems); got != 1 {
+		t.Errorf("expected seer to see own check, got %d", got)
+	}
+	if got := checks(villagerItems); got != 0 {
+		t.Errorf("expected villager not to see check, got %d", got)
+	}
+
+	// 游戏结束后连接：视角之后补发 GAME_ENDED 并正常结束
+	items, done := collect(ctx, g, "v1")
+	if err := <-done; err != nil {
+		t.Fatalf("expected stream of finished game to end cleanly, got %v", err)
+	}
+	if view := <-items; !view.View.GetGameOver() {
+		t.Errorf("expected finished view, got %+v", view)
+	}
+	if last := <-items; last.Event.GetType() != pb.EventType_EVENT_TYPE_GAME_ENDED {
+		t.Errorf("expected GAME_ENDED after view, got %+v", last)
+	}
+}
+
+func TestGame_StreamErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	host := New(nil)
+	g := startGame(t, host)
+
+	if err := g.Stream(ctx, "ghost", func(StreamItem) error { return nil }); !errors.Is(err, ErrNotSeated) {
+		t.Errorf("expected ErrNotSeated, got %v", err)
+	}
+
+	// send 的错误原样返回
+	sendErr := errors.New("client gone")
+	if err := g.Stream(ctx, "v1", func(StreamItem) error { return sendErr }); err != sendErr {
+		t.Errorf("expected send error, got %v", err)
+	}
+
+	items, done := collect(ctx, g, "v1")
+	<-items
+	host.RemoveGame(g.ID)
+	if err := <-done; !errors.Is(err, ErrGameRemoved) {
+		t.Errorf("expected ErrGameRemoved, got %v", err)
+	}
+	if _, ok := host.Lookup(g.ID); ok {
+		t.Error("expected removed game to be unregistered")
+	}
+}
+
+func TestGame_SeatTokens(t *testing.T) {
+	g, err := New(nil).CreateGame(nil)
+	if err != nil {
+		t.Fatalf("CreateGame failed: %v", err)
+	}
+
+	_, hostToken, err := g.Join("host")
+	if err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+	_, guestToken, _ := g.Join("guest")
+	if hostToken == "" || hostToken == guestToken {
+		t.Fatalf("expected distinct tokens, got %q and %q", hostToken, guestToken)
+	}
+
+	if err := g.Authorize("host", hostToken); err != nil {
+		t.Errorf("expected host token to be accepted, got %v", err)
+	}
+	for _, tc := range []struct{ player, token string }{
+		{"host", guestToken},
+		{"host", ""},
+		{"", ""},
+		{"ghost", hostToken},
+	} {
+		if err := g.Authorize(tc.player, tc.token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken for %q/%q, got %v", tc.player, tc.token, err)
+		}
+	}
+
+	// 房主操作还要求是房主本人
+	if err := g.AuthorizeHost("host", hostToken); err != nil {
+		t.Errorf("expected host to be authorized, got %v", err)
+	}
+	if err := g.AuthorizeHost("guest", guestToken); !errors.Is(err, werewolf.ErrNotHost) {
+		t.Errorf("expected ErrNotHost for guest, got %v", err)
+	}
+	if err := g.AuthorizeHost("host", guestToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken for wrong token, got %v", err)
+	}
+
+	// 重复入座不签发新令牌，离座后令牌作废
+	if _, _, err := g.Join("guest"); err == nil {
+		t.Error("expected duplicate join to fail")
+	}
+	if err := g.Leave("guest"); err != nil {
+		t.Fatalf("Leave failed: %v", err)
+	}
+	if err := g.Authorize("guest", guestToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected token to be revoked after leaving, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package werewolf
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// PhaseTransition 阶段图中的一条边
+type PhaseTransition struct {
+	From      pb.PhaseType
+	To        pb.PhaseType
+	Dynamic   bool   // 是否为条件转换（由 PhaseConfig.Transitions 声明）
+	Condition string // 条件转换的条件名称
+}
+
+// Transitions 列出阶段图的所有边：START 到入口阶段、每个阶段的 NextPhase 以及条件转换
+// 按来源阶段的枚举值排序，同一来源的声明式边在前
+func (c *GameConfig) Transitions() []PhaseTransition {
+	transitions := []PhaseTransition{{From: pb.PhaseType_PHASE_TYPE_START, To: FirstPhase}}
+	for _, phase := range c.sortedPhases() {
+		config := c.Phases[phase]
+		if config == nil {
+			continue
+		}
+		if config.NextPhase != pb.PhaseType_PHASE_TYPE_UNSPECIFIED {
+			transitions = append(transitions, PhaseTransition{From: phase, To: config.NextPhase})
+		}
+		for _, transition := range config.Transitions {
+			transitions = append(transitions, PhaseTransition{From: phase, To: transition.Target, Dynamic: true, Condition: transition.When})
+		}
+	}
+	return transitions
+}
+
+// WriteDOT 以 Graphviz DOT 格式输出阶段图
+// 节点标注阶段的超时和步骤（角色、技能、是否必须、是否多人），条件转换用虚线并标注条件名称
+func (c *GameConfig) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph werewolf {\n")
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box, fontname=\"sans-serif\"];\n")
+	b.WriteString("  edge [fontname=\"sans-serif\"];\n")
+
+	for _, node := range c.graphNodes() {
+		if lines := c.phaseLabel(node); lines != nil {
+			fmt.Fprintf(&b, "  %s [label=%s];\n", phaseName(node), dotQuote(strings.Join(lines, "\n")))
+		} else {
+			fmt.Fprintf(&b, "  %s [shape=ellipse];\n", phaseName(node))
+		}
+	}
+	for _, t := range c.Transitions() {
+		if t.Dynamic {
+			fmt.Fprintf(&b, "  %s -> %s [style=dashed, label=%s];\n", phaseName(t.From), phaseName(t.To), dotQuote(t.Condition))
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s;\n", phaseName(t.From), phaseName(t.To))
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid 以 Mermaid flowchart 格式输出阶段图（内容同 WriteDOT）
+func (c *GameConfig) WriteMermaid(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+
+	for _, node := range c.graphNodes() {
+		if lines := c.phaseLabel(node); lines != nil {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", phaseName(node), mermaidEscape(strings.Join(lines, "<br/>")))
+		} else {
+			fmt.Fprintf(&b, "  %s((%s))\n", phaseName(node), phaseName(node))
+		}
+	}
+	for _, t := range c.Transitions() {
+		if t.Dynamic {
+			fmt.Fprintf(&b, "  %s -.->|\"%s\"| %s\n", phaseName(t.From), mermaidEscape(t.Condition), phaseName(t.To))
+		} else {
+			fmt.Fprintf(&b, "  %s --> %s\n", phaseName(t.From), phaseName(t.To))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// graphNodes 图中出现的所有阶段（START、已配置阶段以及边指向的未配置阶段），按枚举值排序
+func (c *GameConfig) graphNodes() []pb.PhaseType {
+	nodes := make(map[pb.PhaseType]bool)
+	for phase := range c.Phases {
+		nodes[phase] = true
+	}
+	for _, t := range c.Transitions() {
+		nodes[t.From] = true
+		nodes[t.To] = true
+	}
+	return sortedKeys(nodes)
+}
+
+// phaseLabel 阶段节点的标注行，未配置的阶段返回 nil
+func (c *GameConfig) phaseLabel(phase pb.PhaseType) []string {
+	config := c.Phases[phase]
+	if config == nil {
+		return nil
+	}
+
+	timeout := fmt.Sprintf("超时 %v", config.Timeout)
+	if config.Timeout == 0 {
+		timeout = fmt.Sprintf("超时 %v（默认）", c.DefaultTimeout)
+	}
+	lines := []string{phaseName(phase), timeout}
+
+	for _, step := range config.Steps {
+		role := roleName(step.Role)
+		if step.Role == pb.RoleType_ROLE_TYPE_UNSPECIFIED {
+			role = "所有玩家"
+		}
+		line := fmt.Sprintf("%d. %s: %s", step.Order, role, strings.TrimPrefix(step.Skill.String(), "SKILL_TYPE_"))
+
+		var flags []string
+		if step.Required {
+			flags = append(flags, "必须")
+		}
+		if step.Multiple {
+			flags = append(flags, "多人")
+		}
+		if len(flags) > 0 {
+			line += "（" + strings.Join(flags, "，") + "）"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// dotQuote 转义为 DOT 字符串（换行写作 \n）
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// mermaidEscape 转义 Mermaid 标签中的双引号
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -0,0 +1,89 @@
+package werewolf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+func TestGameConfig_Transitions(t *testing.T) {
+	transitions := DefaultGameConfig().Transitions()
+
+	if first := transitions[0]; first.From != pb.PhaseType_PHASE_TYPE_START || first.To != FirstPhase {
+		t.Errorf("expected START -> %v first, got %+v", FirstPhase, first)
+	}
+
+	dynamic := make(map[pb.PhaseType]pb.PhaseType)
+	for _, tr := range transitions {
+		if tr.Dynamic {
+			dynamic[tr.From] = tr.To
+			if tr.Condition != ConditionHunterTriggered {
+				t.Errorf("expected hunter condition on dynamic transition %+v", tr)
+			}
+		}
+	}
+	if dynamic[pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE] != pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER ||
+		dynamic[pb.PhaseType_PHASE_TYPE_VOTE] != pb.PhaseType_PHASE_TYPE_DAY_HUNTER {
+		t.Errorf("expected hunter transitions, got %v", dynamic)
+	}
+	// 1 条入口边 + 9 个阶段的 NextPhase + 2 条动态边
+	if len(transitions) != 12 {
+		t.Errorf("expected 12 transitions, got %d", len(transitions))
+	}
+}
+
+func TestGameConfig_WriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := DefaultGameConfig().WriteDOT(&buf); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"digraph werewolf {",
+		"START -> NIGHT_GUARD;",
+		"VOTE -> NIGHT_GUARD;",
+		`NIGHT_RESOLVE -> NIGHT_HUNTER [style=dashed, label="hunter_triggered"];`,
+		`VOTE -> DAY_HUNTER [style=dashed`,
+		`NIGHT_WOLF [label="NIGHT_WOLF\n超时 30s\n0. GOD: ANNOUNCE（必须）\n1. WEREWOLF: KILL（必须，多人）"];`,
+		`1. 所有玩家: VOTE（必须，多人）`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected DOT to contain %q, got:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "}\n") {
+		t.Errorf("expected closed graph, got:\n%s", out)
+	}
+}
+
+func TestGameConfig_WriteMermaid(t *testing.T) {
+	config := DefaultGameConfig()
+	config.Phases[pb.PhaseType_PHASE_TYPE_DAY].Timeout = 0
+
+	var buf bytes.Buffer
+	if err := config.WriteMermaid(&buf); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"flowchart TD",
+		"START((START))",
+		"START --> NIGHT_GUARD",
+		`VOTE -.->|"hunter_triggered"| DAY_HUNTER`,
+		`DAY["DAY<br/>超时 30s（默认）<br/>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected Mermaid to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestDotQuote(t *testing.T) {
+	if got := dotQuote("a \"b\"\nc\\d"); got != `"a \"b\"\nc\\d"` {
+		t.Errorf("unexpected quoting: %s", got)
+	}
+}
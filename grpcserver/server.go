@@ -0,0 +1,405 @@
+// Package grpcserver 提供托管多个游戏引擎的 gRPC 服务实现
+//
+// 游戏由 werewolf.GameManager 统一创建、查找和回收，每个游戏由一个 werewolf.Engine 托管。
+// 事件流按玩家进行迷雾过滤，每个玩家只收到自己有权看到的事件和消息。
+// JoinGame 返回座位令牌，以玩家（或房主）身份的请求都要携带令牌，不匹配时返回 Unauthenticated。
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Zereker/werewolf"
+	"github.com/Zereker/werewolf/gamehost"
+	pb "github.com/Zereker/werewolf/proto"
+	"github.com/Zereker/werewolf/proto/servicepb"
+)
+
+// Server 游戏服务
+type Server struct {
+	servicepb.UnimplementedGameServiceServer
+
+	host *gamehost.Host // 托管的游戏（事件流分发与 HTTP 服务共用）
+}
+
+// NewServer 创建游戏服务
+// manager 为 nil 时使用默认配置创建游戏管理器
+func NewServer(manager *werewolf.GameManager) *Server {
+	return &Server{host: gamehost.New(manager)}
+}
+
+// Register 将服务注册到 gRPC 服务器
+func (s *Server) Register(gs *grpc.Server) {
+	servicepb.RegisterGameServiceServer(gs, s)
+}
+
+// CreateGame 创建游戏
+// 板子可以在建房时预设（roles 或预设名称），也可以由房主稍后通过 SetBoard 选择
+func (s *Server) CreateGame(ctx context.Context, req *servicepb.CreateGameRequest) (*servicepb.CreateGameResponse, error) {
+	board, hasBoard, err := boardFromRequest(req.GetRoles(), req.GetBoard())
+	if err != nil {
+		return nil, err
+	}
+
+	config := werewolf.DefaultGameConfig()
+	if req.GetRules() != nil {
+		rules, err := rulesFromProto(req.GetRules())
+		if err != nil {
+			return nil, err
+		}
+		config.ApplyRules(rules)
+	}
+
+	g, err := s.host.CreateGame(config)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if hasBoard {
+		if err := g.Engine.SetBoard("", board); err != nil {
+			s.host.RemoveGame(g.ID)
+			return nil, toStatus(err)
+		}
+	}
+
+	return &servicepb.CreateGameResponse{GameId: g.ID}, nil
+}
+
+// JoinGame 加入游戏
+func (s *Server) JoinGame(ctx context.Context, req *servicepb.JoinGameRequest) (*servicepb.JoinGameResponse, error) {
+	g, err := s.getGame(req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetPlayerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "player_id must not be empty")
+	}
+
+	seat, token, err := g.Join(req.GetPlayerId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &servicepb.JoinGameResponse{Seat: int32(seat), Token: token}, nil
+}
+
+// LeaveGame 离开游戏
+func (s *Server) LeaveGame(ctx context.Context, req *servicepb.LeaveGameRequest) (*servicepb.LeaveGameResponse, error) {
+	g, err := s.getPlayerGame(req.GetGameId(), req.GetPlayerId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.Leave(req.GetPlayerId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &servicepb.LeaveGameResponse{}, nil
+}
+
+// SetReady 设置准备状态
+func (s *Server) SetReady(ctx context.Context, req *servicepb.SetReadyRequest) (*servicepb.SetReadyResponse, error) {
+	g, err := s.getPlayerGame(req.GetGameId(), req.GetPlayerId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.Engine.SetReady(req.GetPlayerId(), req.GetReady()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &servicepb.SetReadyResponse{}, nil
+}
+
+// SetBoard 房主选择板子
+func (s *Server) SetBoard(ctx context.Context, req *servicepb.SetBoardRequest) (*servicepb.SetBoardResponse, error) {
+	g, err := s.getPlayerGame(req.GetGameId(), req.GetHostId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+	board, hasBoard, err := boardFromRequest(req.GetRoles(), req.GetBoard())
+	if err != nil {
+		return nil, err
+	}
+	if !hasBoard {
+		return nil, status.Error(codes.InvalidArgument, "roles or board must be set")
+	}
+
+	if err := g.Engine.SetBoard(req.GetHostId(), board); err != nil {
+		return nil, toStatus(err)
+	}
+	return &servicepb.SetBoardResponse{}, nil
+}
+
+// SetRules 房主选择规则变体
+func (s *Server) SetRules(ctx context.Context, req *servicepb.SetRulesRequest) (*servicepb.SetRulesResponse, error) {
+	g, err := s.getPlayerGame(req.GetGameId(), req.GetHostId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := rulesFromProto(req.GetRules())
+	if err != nil {
+		return nil, err
+	}
+	if err := g.Engine.SetRules(req.GetHostId(), rules); err != nil {
+		return nil, toStatus(err)
+	}
+	return &servicepb.SetRulesResponse{}, nil
+}
+
+// GetLobby 获取大厅信息
+func (s *Server) GetLobby(ctx context.Context, req *servicepb.GetLobbyRequest) (*servicepb.GetLobbyResponse, error) {
+	g, err := s.getGame(req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+
+	lobby := g.Engine.GetLobby()
+	resp := &servicepb.GetLobbyResponse{
+		HostId: lobby.HostID,
+		Board:  lobby.Board.Name,
+		Roles:  lobby.Board.Roles,
+		Rules: &servicepb.GameRules{
+			WitchCanSaveSelf:     lobby.Rules.WitchCanSaveSelf,
+			GuardCanProtectSelf:  lobby.Rules.GuardCanProtectSelf,
+			GuardCanRepeat:       lobby.Rules.GuardCanRepeat,
+			SameGuardKillIsEmpty: lobby.Rules.SameGuardKillIsEmpty,
+			WolfKillUnanimous:    lobby.Rules.WolfKillUnanimous,
+			WolfTiePolicy:        lobby.Rules.WolfTiePolicy.String(),
+		},
+		Started: g.Engine.GetCurrentPhase() != pb.PhaseType_PHASE_TYPE_START,
+	}
+	for _, seat := range lobby.Seats {
+		resp.Seats = append(resp.Seats, &servicepb.Seat{
+			Number:   int32(seat.Number),
+			PlayerId: seat.PlayerID,
+			Ready:    seat.Ready,
+		})
+	}
+	return resp, nil
+}
+
+// Start 房主发牌并开始游戏（座位数需与板子一致且全部准备）
+func (s *Server) Start(ctx context.Context, req *servicepb.StartRequest) (*servicepb.StartResponse, error) {
+	g, err := s.getHostGame(req.GetGameId(), req.GetHostId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.Engine.Start(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &servicepb.StartResponse{
+		Phase: g.Engine.GetCurrentPhase(),
+		Round: int32(g.Engine.GetCurrentRound()),
+	}, nil
+}
+
+// SubmitSkill 提交技能使用
+func (s *Server) SubmitSkill(ctx context.Context, req *servicepb.SubmitSkillRequest) (*servicepb.SubmitSkillResponse, error) {
+	g, err := s.getPlayerGame(req.GetGameId(), req.GetPlayerId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.Engine.SubmitSkillUse(&werewolf.SkillUse{
+		PlayerID: req.GetPlayerId(),
+		Skill:    req.GetSkill(),
+		TargetID: req.GetTargetId(),
+		Content:  req.GetContent(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &servicepb.SubmitSkillResponse{}, nil
+}
+
+// SendMessage 发送游戏内消息
+func (s *Server) SendMessage(ctx context.Context, req *servicepb.SendMessageRequest) (*servicepb.SendMessageResponse, error) {
+	g, err := s.getPlayerGame(req.GetGameId(), req.GetPlayerId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.Engine.SendMessage(req.GetPlayerId(), req.GetContent()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &servicepb.SendMessageResponse{}, nil
+}
+
+// AdvancePhase 房主结束当前阶段（支持猎人等动态阶段）
+func (s *Server) AdvancePhase(ctx context.Context, req *servicepb.AdvancePhaseRequest) (*servicepb.AdvancePhaseResponse, error) {
+	g, err := s.getHostGame(req.GetGameId(), req.GetHostId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+	if g.Engine.GetCurrentPhase() == pb.PhaseType_PHASE_TYPE_START {
+		return nil, toStatus(werewolf.ErrGameNotStarted)
+	}
+
+	if _, err := g.Engine.EndSubStep(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &servicepb.AdvancePhaseResponse{
+		Phase:    g.Engine.GetCurrentPhase(),
+		Round:    int32(g.Engine.GetCurrentRound()),
+		GameOver: g.Engine.IsGameOver(),
+	}, nil
+}
+
+// GetView 获取玩家视角
+func (s *Server) GetView(ctx context.Context, req *servicepb.GetViewRequest) (*servicepb.GetViewResponse, error) {
+	g, err := s.getPlayerGame(req.GetGameId(), req.GetPlayerId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := g.Engine.GetPlayerView(req.GetPlayerId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &servicepb.GetViewResponse{View: view}, nil
+}
+
+// StreamEvents 推送玩家可见的事件和消息，游戏结束后关闭流
+func (s *Server) StreamEvents(req *servicepb.StreamEventsRequest, stream servicepb.GameService_StreamEventsServer) error {
+	g, err := s.getPlayerGame(req.GetGameId(), req.GetPlayerId(), req.GetToken())
+	if err != nil {
+		return err
+	}
+
+	err = g.Stream(stream.Context(), req.GetPlayerId(), func(item gamehost.StreamItem) error {
+		resp := &servicepb.StreamEventsResponse{}
+		switch {
+		case item.View != nil:
+			resp.Item = &servicepb.StreamEventsResponse_View{View: item.View}
+		case item.Event != nil:
+			resp.Item = &servicepb.StreamEventsResponse_Event{Event: item.Event}
+		default:
+			resp.Item = &servicepb.StreamEventsResponse_Message{Message: item.Message}
+		}
+		return stream.Send(resp)
+	})
+	switch {
+	case errors.Is(err, gamehost.ErrNotSeated):
+		return status.Errorf(codes.NotFound, "player %s not in game", req.GetPlayerId())
+	case errors.Is(err, gamehost.ErrStreamTooSlow):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, gamehost.ErrGameRemoved):
+		return status.Errorf(codes.Unavailable, "game %s was removed", g.ID)
+	}
+	return err
+}
+
+// getGame 按ID查找游戏
+func (s *Server) getGame(id string) (*gamehost.Game, error) {
+	g, ok := s.host.Lookup(id)
+	if !ok {
+		return nil, toStatus(werewolf.ErrGameNotFound)
+	}
+	return g, nil
+}
+
+// getPlayerGame 按ID查找游戏并校验玩家的座位令牌
+func (s *Server) getPlayerGame(id, playerID, token string) (*gamehost.Game, error) {
+	g, err := s.getGame(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.Authorize(playerID, token); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v for player %q", err, playerID)
+	}
+	return g, nil
+}
+
+// getHostGame 按ID查找游戏并校验房主的座位令牌（开始游戏、推进阶段）
+func (s *Server) getHostGame(id, hostID, token string) (*gamehost.Game, error) {
+	g, err := s.getGame(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.AuthorizeHost(hostID, token); err != nil {
+		if errors.Is(err, gamehost.ErrInvalidToken) {
+			return nil, status.Errorf(codes.Unauthenticated, "%v for player %q", err, hostID)
+		}
+		return nil, toStatus(err)
+	}
+	return g, nil
+}
+
+// boardFromRequest 从请求构造板子：优先使用自定义角色，否则按预设名称查找
+// 两者都为空时返回 false
+func boardFromRequest(roles []pb.RoleType, name string) (werewolf.Board, bool, error) {
+	if len(roles) > 0 {
+		return werewolf.Board{Name: name, Roles: roles}, true, nil
+	}
+	if name == "" {
+		return werewolf.Board{}, false, nil
+	}
+	board, ok := werewolf.LookupBoard(name)
+	if !ok {
+		return werewolf.Board{}, false, status.Errorf(codes.InvalidArgument, "unknown board %q", name)
+	}
+	return board, true, nil
+}
+
+// rulesFromProto 转换规则变体，平票策略名称未知时返回 InvalidArgument
+func rulesFromProto(rules *servicepb.GameRules) (werewolf.Rules, error) {
+	tiePolicy := werewolf.WolfTieNoKill
+	if name := rules.GetWolfTiePolicy(); name != "" {
+		policy, ok := werewolf.ParseWolfTiePolicy(name)
+		if !ok {
+			return werewolf.Rules{}, status.Errorf(codes.InvalidArgument, "unknown wolf tie policy %q", name)
+		}
+		tiePolicy = policy
+	}
+	return werewolf.Rules{
+		WitchCanSaveSelf:     rules.GetWitchCanSaveSelf(),
+		GuardCanProtectSelf:  rules.GetGuardCanProtectSelf(),
+		GuardCanRepeat:       rules.GetGuardCanRepeat(),
+		SameGuardKillIsEmpty: rules.GetSameGuardKillIsEmpty(),
+		WolfKillUnanimous:    rules.GetWolfKillUnanimous(),
+		WolfTiePolicy:        tiePolicy,
+	}, nil
+}
+
+// toStatus 将引擎错误转换为 gRPC 状态，结构化错误（pb.ErrorDetail）附在状态详情中
+func toStatus(err error) error {
+	var gameErr *werewolf.GameError
+	if !errors.As(err, &gameErr) {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	code := codes.FailedPrecondition
+	switch gameErr.Code {
+	case pb.ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND,
+		pb.ErrorCode_ERROR_CODE_TARGET_NOT_FOUND,
+		pb.ErrorCode_ERROR_CODE_GAME_NOT_FOUND,
+		pb.ErrorCode_ERROR_CODE_NO_PENDING_SKILL_USE:
+		code = codes.NotFound
+	case pb.ErrorCode_ERROR_CODE_PLAYER_EXISTS,
+		pb.ErrorCode_ERROR_CODE_DUPLICATE_SKILL_USE:
+		code = codes.AlreadyExists
+	case pb.ErrorCode_ERROR_CODE_INVALID_CONFIG:
+		code = codes.InvalidArgument
+	case pb.ErrorCode_ERROR_CODE_NOT_HOST:
+		code = codes.PermissionDenied
+	case pb.ErrorCode_ERROR_CODE_TOO_MANY_GAMES:
+		code = codes.ResourceExhausted
+	case pb.ErrorCode_ERROR_CODE_SHUTTING_DOWN:
+		code = codes.Unavailable
+	}
+
+	st := status.New(code, fmt.Sprintf("%s: %s", gameErr.Code, gameErr.Error()))
+	if detailed, err := st.WithDetails(gameErr.ToProto()); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,460 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+	"github.com/Zereker/werewolf/proto/servicepb"
+)
+
+// newTestClient 启动进程内 gRPC 服务（bufconn，无需网络）
+func newTestClient(t *testing.T) servicepb.GameServiceClient {
+	t.Helper()
+	return newTestClientWithManager(t, nil)
+}
+
+func newTestClientWithManager(t *testing.T, manager *werewolf.GameManager) servicepb.GameServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	NewServer(manager).Register(gs)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return servicepb.NewGameServiceClient(conn)
+}
+
+// setupGame 创建并开始一局 1 狼 1 预言家 3 村民的游戏，返回游戏ID、角色 -> 玩家ID 映射和 玩家ID -> 座位令牌
+func setupGame(t *testing.T, ctx context.Context, client servicepb.GameServiceClient) (string, map[pb.RoleType][]string, map[string]string) {
+	t.Helper()
+
+	created, err := client.CreateGame(ctx, &servicepb.CreateGameRequest{
+		Roles: []pb.RoleType{
+			pb.RoleType_ROLE_TYPE_WEREWOLF,
+			pb.RoleType_ROLE_TYPE_SEER,
+			pb.RoleType_ROLE_TYPE_VILLAGER,
+			pb.RoleType_ROLE_TYPE_VILLAGER,
+			pb.RoleType_ROLE_TYPE_VILLAGER,
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateGame failed: %v", err)
+	}
+	gameID := created.GetGameId()
+
+	players := []string{"p1", "p2", "p3", "p4", "p5"}
+	tokens := make(map[string]string, len(players))
+	for i, id := range players {
+		resp, err := client.JoinGame(ctx, &servicepb.JoinGameRequest{GameId: gameID, PlayerId: id})
+		if err != nil {
+			t.Fatalf("JoinGame %s failed: %v", id, err)
+		}
+		if resp.GetSeat() != int32(i+1) {
+			t.Errorf("expected seat %d, got %d", i+1, resp.GetSeat())
+		}
+		tokens[id] = resp.GetToken()
+		if _, err := client.SetReady(ctx, &servicepb.SetReadyRequest{GameId: gameID, PlayerId: id, Ready: true, Token: tokens[id]}); err != nil {
+			t.Fatalf("SetReady %s failed: %v", id, err)
+		}
+	}
+
+	// 开始游戏只有持令牌的房主可以操作
+	if _, err := client.Start(ctx, &servicepb.StartRequest{GameId: gameID}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without host token, got %v", err)
+	}
+	if _, err := client.Start(ctx, &servicepb.StartRequest{GameId: gameID, HostId: "p2", Token: tokens["p2"]}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for non-host, got %v", err)
+	}
+	if _, err := client.Start(ctx, &servicepb.StartRequest{GameId: gameID, HostId: "p1", Token: tokens["p1"]}); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	roles := make(map[pb.RoleType][]string)
+	for _, id := range players {
+		resp, err := client.GetView(ctx, &servicepb.GetViewRequest{GameId: gameID, PlayerId: id, Token: tokens[id]})
+		if err != nil {
+			t.Fatalf("GetView %s failed: %v", id, err)
+		}
+		roles[resp.GetView().GetRole()] = append(roles[resp.GetView().GetRole()], id)
+	}
+	return gameID, roles, tokens
+}
+
+// join 加入游戏并返回座位令牌
+func join(t *testing.T, ctx context.Context, client servicepb.GameServiceClient, gameID, playerID string) string {
+	t.Helper()
+	resp, err := client.JoinGame(ctx, &servicepb.JoinGameRequest{GameId: gameID, PlayerId: playerID})
+	if err != nil {
+		t.Fatalf("JoinGame %s failed: %v", playerID, err)
+	}
+	if resp.GetToken() == "" {
+		t.Fatalf("expected seat token for %s", playerID)
+	}
+	return resp.GetToken()
+}
+
+// openStream 打开事件流并读取首条视角推送
+func openStream(t *testing.T, ctx context.Context, client servicepb.GameServiceClient, gameID, playerID, token string) servicepb.GameService_StreamEventsClient {
+	t.Helper()
+
+	stream, err := client.StreamEvents(ctx, &servicepb.StreamEventsRequest{GameId: gameID, PlayerId: playerID, Token: token})
+	if err != nil {
+		t.Fatalf("StreamEvents failed: %v", err)
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive initial view: %v", err)
+	}
+	if first.GetView().GetPlayerId() != playerID {
+		t.Fatalf("expected initial view for %s, got %v", playerID, first)
+	}
+	return stream
+}
+
+// flowEvents 游戏流程事件（阶段、回合、汇总），按顺序断言具体事件时跳过
+var flowEvents = map[pb.EventType]bool{
+	pb.EventType_EVENT_TYPE_GAME_STARTED:  true,
+	pb.EventType_EVENT_TYPE_ROUND_STARTED: true,
+	pb.EventType_EVENT_TYPE_PHASE_STARTED: true,
+	pb.EventType_EVENT_TYPE_PHASE_ENDED:   true,
+	pb.EventType_EVENT_TYPE_VOTE_RESULT:   true,
+	pb.EventType_EVENT_TYPE_NIGHT_SUMMARY: true,
+}
+
+// recvSkippingFlow 读取下一条非流程事件的推送
+func recvSkippingFlow(stream servicepb.GameService_StreamEventsClient) (*servicepb.StreamEventsResponse, error) {
+	for {
+		item, err := stream.Recv()
+		if err != nil || !flowEvents[item.GetEvent().GetType()] {
+			return item, err
+		}
+	}
+}
+
+// advance 房主（p1）推进阶段
+func advance(t *testing.T, ctx context.Context, client servicepb.GameServiceClient, gameID, hostToken string) *servicepb.AdvancePhaseResponse {
+	t.Helper()
+	resp, err := client.AdvancePhase(ctx, &servicepb.AdvancePhaseRequest{GameId: gameID, HostId: "p1", Token: hostToken})
+	if err != nil {
+		t.Fatalf("AdvancePhase failed: %v", err)
+	}
+	return resp
+}
+
+func TestServer_GameFlowWithFogOfWar(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := newTestClient(t)
+
+	gameID, roles, tokens := setupGame(t, ctx, client)
+	wolf := roles[pb.RoleType_ROLE_TYPE_WEREWOLF][0]
+	seer := roles[pb.RoleType_ROLE_TYPE_SEER][0]
+	victim := roles[pb.RoleType_ROLE_TYPE_VILLAGER][0]
+	bystander := roles[pb.RoleType_ROLE_TYPE_VILLAGER][1]
+
+	seerStream := openStream(t, ctx, client, gameID, seer, tokens[seer])
+	bystanderStream := openStream(t, ctx, client, gameID, bystander, tokens[bystander])
+
+	// NIGHT_GUARD -> NIGHT_WOLF
+	if resp := advance(t, ctx, client, gameID, tokens["p1"]); resp.GetPhase() != pb.PhaseType_PHASE_TYPE_NIGHT_WOLF {
+		t.Fatalf("expected NIGHT_WOLF, got %v", resp.GetPhase())
+	}
+
+	// 狼人聊天只有狼人能收到，村民发言被拒绝
+	if _, err := client.SendMessage(ctx, &servicepb.SendMessageRequest{GameId: gameID, PlayerId: bystander, Content: "hi", Token: tokens[bystander]}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition for villager chat at night, got %v", err)
+	}
+
+	if _, err := client.SubmitSkill(ctx, &servicepb.SubmitSkillRequest{
+		GameId: gameID, PlayerId: wolf, Skill: pb.SkillType_SKILL_TYPE_KILL, TargetId: victim, Token: tokens[wolf],
+	}); err != nil {
+		t.Fatalf("wolf kill failed: %v", err)
+	}
+	advance(t, ctx, client, gameID, tokens["p1"]) // NIGHT_WOLF -> NIGHT_WITCH
+	advance(t, ctx, client, gameID, tokens["p1"]) // NIGHT_WITCH -> NIGHT_SEER
+
+	if _, err := client.SubmitSkill(ctx, &servicepb.SubmitSkillRequest{
+		GameId: gameID, PlayerId: seer, Skill: pb.SkillType_SKILL_TYPE_CHECK, TargetId: wolf, Token: tokens[seer],
+	}); err != nil {
+		t.Fatalf("seer check failed: %v", err)
+	}
+	advance(t, ctx, client, gameID, tokens["p1"]) // NIGHT_SEER -> NIGHT_RESOLVE
+	if resp := advance(t, ctx, client, gameID, tokens["p1"]); resp.GetPhase() != pb.PhaseType_PHASE_TYPE_DAY {
+		t.Fatalf("expected DAY, got %v", resp.GetPhase())
+	}
+
+	// 预言家收到查验结果
+	item, err := recvSkippingFlow(seerStream)
+	if err != nil {
+		t.Fatalf("seer stream recv failed: %v", err)
+	}
+	if item.GetEvent().GetType() != pb.EventType_EVENT_TYPE_CHECK || item.GetEvent().GetTargetId() != wolf {
+		t.Errorf("expected CHECK on %s, got %v", wolf, item)
+	}
+
+	// 夜晚击杀带有死因，玩家只从夜晚总结得知死亡名单；村民看不到查验结果
+	for _, stream := range []servicepb.GameService_StreamEventsClient{seerStream, bystanderStream} {
+		for {
+			item, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("stream recv failed: %v", err)
+			}
+			event := item.GetEvent()
+			if event.GetType() == pb.EventType_EVENT_TYPE_KILL || (stream == bystanderStream && event.GetType() == pb.EventType_EVENT_TYPE_CHECK) {
+				t.Errorf("expected %v to be hidden, got %v", event.GetType(), event)
+			}
+			if event.GetType() == pb.EventType_EVENT_TYPE_NIGHT_SUMMARY {
+				if dead := event.GetNightSummary().GetDeadPlayerIds(); len(dead) != 1 || dead[0] != victim {
+					t.Errorf("expected %s in night summary, got %v", victim, dead)
+				}
+				break
+			}
+		}
+	}
+
+	// 白天发言推送给所有存活玩家
+	if _, err := client.SendMessage(ctx, &servicepb.SendMessageRequest{GameId: gameID, PlayerId: seer, Content: "wolf found", Token: tokens[seer]}); err != nil {
+		t.Fatalf("day message failed: %v", err)
+	}
+	item, err = recvSkippingFlow(bystanderStream)
+	if err != nil {
+		t.Fatalf("bystander stream recv failed: %v", err)
+	}
+	if item.GetMessage().GetContent() != "wolf found" || item.GetMessage().GetSenderId() != seer {
+		t.Errorf("expected day message from seer, got %v", item)
+	}
+}
+
+func TestServer_GetViewHidesRoles(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := newTestClient(t)
+
+	gameID, roles, tokens := setupGame(t, ctx, client)
+	villager := roles[pb.RoleType_ROLE_TYPE_VILLAGER][0]
+	wolf := roles[pb.RoleType_ROLE_TYPE_WEREWOLF][0]
+
+	// 其他玩家的令牌不能用来查看视角
+	if _, err := client.GetView(ctx, &servicepb.GetViewRequest{GameId: gameID, PlayerId: wolf, Token: tokens[villager]}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated for another player's token, got %v", err)
+	}
+	stream, err := client.StreamEvents(ctx, &servicepb.StreamEventsRequest{GameId: gameID, PlayerId: wolf})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated for stream without token, got %v", err)
+	}
+
+	resp, err := client.GetView(ctx, &servicepb.GetViewRequest{GameId: gameID, PlayerId: villager, Token: tokens[villager]})
+	if err != nil {
+		t.Fatalf("GetView failed: %v", err)
+	}
+	view := resp.GetView()
+	if len(view.GetPlayers()) != 5 {
+		t.Fatalf("expected 5 players in view, got %d", len(view.GetPlayers()))
+	}
+	for _, p := range view.GetPlayers() {
+		if p.GetId() == villager {
+			if p.GetRole() != pb.RoleType_ROLE_TYPE_VILLAGER {
+				t.Errorf("expected own role visible, got %v", p.GetRole())
+			}
+		} else if p.GetRole() != pb.RoleType_ROLE_TYPE_UNSPECIFIED {
+			t.Errorf("expected role of %s hidden, got %v", p.GetId(), p.GetRole())
+		}
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := newTestClient(t)
+
+	if _, err := client.JoinGame(ctx, &servicepb.JoinGameRequest{GameId: "missing", PlayerId: "p1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for unknown game, got %v", err)
+	}
+	if _, err := client.CreateGame(ctx, &servicepb.CreateGameRequest{Board: "missing"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for unknown board, got %v", err)
+	}
+
+	created, err := client.CreateGame(ctx, &servicepb.CreateGameRequest{
+		Roles: []pb.RoleType{pb.RoleType_ROLE_TYPE_WEREWOLF, pb.RoleType_ROLE_TYPE_VILLAGER},
+	})
+	if err != nil {
+		t.Fatalf("CreateGame failed: %v", err)
+	}
+	gameID := created.GetGameId()
+
+	token := join(t, ctx, client, gameID, "p1")
+	if _, err := client.JoinGame(ctx, &servicepb.JoinGameRequest{GameId: gameID, PlayerId: "p1"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists for duplicate join, got %v", err)
+	}
+	if _, err := client.Start(ctx, &servicepb.StartRequest{GameId: gameID, HostId: "p1", Token: token}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition for missing players, got %v", err)
+	}
+	if _, err := client.AdvancePhase(ctx, &servicepb.AdvancePhaseRequest{GameId: gameID}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without host token, got %v", err)
+	}
+	if _, err := client.AdvancePhase(ctx, &servicepb.AdvancePhaseRequest{GameId: gameID, HostId: "p1", Token: token}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition before start, got %v", err)
+	}
+	// 未入座的玩家没有令牌
+	if _, err := client.SubmitSkill(ctx, &servicepb.SubmitSkillRequest{
+		GameId: gameID, PlayerId: "ghost", Skill: pb.SkillType_SKILL_TYPE_VOTE, Token: token,
+	}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated for unknown player, got %v", err)
+	}
+	_, err = client.SubmitSkill(ctx, &servicepb.SubmitSkillRequest{
+		GameId: gameID, PlayerId: "p1", Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetId: "ghost", Token: token,
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound before cards are dealt, got %v", err)
+	}
+	// 结构化错误附在状态详情中
+	details := status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatalf("expected one status detail, got %v", details)
+	}
+	if detail, ok := details[0].(*pb.ErrorDetail); !ok || detail.Code != pb.ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND || detail.PlayerId != "p1" {
+		t.Errorf("expected ErrorDetail for p1, got %v", details[0])
+	}
+}
+
+func TestServer_Lobby(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := newTestClient(t)
+
+	// 建房时不指定板子，由房主选择
+	created, err := client.CreateGame(ctx, &servicepb.CreateGameRequest{})
+	if err != nil {
+		t.Fatalf("CreateGame failed: %v", err)
+	}
+	gameID := created.GetGameId()
+
+	hostToken := join(t, ctx, client, gameID, "host")
+	guestToken := join(t, ctx, client, gameID, "guest")
+	hostStream := openStream(t, ctx, client, gameID, "host", hostToken)
+
+	if _, err := client.SetBoard(ctx, &servicepb.SetBoardRequest{
+		GameId: gameID, HostId: "guest", Board: werewolf.BoardSixPlayers, Token: guestToken,
+	}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for non-host, got %v", err)
+	}
+	// 冒充房主（或留空绕过房主校验）需要房主的令牌
+	for _, hostID := range []string{"host", ""} {
+		if _, err := client.SetBoard(ctx, &servicepb.SetBoardRequest{
+			GameId: gameID, HostId: hostID, Board: werewolf.BoardSixPlayers, Token: guestToken,
+		}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("expected Unauthenticated for host_id %q with guest token, got %v", hostID, err)
+		}
+	}
+	if _, err := client.SetBoard(ctx, &servicepb.SetBoardRequest{
+		GameId: gameID, HostId: "host", Board: werewolf.BoardSixPlayers, Token: hostToken,
+	}); err != nil {
+		t.Fatalf("SetBoard failed: %v", err)
+	}
+	if _, err := client.SetRules(ctx, &servicepb.SetRulesRequest{
+		GameId: gameID, HostId: "host", Rules: &servicepb.GameRules{WolfTiePolicy: "coin_flip"}, Token: hostToken,
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for unknown wolf tie policy, got %v", err)
+	}
+	if _, err := client.SetRules(ctx, &servicepb.SetRulesRequest{
+		GameId: gameID, HostId: "host", Rules: &servicepb.GameRules{WolfKillUnanimous: true, WolfTiePolicy: "random"}, Token: hostToken,
+	}); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+	if _, err := client.SetReady(ctx, &servicepb.SetReadyRequest{GameId: gameID, PlayerId: "guest", Ready: true, Token: guestToken}); err != nil {
+		t.Fatalf("SetReady failed: %v", err)
+	}
+	if _, err := client.LeaveGame(ctx, &servicepb.LeaveGameRequest{GameId: gameID, PlayerId: "guest", Token: guestToken}); err != nil {
+		t.Fatalf("LeaveGame failed: %v", err)
+	}
+	// 离座后令牌作废
+	if _, err := client.SetReady(ctx, &servicepb.SetReadyRequest{GameId: gameID, PlayerId: "guest", Ready: true, Token: guestToken}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated after leaving, got %v", err)
+	}
+
+	// 大厅变更推送给已入座的玩家
+	for _, expected := range []pb.EventType{
+		pb.EventType_EVENT_TYPE_BOARD_CHANGED,
+		pb.EventType_EVENT_TYPE_RULES_CHANGED,
+		pb.EventType_EVENT_TYPE_PLAYER_READY,
+		pb.EventType_EVENT_TYPE_PLAYER_LEFT,
+	} {
+		item, err := hostStream.Recv()
+		if err != nil {
+			t.Fatalf("host stream recv failed: %v", err)
+		}
+		if item.GetEvent().GetType() != expected {
+			t.Errorf("expected %v, got %v", expected, item)
+		}
+	}
+
+	lobby, err := client.GetLobby(ctx, &servicepb.GetLobbyRequest{GameId: gameID})
+	if err != nil {
+		t.Fatalf("GetLobby failed: %v", err)
+	}
+	if lobby.GetHostId() != "host" || lobby.GetBoard() != werewolf.BoardSixPlayers || len(lobby.GetRoles()) != 6 {
+		t.Errorf("unexpected lobby: %v", lobby)
+	}
+	if rules := lobby.GetRules(); !rules.GetWolfKillUnanimous() || rules.GetWolfTiePolicy() != "random" {
+		t.Errorf("expected wolf kill rules in lobby, got %v", rules)
+	}
+	if len(lobby.GetSeats()) != 1 || lobby.GetSeats()[0].GetPlayerId() != "host" {
+		t.Errorf("expected only host seated, got %v", lobby.GetSeats())
+	}
+	if _, err := client.Start(ctx, &servicepb.StartRequest{GameId: gameID, HostId: "host", Token: hostToken}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition with empty seats, got %v", err)
+	}
+}
+
+func TestServer_ManagerLimitsAndRemoval(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	manager := werewolf.NewGameManager(werewolf.GameManagerConfig{MaxGames: 1, FinishedTTL: time.Minute})
+	client := newTestClientWithManager(t, manager)
+
+	roles := []pb.RoleType{pb.RoleType_ROLE_TYPE_WEREWOLF, pb.RoleType_ROLE_TYPE_VILLAGER}
+	created, err := client.CreateGame(ctx, &servicepb.CreateGameRequest{Roles: roles})
+	if err != nil {
+		t.Fatalf("CreateGame failed: %v", err)
+	}
+	if _, err := client.CreateGame(ctx, &servicepb.CreateGameRequest{Roles: roles}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted over max games, got %v", err)
+	}
+
+	gameID := created.GetGameId()
+	stream := openStream(t, ctx, client, gameID, "p1", join(t, ctx, client, gameID, "p1"))
+
+	// 管理器移除游戏后，事件流被关闭，游戏不可再访问
+	manager.RemoveGame(gameID)
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable after removal, got %v", err)
+	}
+	if _, err := client.JoinGame(ctx, &servicepb.JoinGameRequest{GameId: gameID, PlayerId: "p2"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound after removal, got %v", err)
+	}
+}
@@ -0,0 +1,693 @@
+// Package httpserver 提供面向浏览器客户端的 HTTP/JSON 游戏服务
+//
+// 接口一览：
+//
+//	GET  /games                                 大厅：游戏列表
+//	POST /games                                 创建游戏 {"roles": [...], "board": "9p-standard", "rules": {...}}
+//	GET  /games/{id}/lobby                      大厅：座位、房主、板子
+//	POST /games/{id}/join                       加入 {"player_id": "p1"}，返回 {"seat", "token"}（第一个加入的玩家成为房主）
+//	POST /games/{id}/leave                      离开（开始前）{"player_id": "p1", "token"}
+//	POST /games/{id}/ready                      准备 {"player_id": "p1", "ready": true, "token"}
+//	POST /games/{id}/board                      房主选择板子 {"host_id", "roles", "board", "token"}
+//	POST /games/{id}/rules                      房主选择规则 {"host_id", "rules": {...}, "token"}
+//	POST /games/{id}/start                      座位坐满且全部准备后房主发牌并开始 {"host_id", "token"}
+//	POST /games/{id}/skills                     提交技能 {"player_id", "skill", "target_id", "content", "token"}
+//	POST /games/{id}/messages                   发送消息 {"player_id", "content", "token"}
+//	POST /games/{id}/advance                    房主结束当前阶段 {"host_id", "token"}
+//	GET  /games/{id}/players/{player}/view      玩家视角（protojson）?token=...
+//	GET  /games/{id}/players/{player}/events    事件流（Server-Sent Events）?token=...
+//
+// 游戏由 werewolf.GameManager 统一创建、查找和回收；大厅列表按创建时间排序。
+// 加入时签发座位令牌，以玩家（或房主）身份的请求都要携带令牌（GET 请求放在 token 查询参数中，
+// 便于浏览器 EventSource 使用），不匹配时返回 401。
+// 事件流使用 SSE，事件名为 view / event / message，data 为对应 pb 类型的 protojson 编码。
+// 每个连接只收到该玩家按迷雾规则可见的事件和消息。
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Zereker/werewolf"
+	"github.com/Zereker/werewolf/gamehost"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// Server HTTP 游戏服务（实现 http.Handler）
+type Server struct {
+	host *gamehost.Host // 托管的游戏（事件流分发与 gRPC 服务共用）
+
+	mux *http.ServeMux
+}
+
+// NewServer 创建 HTTP 游戏服务
+// manager 为 nil 时使用默认配置创建游戏管理器
+func NewServer(manager *werewolf.GameManager) *Server {
+	s := &Server{
+		host: gamehost.New(manager),
+		mux:  http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /games", s.handleListGames)
+	s.mux.HandleFunc("POST /games", s.handleCreateGame)
+	s.mux.HandleFunc("GET /games/{id}/lobby", s.handleLobby)
+	s.mux.HandleFunc("POST /games/{id}/join", s.handleJoin)
+	s.mux.HandleFunc("POST /games/{id}/leave", s.handleLeave)
+	s.mux.HandleFunc("POST /games/{id}/ready", s.handleReady)
+	s.mux.HandleFunc("POST /games/{id}/board", s.handleSetBoard)
+	s.mux.HandleFunc("POST /games/{id}/rules", s.handleSetRules)
+	s.mux.HandleFunc("POST /games/{id}/start", s.handleStart)
+	s.mux.HandleFunc("POST /games/{id}/skills", s.handleSubmitSkill)
+	s.mux.HandleFunc("POST /games/{id}/messages", s.handleSendMessage)
+	s.mux.HandleFunc("POST /games/{id}/advance", s.handleAdvance)
+	s.mux.HandleFunc("GET /games/{id}/players/{player}/view", s.handleView)
+	s.mux.HandleFunc("GET /games/{id}/players/{player}/events", s.handleEvents)
+
+	return s
+}
+
+// ServeHTTP 实现 http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ==================== 请求/响应 ====================
+
+// Rules 规则变体（未设置时使用默认配置）
+type Rules struct {
+	WitchCanSaveSelf     bool   `json:"witch_can_save_self"`
+	GuardCanProtectSelf  bool   `json:"guard_can_protect_self"`
+	GuardCanRepeat       bool   `json:"guard_can_repeat"`
+	SameGuardKillIsEmpty bool   `json:"same_guard_kill_is_empty"`
+	WolfKillUnanimous    bool   `json:"wolf_kill_unanimous"`
+	WolfTiePolicy        string `json:"wolf_tie_policy,omitempty"` // no_kill（默认）、random、lead_wolf
+}
+
+// toRules 转换为引擎规则变体，平票策略名称未知时返回错误
+func (r *Rules) toRules() (werewolf.Rules, error) {
+	if r == nil {
+		return werewolf.Rules{}, nil
+	}
+	tiePolicy := werewolf.WolfTieNoKill
+	if r.WolfTiePolicy != "" {
+		policy, ok := werewolf.ParseWolfTiePolicy(r.WolfTiePolicy)
+		if !ok {
+			return werewolf.Rules{}, fmt.Errorf("unknown wolf tie policy %q", r.WolfTiePolicy)
+		}
+		tiePolicy = policy
+	}
+	return werewolf.Rules{
+		WitchCanSaveSelf:     r.WitchCanSaveSelf,
+		GuardCanProtectSelf:  r.GuardCanProtectSelf,
+		GuardCanRepeat:       r.GuardCanRepeat,
+		SameGuardKillIsEmpty: r.SameGuardKillIsEmpty,
+		WolfKillUnanimous:    r.WolfKillUnanimous,
+		WolfTiePolicy:        tiePolicy,
+	}, nil
+}
+
+// CreateGameRequest 创建游戏请求，角色使用枚举名（如 "ROLE_TYPE_WEREWOLF"）
+// roles 和 board 都为空时由房主稍后选择板子
+type CreateGameRequest struct {
+	Roles []string `json:"roles,omitempty"`
+	Board string   `json:"board,omitempty"` // 预设板子名称（roles 为空时使用）
+	Rules *Rules   `json:"rules,omitempty"`
+}
+
+// PlayerRequest 加入/离开请求（加入时不需要令牌）
+type PlayerRequest struct {
+	PlayerID string `json:"player_id"`
+	Token    string `json:"token,omitempty"`
+}
+
+// JoinResponse 加入响应，令牌只在这里返回一次
+type JoinResponse struct {
+	Seat  int    `json:"seat"`
+	Token string `json:"token"`
+}
+
+// ReadyRequest 准备请求
+type ReadyRequest struct {
+	PlayerID string `json:"player_id"`
+	Ready    bool   `json:"ready"`
+	Token    string `json:"token"`
+}
+
+// BoardRequest 选择板子请求（令牌为房主的座位令牌）
+type BoardRequest struct {
+	HostID string   `json:"host_id"`
+	Roles  []string `json:"roles,omitempty"`
+	Board  string   `json:"board,omitempty"`
+	Token  string   `json:"token"`
+}
+
+// RulesRequest 选择规则请求（令牌为房主的座位令牌）
+type RulesRequest struct {
+	HostID string `json:"host_id"`
+	Rules  *Rules `json:"rules"`
+	Token  string `json:"token"`
+}
+
+// HostRequest 开始游戏、推进阶段等房主操作的请求（令牌为房主的座位令牌）
+type HostRequest struct {
+	HostID string `json:"host_id"`
+	Token  string `json:"token"`
+}
+
+// SkillRequest 技能提交请求，技能使用枚举名（如 "SKILL_TYPE_KILL"）
+type SkillRequest struct {
+	PlayerID string `json:"player_id"`
+	Skill    string `json:"skill"`
+	TargetID string `json:"target_id,omitempty"`
+	Content  string `json:"content,omitempty"`
+	Token    string `json:"token"`
+}
+
+// MessageRequest 消息发送请求
+type MessageRequest struct {
+	PlayerID string `json:"player_id"`
+	Content  string `json:"content"`
+	Token    string `json:"token"`
+}
+
+// GameSummary 大厅中的游戏摘要
+type GameSummary struct {
+	ID       string   `json:"id"`
+	Status   string   `json:"status"`
+	Host     string   `json:"host"`
+	Board    string   `json:"board"`
+	Seats    int      `json:"seats"`
+	Players  []string `json:"players"`
+	Started  bool     `json:"started"`
+	GameOver bool     `json:"game_over"`
+}
+
+// SeatInfo 座位信息
+type SeatInfo struct {
+	Number   int    `json:"number"`
+	PlayerID string `json:"player_id"`
+	Ready    bool   `json:"ready"`
+}
+
+// LobbyResponse 大厅信息
+type LobbyResponse struct {
+	Host    string     `json:"host"`
+	Board   string     `json:"board"`
+	Roles   []string   `json:"roles"`
+	Rules   Rules      `json:"rules"`
+	Seats   []SeatInfo `json:"seats"`
+	Started bool       `json:"started"`
+}
+
+// ==================== 游戏托管 ====================
+
+// gameSummary 生成大厅摘要
+func gameSummary(g *gamehost.Game, status werewolf.GameStatus) GameSummary {
+	lobby := g.Engine.GetLobby()
+
+	summary := GameSummary{
+		ID:       g.ID,
+		Status:   status.String(),
+		Host:     lobby.HostID,
+		Board:    lobby.Board.Name,
+		Seats:    lobby.Board.Size(),
+		Players:  make([]string, 0, len(lobby.Seats)),
+		Started:  status != werewolf.GameStatusWaiting,
+		GameOver: status == werewolf.GameStatusFinished,
+	}
+	for _, seat := range lobby.Seats {
+		summary.Players = append(summary.Players, seat.PlayerID)
+	}
+	return summary
+}
+
+// ==================== 处理器 ====================
+
+func (s *Server) handleListGames(w http.ResponseWriter, r *http.Request) {
+	listed := s.host.Manager().ListGames()
+
+	summaries := make([]GameSummary, 0, len(listed))
+	for _, info := range listed {
+		if g, ok := s.host.Lookup(info.ID); ok {
+			summaries = append(summaries, gameSummary(g, info.Status))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+func (s *Server) handleCreateGame(w http.ResponseWriter, r *http.Request) {
+	var req CreateGameRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	board, hasBoard, err := parseBoard(req.Roles, req.Board)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	config := werewolf.DefaultGameConfig()
+	if req.Rules != nil {
+		rules, err := req.Rules.toRules()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		config.ApplyRules(rules)
+	}
+
+	g, err := s.host.CreateGame(config)
+	if err != nil {
+		writeGameError(w, err)
+		return
+	}
+	if hasBoard {
+		if err := g.Engine.SetBoard("", board); err != nil {
+			s.host.RemoveGame(g.ID)
+			writeGameError(w, err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]string{"game_id": g.ID})
+}
+
+func (s *Server) handleJoin(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+	var req PlayerRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PlayerID == "" {
+		writeError(w, http.StatusBadRequest, "player_id must not be empty")
+		return
+	}
+
+	seat, token, err := g.Join(req.PlayerID)
+	if err != nil {
+		writeGameError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, JoinResponse{Seat: seat, Token: token})
+}
+
+func (s *Server) handleLeave(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+	var req PlayerRequest
+	if !decodeJSON(w, r, &req) || !authorize(w, g, req.PlayerID, req.Token) {
+		return
+	}
+
+	if err := g.Leave(req.PlayerID); err != nil {
+		writeGameError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+	var req ReadyRequest
+	if !decodeJSON(w, r, &req) || !authorize(w, g, req.PlayerID, req.Token) {
+		return
+	}
+
+	if err := g.Engine.SetReady(req.PlayerID, req.Ready); err != nil {
+		writeGameError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleSetBoard(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+	var req BoardRequest
+	if !decodeJSON(w, r, &req) || !authorize(w, g, req.HostID, req.Token) {
+		return
+	}
+	board, hasBoard, err := parseBoard(req.Roles, req.Board)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !hasBoard {
+		writeError(w, http.StatusBadRequest, "roles or board must be set")
+		return
+	}
+
+	if err := g.Engine.SetBoard(req.HostID, board); err != nil {
+		writeGameError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleSetRules(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+	var req RulesRequest
+	if !decodeJSON(w, r, &req) || !authorize(w, g, req.HostID, req.Token) {
+		return
+	}
+	// 缺少 rules 时不能当作全部关闭的规则
+	if req.Rules == nil {
+		writeError(w, http.StatusBadRequest, "rules must be set")
+		return
+	}
+
+	rules, err := req.Rules.toRules()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := g.Engine.SetRules(req.HostID, rules); err != nil {
+		writeGameError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleLobby(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+
+	lobby := g.Engine.GetLobby()
+	resp := LobbyResponse{
+		Host:  lobby.HostID,
+		Board: lobby.Board.Name,
+		Roles: make([]string, 0, lobby.Board.Size()),
+		Rules: Rules{
+			WitchCanSaveSelf:     lobby.Rules.WitchCanSaveSelf,
+			GuardCanProtectSelf:  lobby.Rules.GuardCanProtectSelf,
+			GuardCanRepeat:       lobby.Rules.GuardCanRepeat,
+			SameGuardKillIsEmpty: lobby.Rules.SameGuardKillIsEmpty,
+			WolfKillUnanimous:    lobby.Rules.WolfKillUnanimous,
+			WolfTiePolicy:        lobby.Rules.WolfTiePolicy.String(),
+		},
+		Seats:   make([]SeatInfo, 0, len(lobby.Seats)),
+		Started: g.Engine.GetCurrentPhase() != pb.PhaseType_PHASE_TYPE_START,
+	}
+	for _, role := range lobby.Board.Roles {
+		resp.Roles = append(resp.Roles, role.String())
+	}
+	for _, seat := range lobby.Seats {
+		resp.Seats = append(resp.Seats, SeatInfo{Number: seat.Number, PlayerID: seat.PlayerID, Ready: seat.Ready})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+	var req HostRequest
+	if !decodeJSON(w, r, &req) || !authorizeHost(w, g, req.HostID, req.Token) {
+		return
+	}
+
+	if err := g.Engine.Start(); err != nil {
+		writeGameError(w, err)
+		return
+	}
+	writePhase(w, g.Engine)
+}
+
+func (s *Server) handleSubmitSkill(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+	var req SkillRequest
+	if !decodeJSON(w, r, &req) || !authorize(w, g, req.PlayerID, req.Token) {
+		return
+	}
+	skill, known := pb.SkillType_value[req.Skill]
+	if !known {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown skill %q", req.Skill))
+		return
+	}
+
+	err := g.Engine.SubmitSkillUse(&werewolf.SkillUse{
+		PlayerID: req.PlayerID,
+		Skill:    pb.SkillType(skill),
+		TargetID: req.TargetID,
+		Content:  req.Content,
+	})
+	if err != nil {
+		writeGameError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleSendMessage(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+	var req MessageRequest
+	if !decodeJSON(w, r, &req) || !authorize(w, g, req.PlayerID, req.Token) {
+		return
+	}
+
+	if err := g.Engine.SendMessage(req.PlayerID, req.Content); err != nil {
+		writeGameError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleAdvance(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok {
+		return
+	}
+	var req HostRequest
+	if !decodeJSON(w, r, &req) || !authorizeHost(w, g, req.HostID, req.Token) {
+		return
+	}
+
+	if g.Engine.GetCurrentPhase() == pb.PhaseType_PHASE_TYPE_START {
+		writeGameError(w, werewolf.ErrGameNotStarted)
+		return
+	}
+
+	if _, err := g.Engine.EndSubStep(); err != nil {
+		writeGameError(w, err)
+		return
+	}
+	writePhase(w, g.Engine)
+}
+
+func (s *Server) handleView(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok || !authorize(w, g, r.PathValue("player"), r.URL.Query().Get("token")) {
+		return
+	}
+
+	view, err := g.Engine.GetPlayerView(r.PathValue("player"))
+	if err != nil {
+		writeGameError(w, err)
+		return
+	}
+	writeProto(w, view)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupGame(w, r)
+	if !ok || !authorize(w, g, r.PathValue("player"), r.URL.Query().Get("token")) {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	playerID := r.PathValue("player")
+	err := g.Stream(r.Context(), playerID, func(item gamehost.StreamItem) error {
+		name, m := "event", proto.Message(item.Event)
+		switch {
+		case item.View != nil:
+			// 首条推送当前视角时才写入响应头，玩家不在游戏中时仍可返回 404
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Connection", "keep-alive")
+			w.WriteHeader(http.StatusOK)
+			name, m = "view", item.View
+		case item.Message != nil:
+			name, m = "message", item.Message
+		}
+		data, err := protojson.Marshal(m)
+		if err != nil {
+			return err
+		}
+		writeSSE(w, name, data)
+		flusher.Flush()
+		return nil
+	})
+	if errors.Is(err, gamehost.ErrNotSeated) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("player %s not in game", playerID))
+	}
+}
+
+// ==================== 工具函数 ====================
+
+// lookupGame 按路径参数查找游戏，不存在时写入 404
+func (s *Server) lookupGame(w http.ResponseWriter, r *http.Request) (*gamehost.Game, bool) {
+	g, ok := s.host.Lookup(r.PathValue("id"))
+	if !ok {
+		writeGameError(w, werewolf.ErrGameNotFound)
+	}
+	return g, ok
+}
+
+// authorize 校验玩家的座位令牌，不匹配时写入 401
+func authorize(w http.ResponseWriter, g *gamehost.Game, playerID, token string) bool {
+	if err := g.Authorize(playerID, token); err != nil {
+		writeError(w, http.StatusUnauthorized, fmt.Sprintf("%v for player %q", err, playerID))
+		return false
+	}
+	return true
+}
+
+// authorizeHost 校验房主的座位令牌，令牌不匹配写入 401，不是房主写入 403
+func authorizeHost(w http.ResponseWriter, g *gamehost.Game, hostID, token string) bool {
+	err := g.AuthorizeHost(hostID, token)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, gamehost.ErrInvalidToken):
+		writeError(w, http.StatusUnauthorized, fmt.Sprintf("%v for player %q", err, hostID))
+	default:
+		writeGameError(w, err)
+	}
+	return false
+}
+
+// parseBoard 解析板子：优先使用自定义角色（枚举名），否则按预设名称查找
+// 两者都为空时返回 false
+func parseBoard(roleNames []string, name string) (werewolf.Board, bool, error) {
+	if len(roleNames) > 0 {
+		roles := make([]pb.RoleType, 0, len(roleNames))
+		for _, roleName := range roleNames {
+			role, ok := pb.RoleType_value[roleName]
+			if !ok {
+				return werewolf.Board{}, false, fmt.Errorf("unknown role %q", roleName)
+			}
+			roles = append(roles, pb.RoleType(role))
+		}
+		return werewolf.Board{Name: name, Roles: roles}, true, nil
+	}
+	if name == "" {
+		return werewolf.Board{}, false, nil
+	}
+	board, ok := werewolf.LookupBoard(name)
+	if !ok {
+		return werewolf.Board{}, false, fmt.Errorf("unknown board %q", name)
+	}
+	return board, true, nil
+}
+
+// decodeJSON 解析请求体，失败时写入 400
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writePhase 写入当前阶段信息
+func writePhase(w http.ResponseWriter, engine *werewolf.Engine) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"phase":     engine.GetCurrentPhase().String(),
+		"round":     engine.GetCurrentRound(),
+		"game_over": engine.IsGameOver(),
+	})
+}
+
+// writeJSON 写入 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeProto 写入 protojson 响应
+func writeProto(w http.ResponseWriter, m proto.Message) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// writeError 写入错误响应
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// writeGameError 将引擎错误转换为 HTTP 响应，detail 为 pb.ErrorDetail 的 protojson 编码
+func writeGameError(w http.ResponseWriter, err error) {
+	var gameErr *werewolf.GameError
+	if !errors.As(err, &gameErr) {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := http.StatusConflict
+	switch gameErr.Code {
+	case pb.ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND,
+		pb.ErrorCode_ERROR_CODE_TARGET_NOT_FOUND,
+		pb.ErrorCode_ERROR_CODE_GAME_NOT_FOUND,
+		pb.ErrorCode_ERROR_CODE_NO_PENDING_SKILL_USE:
+		status = http.StatusNotFound
+	case pb.ErrorCode_ERROR_CODE_NOT_HOST:
+		status = http.StatusForbidden
+	case pb.ErrorCode_ERROR_CODE_INVALID_CONFIG:
+		status = http.StatusBadRequest
+	case pb.ErrorCode_ERROR_CODE_TOO_MANY_GAMES:
+		status = http.StatusTooManyRequests
+	case pb.ErrorCode_ERROR_CODE_SHUTTING_DOWN:
+		status = http.StatusServiceUnavailable
+	}
+	body := map[string]interface{}{
+		"error": gameErr.Error(),
+		"code":  gameErr.Code.String(),
+	}
+	if detail, err := protojson.Marshal(gameErr.ToProto()); err == nil {
+		body["detail"] = json.RawMessage(detail)
+	}
+	writeJSON(w, status, body)
+}
+
+// writeSSE 写入一条 SSE 事件
+func writeSSE(w http.ResponseWriter, name string, data []byte) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+}
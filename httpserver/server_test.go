@@ -0,0 +1,474 @@
+package httpserver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// postJSON 发送 JSON POST 请求，返回状态码和响应体
+func postJSON(t *testing.T, url string, body interface{}) (int, []byte) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("failed to encode body: %v", err)
+		}
+	} else {
+		buf.WriteString("{}")
+	}
+
+	resp, err := http.Post(url, "application/json", &buf)
+	if err != nil {
+		t.Fatalf("POST %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	var out bytes.Buffer
+	out.ReadFrom(resp.Body)
+	return resp.StatusCode, out.Bytes()
+}
+
+// join 加入游戏并返回座位令牌
+func join(t *testing.T, gameURL, playerID string) string {
+	t.Helper()
+
+	status, body := postJSON(t, gameURL+"/join", PlayerRequest{PlayerID: playerID})
+	if status != http.StatusOK {
+		t.Fatalf("join %s failed: %d %s", playerID, status, body)
+	}
+	var joined JoinResponse
+	if err := json.Unmarshal(body, &joined); err != nil || joined.Token == "" {
+		t.Fatalf("expected seat token for %s, got %s", playerID, body)
+	}
+	return joined.Token
+}
+
+// getView 获取玩家视角
+func getView(t *testing.T, base, gameID, playerID, token string) *pb.PlayerView {
+	t.Helper()
+
+	resp, err := http.Get(base + "/games/" + gameID + "/players/" + playerID + "/view?token=" + token)
+	if err != nil {
+		t.Fatalf("GET view failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 for view, got %d", resp.StatusCode)
+	}
+
+	var buf bytes.Buffer
+	buf.ReadFrom(resp.Body)
+	view := &pb.PlayerView{}
+	if err := protojson.Unmarshal(buf.Bytes(), view); err != nil {
+		t.Fatalf("failed to decode view: %v", err)
+	}
+	return view
+}
+
+// sseStream SSE 读取器
+type sseStream struct {
+	resp   *http.Response
+	reader *bufio.Reader
+}
+
+func openEvents(t *testing.T, base, gameID, playerID, token string) *sseStream {
+	t.Helper()
+
+	resp, err := http.Get(base + "/games/" + gameID + "/players/" + playerID + "/events?token=" + token)
+	if err != nil {
+		t.Fatalf("GET events failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 for events, got %d", resp.StatusCode)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	s := &sseStream{resp: resp, reader: bufio.NewReader(resp.Body)}
+	if name, _ := s.next(t); name != "view" {
+		t.Fatalf("expected initial view, got %q", name)
+	}
+	return s
+}
+
+// next 读取下一条 SSE 事件
+// 跳过游戏流程事件（阶段、回合、汇总），便于按顺序断言具体事件
+func (s *sseStream) next(t *testing.T) (string, []byte) {
+	t.Helper()
+
+	for {
+		name, data := s.nextRaw(t)
+		if name == "event" {
+			event := &pb.Event{}
+			if err := protojson.Unmarshal(data, event); err == nil && flowEvents[event.Type] {
+				continue
+			}
+		}
+		return name, data
+	}
+}
+
+// flowEvents 游戏流程事件
+var flowEvents = map[pb.EventType]bool{
+	pb.EventType_EVENT_TYPE_GAME_STARTED:  true,
+	pb.EventType_EVENT_TYPE_ROUND_STARTED: true,
+	pb.EventType_EVENT_TYPE_PHASE_STARTED: true,
+	pb.EventType_EVENT_TYPE_PHASE_ENDED:   true,
+	pb.EventType_EVENT_TYPE_VOTE_RESULT:   true,
+	pb.EventType_EVENT_TYPE_NIGHT_SUMMARY: true,
+}
+
+// nextRaw 读取下一条 SSE 消息
+func (s *sseStream) nextRaw(t *testing.T) (string, []byte) {
+	t.Helper()
+
+	var name string
+	var data []byte
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read SSE stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			return name, data
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = []byte(strings.TrimPrefix(line, "data: "))
+		}
+	}
+}
+
+// nextEvent 读取下一条游戏事件
+func (s *sseStream) nextEvent(t *testing.T) *pb.Event {
+	t.Helper()
+
+	name, data := s.next(t)
+	if name != "event" {
+		t.Fatalf("expected event, got %q", name)
+	}
+	event := &pb.Event{}
+	if err := protojson.Unmarshal(data, event); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	return event
+}
+
+// nightSummary 读取到夜晚总结为止，途中不应收到带死因的夜晚击杀
+func (s *sseStream) nightSummary(t *testing.T) *pb.NightSummaryEvent {
+	t.Helper()
+
+	for {
+		name, data := s.nextRaw(t)
+		if name != "event" {
+			continue
+		}
+		event := &pb.Event{}
+		if err := protojson.Unmarshal(data, event); err != nil {
+			t.Fatalf("failed to decode event: %v", err)
+		}
+		switch event.Type {
+		case pb.EventType_EVENT_TYPE_KILL:
+			t.Errorf("expected night kill to be hidden, got %v", event)
+		case pb.EventType_EVENT_TYPE_NIGHT_SUMMARY:
+			return event.GetNightSummary()
+		}
+	}
+}
+
+// setupGame 创建并开始一局 1 狼 1 预言家 3 村民的游戏，返回游戏ID、角色 -> 玩家ID 映射和 玩家ID -> 座位令牌
+func setupGame(t *testing.T, base string) (string, map[pb.RoleType][]string, map[string]string) {
+	t.Helper()
+
+	status, body := postJSON(t, base+"/games", CreateGameRequest{
+		Roles: []string{"ROLE_TYPE_WEREWOLF", "ROLE_TYPE_SEER", "ROLE_TYPE_VILLAGER", "ROLE_TYPE_VILLAGER", "ROLE_TYPE_VILLAGER"},
+	})
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", status, body)
+	}
+	var created map[string]string
+	json.Unmarshal(body, &created)
+	gameID := created["game_id"]
+
+	players := []string{"p1", "p2", "p3", "p4", "p5"}
+	tokens := make(map[string]string, len(players))
+	for _, id := range players {
+		tokens[id] = join(t, base+"/games/"+gameID, id)
+		if status, body := postJSON(t, base+"/games/"+gameID+"/ready", ReadyRequest{PlayerID: id, Ready: true, Token: tokens[id]}); status != http.StatusNoContent {
+			t.Fatalf("ready %s failed: %d %s", id, status, body)
+		}
+	}
+	// 开始游戏只有持令牌的房主可以操作
+	if status, _ := postJSON(t, base+"/games/"+gameID+"/start", HostRequest{HostID: "p1"}); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for start without token, got %d", status)
+	}
+	if status, _ := postJSON(t, base+"/games/"+gameID+"/start", HostRequest{HostID: "p2", Token: tokens["p2"]}); status != http.StatusForbidden {
+		t.Errorf("expected 403 for start by non-host, got %d", status)
+	}
+	if status, body := postJSON(t, base+"/games/"+gameID+"/start", HostRequest{HostID: "p1", Token: tokens["p1"]}); status != http.StatusOK {
+		t.Fatalf("start failed: %d %s", status, body)
+	}
+
+	roles := make(map[pb.RoleType][]string)
+	for _, id := range players {
+		view := getView(t, base, gameID, id, tokens[id])
+		roles[view.Role] = append(roles[view.Role], id)
+	}
+	return gameID, roles, tokens
+}
+
+func TestServer_LobbyJoinLeave(t *testing.T) {
+	srv := httptest.NewServer(NewServer(nil))
+	t.Cleanup(srv.Close)
+
+	status, body := postJSON(t, srv.URL+"/games", CreateGameRequest{
+		Roles: []string{"ROLE_TYPE_WEREWOLF", "ROLE_TYPE_VILLAGER", "ROLE_TYPE_VILLAGER"},
+	})
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", status, body)
+	}
+	var created map[string]string
+	json.Unmarshal(body, &created)
+	gameID := created["game_id"]
+
+	p1Token := join(t, srv.URL+"/games/"+gameID, "p1")
+	p2Token := join(t, srv.URL+"/games/"+gameID, "p2")
+	if status, _ := postJSON(t, srv.URL+"/games/"+gameID+"/join", PlayerRequest{PlayerID: "p2"}); status != http.StatusConflict {
+		t.Errorf("expected 409 for duplicate join, got %d", status)
+	}
+	// 不能用别人的令牌让其他玩家离开
+	if status, _ := postJSON(t, srv.URL+"/games/"+gameID+"/leave", PlayerRequest{PlayerID: "p1", Token: p2Token}); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for leave with another player's token, got %d", status)
+	}
+	if status, _ := postJSON(t, srv.URL+"/games/"+gameID+"/leave", PlayerRequest{PlayerID: "p1", Token: p1Token}); status != http.StatusNoContent {
+		t.Errorf("expected 204 for leave, got %d", status)
+	}
+	if status, _ := postJSON(t, srv.URL+"/games/"+gameID+"/start", HostRequest{HostID: "p2", Token: p2Token}); status != http.StatusConflict {
+		t.Errorf("expected 409 for start with missing players, got %d", status)
+	}
+
+	resp, err := http.Get(srv.URL + "/games")
+	if err != nil {
+		t.Fatalf("GET /games failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var games []GameSummary
+	json.NewDecoder(resp.Body).Decode(&games)
+	if len(games) != 1 {
+		t.Fatalf("expected 1 game in lobby, got %d", len(games))
+	}
+	if games[0].Status != "waiting" {
+		t.Errorf("expected waiting status, got %q", games[0].Status)
+	}
+	if games[0].Host != "p2" {
+		t.Errorf("expected p2 to become host, got %q", games[0].Host)
+	}
+	if games[0].Seats != 3 || len(games[0].Players) != 1 || games[0].Players[0] != "p2" {
+		t.Errorf("unexpected lobby summary: %+v", games[0])
+	}
+}
+
+func TestServer_HostChoosesBoard(t *testing.T) {
+	srv := httptest.NewServer(NewServer(nil))
+	t.Cleanup(srv.Close)
+
+	status, body := postJSON(t, srv.URL+"/games", CreateGameRequest{})
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", status, body)
+	}
+	var created map[string]string
+	json.Unmarshal(body, &created)
+	base := srv.URL + "/games/" + created["game_id"]
+
+	hostToken := join(t, base, "host")
+	guestToken := join(t, base, "guest")
+
+	if status, _ := postJSON(t, base+"/board", BoardRequest{HostID: "guest", Board: werewolf.BoardNinePlayers, Token: guestToken}); status != http.StatusForbidden {
+		t.Errorf("expected 403 for non-host, got %d", status)
+	}
+	// 冒充房主（或留空绕过房主校验）需要房主的令牌
+	for _, hostID := range []string{"host", ""} {
+		if status, _ := postJSON(t, base+"/rules", RulesRequest{HostID: hostID, Rules: &Rules{}, Token: guestToken}); status != http.StatusUnauthorized {
+			t.Errorf("expected 401 for host_id %q with guest token, got %d", hostID, status)
+		}
+	}
+	if status, body := postJSON(t, base+"/board", BoardRequest{HostID: "host", Board: werewolf.BoardNinePlayers, Token: hostToken}); status != http.StatusNoContent {
+		t.Fatalf("set board failed: %d %s", status, body)
+	}
+	if status, _ := postJSON(t, base+"/rules", RulesRequest{HostID: "host", Token: hostToken}); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for missing rules, got %d", status)
+	}
+	if status, _ := postJSON(t, base+"/rules", RulesRequest{HostID: "host", Rules: &Rules{WolfTiePolicy: "coin_flip"}, Token: hostToken}); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown wolf tie policy, got %d", status)
+	}
+	if status, body := postJSON(t, base+"/rules", RulesRequest{HostID: "host", Rules: &Rules{WitchCanSaveSelf: true, WolfTiePolicy: "lead_wolf"}, Token: hostToken}); status != http.StatusNoContent {
+		t.Fatalf("set rules failed: %d %s", status, body)
+	}
+	postJSON(t, base+"/ready", ReadyRequest{PlayerID: "guest", Ready: true, Token: guestToken})
+
+	resp, err := http.Get(base + "/lobby")
+	if err != nil {
+		t.Fatalf("GET lobby failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var lobby LobbyResponse
+	json.NewDecoder(resp.Body).Decode(&lobby)
+
+	if lobby.Host != "host" || lobby.Board != werewolf.BoardNinePlayers || len(lobby.Roles) != 9 {
+		t.Errorf("unexpected lobby: %+v", lobby)
+	}
+	if !lobby.Rules.WitchCanSaveSelf || lobby.Rules.WolfTiePolicy != "lead_wolf" {
+		t.Errorf("expected rules to be applied, got %+v", lobby.Rules)
+	}
+	if len(lobby.Seats) != 2 || lobby.Seats[0].Ready || !lobby.Seats[1].Ready {
+		t.Errorf("unexpected seats: %+v", lobby.Seats)
+	}
+}
+
+func TestServer_CreateGame_BadRequest(t *testing.T) {
+	srv := httptest.NewServer(NewServer(nil))
+	t.Cleanup(srv.Close)
+
+	if status, _ := postJSON(t, srv.URL+"/games", CreateGameRequest{Roles: []string{"ROLE_TYPE_DRAGON"}}); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown role, got %d", status)
+	}
+	if status, _ := postJSON(t, srv.URL+"/games", CreateGameRequest{Board: "missing"}); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown board, got %d", status)
+	}
+	if status, _ := postJSON(t, srv.URL+"/games/missing/join", PlayerRequest{PlayerID: "p1"}); status != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown game, got %d", status)
+	}
+}
+
+func TestServer_EventStreamFogOfWar(t *testing.T) {
+	srv := httptest.NewServer(NewServer(nil))
+	t.Cleanup(srv.Close)
+
+	gameID, roles, tokens := setupGame(t, srv.URL)
+	wolf := roles[pb.RoleType_ROLE_TYPE_WEREWOLF][0]
+	seer := roles[pb.RoleType_ROLE_TYPE_SEER][0]
+	victim := roles[pb.RoleType_ROLE_TYPE_VILLAGER][0]
+	bystander := roles[pb.RoleType_ROLE_TYPE_VILLAGER][1]
+
+	// 没有令牌或使用别人的令牌不能查看视角、订阅事件流
+	for _, path := range []string{"/view?token=" + tokens[bystander], "/events", "/events?token=" + tokens[bystander]} {
+		resp, err := http.Get(srv.URL + "/games/" + gameID + "/players/" + wolf + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("expected 401 for %s, got %d", path, resp.StatusCode)
+		}
+	}
+
+	seerEvents := openEvents(t, srv.URL, gameID, seer, tokens[seer])
+	bystanderEvents := openEvents(t, srv.URL, gameID, bystander, tokens[bystander])
+
+	if status, _ := postJSON(t, srv.URL+"/games/"+gameID+"/advance", HostRequest{HostID: "p1"}); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for advance without token, got %d", status)
+	}
+	advance := func() {
+		if status, body := postJSON(t, srv.URL+"/games/"+gameID+"/advance", HostRequest{HostID: "p1", Token: tokens["p1"]}); status != http.StatusOK {
+			t.Fatalf("advance failed: %d %s", status, body)
+		}
+	}
+
+	advance() // NIGHT_GUARD -> NIGHT_WOLF
+	if status, body := postJSON(t, srv.URL+"/games/"+gameID+"/skills", SkillRequest{
+		PlayerID: wolf, Skill: "SKILL_TYPE_KILL", TargetID: victim, Token: tokens[wolf],
+	}); status != http.StatusNoContent {
+		t.Fatalf("wolf kill failed: %d %s", status, body)
+	}
+	// 村民夜晚不能使用技能，响应带有结构化错误
+	status, body := postJSON(t, srv.URL+"/games/"+gameID+"/skills", SkillRequest{
+		PlayerID: bystander, Skill: "SKILL_TYPE_KILL", TargetID: victim, Token: tokens[bystander],
+	})
+	if status != http.StatusConflict {
+		t.Errorf("expected 409 for villager kill, got %d", status)
+	}
+	var rejected struct {
+		Code   string `json:"code"`
+		Detail struct {
+			PlayerID string `json:"playerId"`
+			TargetID string `json:"targetId"`
+			Phase    string `json:"phase"`
+		} `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &rejected); err != nil || rejected.Code != "ERROR_CODE_SKILL_NOT_ALLOWED" ||
+		rejected.Detail.PlayerID != bystander || rejected.Detail.TargetID != victim || rejected.Detail.Phase != "PHASE_TYPE_NIGHT_WOLF" {
+		t.Errorf("expected error detail for villager kill, got %s", body)
+	}
+	advance() // NIGHT_WOLF -> NIGHT_WITCH
+	advance() // NIGHT_WITCH -> NIGHT_SEER
+	postJSON(t, srv.URL+"/games/"+gameID+"/skills", SkillRequest{
+		PlayerID: seer, Skill: "SKILL_TYPE_CHECK", TargetID: wolf, Token: tokens[seer],
+	})
+	advance() // NIGHT_SEER -> NIGHT_RESOLVE
+	advance() // NIGHT_RESOLVE -> DAY
+
+	if event := seerEvents.nextEvent(t); event.Type != pb.EventType_EVENT_TYPE_CHECK || event.TargetId != wolf {
+		t.Errorf("expected seer to receive CHECK on %s, got %v", wolf, event)
+	}
+	// 夜晚击杀带有死因，玩家只从夜晚总结得知死亡名单
+	for _, events := range []*sseStream{seerEvents, bystanderEvents} {
+		if summary := events.nightSummary(t); !reflect.DeepEqual(summary.GetDeadPlayerIds(), []string{victim}) {
+			t.Errorf("expected %s in night summary, got %v", victim, summary)
+		}
+	}
+
+	if status, body := postJSON(t, srv.URL+"/games/"+gameID+"/messages", MessageRequest{
+		PlayerID: seer, Content: "check result", Token: tokens[seer],
+	}); status != http.StatusNoContent {
+		t.Fatalf("send message failed: %d %s", status, body)
+	}
+	name, data := bystanderEvents.next(t)
+	if name != "message" {
+		t.Fatalf("expected message, got %q", name)
+	}
+	msg := &pb.ChatMessage{}
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	if msg.SenderId != seer || msg.Content != "check result" {
+		t.Errorf("unexpected message: %v", msg)
+	}
+}
+
+func TestServer_ManagerLimitsAndRemoval(t *testing.T) {
+	manager := werewolf.NewGameManager(werewolf.GameManagerConfig{MaxGames: 1, FinishedTTL: time.Minute})
+	srv := httptest.NewServer(NewServer(manager))
+	t.Cleanup(srv.Close)
+
+	create := CreateGameRequest{Roles: []string{"ROLE_TYPE_WEREWOLF", "ROLE_TYPE_VILLAGER"}}
+	status, body := postJSON(t, srv.URL+"/games", create)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", status, body)
+	}
+	var created map[string]string
+	json.Unmarshal(body, &created)
+	gameID := created["game_id"]
+
+	if status, _ := postJSON(t, srv.URL+"/games", create); status != http.StatusTooManyRequests {
+		t.Errorf("expected 429 over max games, got %d", status)
+	}
+
+	manager.RemoveGame(gameID)
+	if status, _ := postJSON(t, srv.URL+"/games/"+gameID+"/join", PlayerRequest{PlayerID: "p1"}); status != http.StatusNotFound {
+		t.Errorf("expected 404 after removal, got %d", status)
+	}
+}
@@ -9,7 +9,7 @@ import (
 // ==================== Complete Game Tests ====================
 
 func TestFullGame_WolvesWin(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	// 2 wolves vs 2 villagers
 	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -53,7 +53,7 @@ func TestFullGame_WolvesWin(t *testing.T) {
 }
 
 func TestFullGame_GoodWins(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	// 1 wolf vs 3 villagers
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -107,7 +107,7 @@ func TestFullGame_GoodWins(t *testing.T) {
 // ==================== Rule Scenario Tests ====================
 
 func TestScenario_WitchSavesVictim(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
@@ -147,7 +147,7 @@ func TestScenario_WitchSavesVictim(t *testing.T) {
 func TestScenario_GuardProtects(t *testing.T) {
 	config := DefaultGameConfig()
 	config.SameGuardKillIsEmpty = true
-	engine := NewEngine(config)
+	engine := newTestEngine(t, config)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
@@ -183,7 +183,7 @@ func TestScenario_GuardProtects(t *testing.T) {
 }
 
 func TestScenario_VoteTie(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -233,7 +233,7 @@ func TestScenario_VoteTie(t *testing.T) {
 }
 
 func TestScenario_MultipleRounds(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
@@ -279,7 +279,7 @@ func TestScenario_MultipleRounds(t *testing.T) {
 func TestConfig_WitchCanSaveSelf_Enabled(t *testing.T) {
 	config := DefaultGameConfig()
 	config.WitchCanSaveSelf = true
-	engine := NewEngine(config)
+	engine := newTestEngine(t, config)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
@@ -319,7 +319,7 @@ func TestConfig_WitchCanSaveSelf_Enabled(t *testing.T) {
 func TestConfig_WitchCanSaveSelf_Disabled(t *testing.T) {
 	config := DefaultGameConfig()
 	config.WitchCanSaveSelf = false
-	engine := NewEngine(config)
+	engine := newTestEngine(t, config)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
@@ -359,7 +359,7 @@ func TestConfig_WitchCanSaveSelf_Disabled(t *testing.T) {
 func TestConfig_SameGuardKill_Empty(t *testing.T) {
 	config := DefaultGameConfig()
 	config.SameGuardKillIsEmpty = true
-	engine := NewEngine(config)
+	engine := newTestEngine(t, config)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
@@ -397,7 +397,7 @@ func TestConfig_SameGuardKill_Empty(t *testing.T) {
 func TestConfig_SameGuardKill_NotEmpty(t *testing.T) {
 	config := DefaultGameConfig()
 	config.SameGuardKillIsEmpty = false
-	engine := NewEngine(config)
+	engine := newTestEngine(t, config)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
@@ -435,7 +435,7 @@ func TestConfig_SameGuardKill_NotEmpty(t *testing.T) {
 // ==================== Complex Scenario Tests ====================
 
 func TestScenario_WitchPoisonAndSaveOnSameNight(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
@@ -488,7 +488,7 @@ func TestScenario_WitchPoisonAndSaveOnSameNight(t *testing.T) {
 }
 
 func TestScenario_SeerIdentifiesWolf(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
 	engine.AddPlayer("seer", pb.RoleType_ROLE_TYPE_SEER, pb.Camp_CAMP_GOOD)
@@ -542,7 +542,7 @@ func TestScenario_SeerIdentifiesWolf(t *testing.T) {
 // ==================== Sub-Step Mode Tests ====================
 
 func TestSubStepMode_FullNightCycle(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	// 设置玩家
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
@@ -664,7 +664,7 @@ func TestSubStepMode_FullNightCycle(t *testing.T) {
 }
 
 func TestSubStepMode_WolfVoteTie(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -714,7 +714,7 @@ func TestSubStepMode_WolfVoteTie(t *testing.T) {
 func TestSubStepMode_GuardProtectsFromKill(t *testing.T) {
 	config := DefaultGameConfig()
 	config.SameGuardKillIsEmpty = true
-	engine := NewEngine(config)
+	engine := newTestEngine(t, config)
 
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -759,7 +759,7 @@ func TestSubStepMode_GuardProtectsFromKill(t *testing.T) {
 }
 
 func TestSubStepMode_MultipleRounds(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
 	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -806,7 +806,7 @@ func TestSubStepMode_MultipleRounds(t *testing.T) {
 }
 
 func TestScenario_AllRolesActive(t *testing.T) {
-	engine := NewEngine(nil)
+	engine := newTestEngine(t, nil)
 
 	// Full game with all roles
 	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
@@ -0,0 +1,146 @@
+package werewolf
+
+import (
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// ==================== 行动意图 ====================
+//
+// 阶段结束前，玩家对每个技能的提交只是意图：同一玩家同一技能只保留最新的一次，
+// 可以通过 ChangeSkillUse 修改目标或 WithdrawSkillUse 撤回，阶段结束时才交给解析器。
+// 发言、公告等可重复的技能不受影响，每次提交都会保留。
+// 狼人阶段每次刀口变化都会发布 WOLF_TALLY 事件（只有狼人可见），狼队友可以看到彼此的选择。
+
+// ChangeSkillUse 修改玩家本阶段已提交的技能目标（按玩家和技能匹配）
+// 本阶段没有该技能的提交时返回 ErrNoPendingSkillUse，新的目标按提交时的规则校验
+func (e *Engine) ChangeSkillUse(use *SkillUse) error {
+	return e.updateIntents(use.Skill, func() error {
+		i := e.pendingIntentLocked(use.PlayerID, use.Skill)
+		if i < 0 {
+			return ErrNoPendingSkillUse
+		}
+		if err := e.validateSkillUseLocked(use); err != nil {
+			return err
+		}
+		e.replaceIntentLocked(i, use)
+		return nil
+	})
+}
+
+// WithdrawSkillUse 撤回玩家本阶段已提交的技能
+// 本阶段没有该技能的提交时返回 ErrNoPendingSkillUse
+func (e *Engine) WithdrawSkillUse(playerID string, skill pb.SkillType) error {
+	return e.updateIntents(skill, func() error {
+		i := e.pendingIntentLocked(playerID, skill)
+		if i < 0 {
+			return ErrNoPendingSkillUse
+		}
+		e.pendingUses = append(e.pendingUses[:i], e.pendingUses[i+1:]...)
+
+		e.logger.Debug("skill withdrawn", PlayerField(playerID), SkillField(skill))
+		e.phaseSpan.AddEvent("skill withdrawn", PlayerField(playerID), SkillField(skill))
+		return nil
+	})
+}
+
+// GetPendingSkillUses 获取玩家本阶段待结算的技能使用（副本，按提交顺序）
+func (e *Engine) GetPendingSkillUses(playerID string) []*SkillUse {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var uses []*SkillUse
+	for _, use := range e.pendingUses {
+		if use.PlayerID == playerID {
+			copied := *use
+			uses = append(uses, &copied)
+		}
+	}
+	return uses
+}
+
+// GetWolfTally 获取狼人阶段的实时票型（每只存活狼人选择的目标和各目标票数）
+// 只有存活的狼人在狼人阶段可以查看，其他情况返回 nil
+func (e *Engine) GetWolfTally(playerID string) *pb.WolfTallyEvent {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.wolfTallyLocked(playerID)
+}
+
+// wolfTallyLocked 玩家可见的实时票型，不可见时返回 nil（调用前需持有锁）
+func (e *Engine) wolfTallyLocked(playerID string) *pb.WolfTallyEvent {
+	if e.state.Phase != pb.PhaseType_PHASE_TYPE_NIGHT_WOLF {
+		return nil
+	}
+	if player, ok := e.state.GetPlayerInfo(playerID); !ok || !player.Alive || player.Role != pb.RoleType_ROLE_TYPE_WEREWOLF {
+		return nil
+	}
+	return wolfTallyPayload(e.pendingUses, e.state)
+}
+
+// updateIntents 在锁内修改意图；狼人阶段的刀口变化后在锁外向狼人发布实时票型
+func (e *Engine) updateIntents(skill pb.SkillType, update func() error) error {
+	e.mu.Lock()
+	err := update()
+	var tally *pb.Event
+	if err == nil && skill == pb.SkillType_SKILL_TYPE_KILL && e.state.Phase == pb.PhaseType_PHASE_TYPE_NIGHT_WOLF {
+		tally = e.wolfTallyEventLocked()
+	}
+	e.mu.Unlock()
+
+	if tally != nil {
+		e.publishEvent(tally)
+	}
+	return err
+}
+
+// wolfTallyEventLocked 实时票型事件，只有狼人可见（调用前需持有锁）
+func (e *Engine) wolfTallyEventLocked() *pb.Event {
+	payload := wolfTallyPayload(e.pendingUses, e.state)
+	event := &pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_WOLF_TALLY,
+		Data:      make(map[string]string),
+		PhaseType: e.state.Phase,
+	}
+	for _, pick := range payload.Picks {
+		event.AudienceIds = append(event.AudienceIds, pick.WolfId)
+		event.Data[pick.WolfId] = pick.TargetId
+	}
+	setEventPayload(event, payload)
+	return event
+}
+
+// pendingIntentLocked 玩家本阶段某技能的提交在待处理列表中的位置，没有或技能可重复时返回 -1
+// （调用前需持有锁）
+func (e *Engine) pendingIntentLocked(playerID string, skill pb.SkillType) int {
+	if repeatableSkill(skill) {
+		return -1
+	}
+	for i, use := range e.pendingUses {
+		if use.PlayerID == playerID && use.Skill == skill {
+			return i
+		}
+	}
+	return -1
+}
+
+// replaceIntentLocked 用新的提交替换待处理列表中的意图，保持原有顺序（调用前需持有锁）
+func (e *Engine) replaceIntentLocked(i int, use *SkillUse) {
+	previous := e.pendingUses[i]
+	use.Phase = e.state.Phase
+	use.Round = e.state.Round
+	e.pendingUses[i] = use
+
+	e.logger.Debug("skill changed",
+		PlayerField(use.PlayerID),
+		SkillField(use.Skill),
+		F("from", previous.TargetID),
+		TargetField(use.TargetID))
+	e.metrics.IncSkillSubmitted(use.Skill)
+	e.metrics.ObserveTimeToAction(use.Skill, e.now().Sub(e.phaseStartedAt))
+	e.phaseSpan.AddEvent("skill changed", PlayerField(use.PlayerID), SkillField(use.Skill), TargetField(use.TargetID))
+}
+
+// repeatableSkill 每次提交都保留的技能（发言、公告）
+func repeatableSkill(skill pb.SkillType) bool {
+	return skill == pb.SkillType_SKILL_TYPE_SPEAK || skill == pb.SkillType_SKILL_TYPE_ANNOUNCE
+}
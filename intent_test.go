@@ -0,0 +1,160 @@
+package werewolf
+
+import (
+	"errors"
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+func newIntentTestEngine(t *testing.T) *Engine {
+	t.Helper()
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("wolf2", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v3", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+	return engine
+}
+
+func TestEngine_SkillIntent_LatestWins(t *testing.T) {
+	engine := newIntentTestEngine(t)
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+
+	kill := func(wolf, target string) *SkillUse {
+		return &SkillUse{PlayerID: wolf, Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: target}
+	}
+	if err := engine.SubmitSkillUse(kill("wolf1", "v1")); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if err := engine.SubmitSkillUse(kill("wolf1", "v1")); !errors.Is(err, ErrDuplicateSkillUse) {
+		t.Errorf("expected ErrDuplicateSkillUse, got %v", err)
+	}
+
+	// 狼人点错后改刀：最新的意图替换之前的
+	if err := engine.ChangeSkillUse(kill("wolf1", "v2")); err != nil {
+		t.Fatalf("change failed: %v", err)
+	}
+	engine.SubmitSkillUse(kill("wolf2", "v3"))
+	if err := engine.SubmitSkillUse(kill("wolf2", "v2")); err != nil {
+		t.Fatalf("resubmit with new target failed: %v", err)
+	}
+
+	pending := engine.GetPendingSkillUses("wolf1")
+	if len(pending) != 1 || pending[0].TargetID != "v2" || pending[0].Phase != pb.PhaseType_PHASE_TYPE_NIGHT_WOLF {
+		t.Fatalf("expected wolf1's pending kill on v2, got %+v", pending)
+	}
+	pending[0].TargetID = "v3"
+	if engine.GetPendingSkillUses("wolf1")[0].TargetID != "v2" {
+		t.Error("expected GetPendingSkillUses to return copies")
+	}
+
+	// 两只狼都刀 v2，达成共识
+	engine.EndPhase()
+	if target := engine.GetNightKillTarget(); target != "v2" {
+		t.Errorf("expected kill target v2, got %q", target)
+	}
+	if pending := engine.GetPendingSkillUses("wolf1"); len(pending) != 0 {
+		t.Errorf("expected no pending uses after phase end, got %+v", pending)
+	}
+}
+
+func TestEngine_SkillIntent_Withdraw(t *testing.T) {
+	engine := newIntentTestEngine(t)
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WITCH)
+	engine.state.RoundCtx.KillTarget = "v1"
+
+	// 女巫的解药和毒药是两个独立的意图
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v1"})
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_POISON, TargetID: "wolf1"})
+	if pending := engine.GetPendingSkillUses("witch"); len(pending) != 2 {
+		t.Fatalf("expected 2 pending uses, got %+v", pending)
+	}
+
+	if err := engine.WithdrawSkillUse("witch", pb.SkillType_SKILL_TYPE_POISON); err != nil {
+		t.Fatalf("withdraw failed: %v", err)
+	}
+	if err := engine.WithdrawSkillUse("witch", pb.SkillType_SKILL_TYPE_POISON); !errors.Is(err, ErrNoPendingSkillUse) {
+		t.Errorf("expected ErrNoPendingSkillUse, got %v", err)
+	}
+	if err := engine.ChangeSkillUse(&SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_POISON, TargetID: "wolf2"}); !errors.Is(err, ErrNoPendingSkillUse) {
+		t.Errorf("expected ErrNoPendingSkillUse for change without submit, got %v", err)
+	}
+
+	// 修改目标按提交规则校验，失败时保留原意图
+	if err := engine.ChangeSkillUse(&SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "ghost"}); err == nil {
+		t.Error("expected invalid target to be rejected")
+	}
+	if pending := engine.GetPendingSkillUses("witch"); len(pending) != 1 || pending[0].TargetID != "v1" {
+		t.Fatalf("expected antidote intent on v1 to remain, got %+v", pending)
+	}
+
+	engine.EndPhase()
+	if info, _ := engine.GetPlayerInfo("wolf1"); !info.Alive {
+		t.Error("expected withdrawn poison not to be used")
+	}
+	if engine.GetNightKillTarget() != "" {
+		t.Error("expected antidote to save v1")
+	}
+}
+
+func TestEngine_WolfTally(t *testing.T) {
+	engine := newIntentTestEngine(t)
+	var tallies []*pb.Event
+	engine.SubscribeEvents(func(event *pb.Event) {
+		if event.Type == pb.EventType_EVENT_TYPE_WOLF_TALLY {
+			tallies = append(tallies, event)
+		}
+	})
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+
+	kill := func(wolf, target string) *SkillUse {
+		return &SkillUse{PlayerID: wolf, Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: target}
+	}
+	engine.SubmitSkillUse(kill("wolf1", "v1"))
+	engine.SubmitSkillUse(kill("wolf2", "v2"))
+	engine.ChangeSkillUse(kill("wolf1", "v2"))
+	engine.SubmitSkillUse(kill("wolf1", "v2")) // 重复提交被拒绝，不发布票型
+
+	// 每次刀口变化发布一次票型
+	if len(tallies) != 3 {
+		t.Fatalf("expected 3 tally events, got %d", len(tallies))
+	}
+	latest := tallies[2]
+	if latest.Data["wolf1"] != "v2" || latest.Data["wolf2"] != "v2" {
+		t.Errorf("expected both wolves on v2, got %v", latest.Data)
+	}
+	if tally := latest.GetWolfTally().GetTallies(); len(tally) != 1 || tally[0].Votes != 2 {
+		t.Errorf("expected 2 votes on v2, got %v", tally)
+	}
+
+	// 只有狼人可见
+	for id, want := range map[string]bool{"wolf1": true, "wolf2": true, "witch": false, "v1": false} {
+		if got := engine.IsEventVisibleTo(latest, id); got != want {
+			t.Errorf("expected tally visible to %s = %v, got %v", id, want, got)
+		}
+		view, _ := engine.GetPlayerView(id)
+		if (view.GetWolfTally() != nil) != want || (engine.GetWolfTally(id) != nil) != want {
+			t.Errorf("expected tally in %s's view = %v", id, want)
+		}
+	}
+
+	// 撤回后票型中该狼人未选择
+	engine.WithdrawSkillUse("wolf2", pb.SkillType_SKILL_TYPE_KILL)
+	picks := engine.GetWolfTally("wolf1").GetPicks()
+	if len(picks) != 2 || picks[0].TargetId != "v2" || picks[1].TargetId != "" {
+		t.Errorf("expected wolf2 without a pick after withdraw, got %v", picks)
+	}
+	if len(tallies) != 4 {
+		t.Errorf("expected a tally event after withdraw, got %d", len(tallies))
+	}
+
+	// 离开狼人阶段后不再提供票型
+	engine.EndPhase()
+	if engine.GetWolfTally("wolf1") != nil {
+		t.Error("expected no tally outside NIGHT_WOLF")
+	}
+}
@@ -0,0 +1,161 @@
+package werewolf
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// ==================== 私有信息记录 ====================
+//
+// 每个玩家有一份只属于自己的信息记录（"笔记本"），在效果应用和阶段流转时由玩家角色定义的
+// RecordKnowledge 钩子决定追加什么，注册的角色无需修改引擎代码就能记录自己的信息。内置角色：
+//   - 预言家：每次查验的目标和阵营
+//   - 女巫：每晚进入女巫阶段时得知的被杀目标
+//   - 守卫：每晚的守护目标
+//   - 猎人：被触发、开枪或放弃开枪（开枪阶段结束时仍未开枪也记为放弃）
+//
+// 记录包含在 Snapshot 和 PlayerView 中，断线重连的客户端可以随时重建玩家的笔记本。
+
+// GetKnowledge 获取玩家的私有信息记录（副本，按时间顺序）
+func (e *Engine) GetKnowledge(playerID string) ([]*pb.KnowledgeEntry, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.hasPlayerLocked(playerID) {
+		return nil, ErrPlayerNotFound
+	}
+	return e.state.GetKnowledge(playerID), nil
+}
+
+// GetKnowledge 获取玩家的私有信息记录（副本，按时间顺序），玩家不存在时返回 nil
+func (s *State) GetKnowledge(playerID string) []*pb.KnowledgeEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	player, ok := s.players[playerID]
+	if !ok {
+		return nil
+	}
+	return cloneKnowledge(player.knowledge)
+}
+
+// recordKnowledgeLocked 向玩家的记录追加一条，回合和阶段取当前值（调用方持有锁）
+func (s *State) recordKnowledgeLocked(playerID string, entry *pb.KnowledgeEntry) {
+	player, ok := s.players[playerID]
+	if !ok {
+		return
+	}
+	entry.Round = int32(s.Round)
+	entry.Phase = s.Phase
+	player.knowledge = append(player.knowledge, entry)
+}
+
+// KnowledgeTrigger 触发私有信息记录的时机：效果应用后（Effect）、离开阶段（Exit）或进入阶段（Enter），每次只有一种
+type KnowledgeTrigger struct {
+	Effect *Effect      // 该玩家施放的效果已应用
+	Target PlayerInfo   // 效果目标（没有目标时为零值）
+	Exit   pb.PhaseType // 正在离开的阶段（阶段尚未更新），否则为 UNSPECIFIED
+	Enter  pb.PhaseType // 刚进入的阶段（阶段和回合已更新），否则为 UNSPECIFIED
+
+	Round *RoundContext        // 当前回合上下文（只读）
+	Known []*pb.KnowledgeEntry // 玩家已有的记录（只读）
+}
+
+// recordRoleKnowledgeLocked 调用玩家角色定义的 RecordKnowledge 钩子并追加返回的记录（调用方持有锁）
+func (s *State) recordRoleKnowledgeLocked(player *PlayerState, trigger KnowledgeTrigger) {
+	def, ok := LookupRole(player.Role)
+	if !ok || def.RecordKnowledge == nil {
+		return
+	}
+	trigger.Round = s.RoundCtx
+	trigger.Known = player.knowledge
+	for _, entry := range def.RecordKnowledge(s.playerInfoLocked(player), trigger) {
+		s.recordKnowledgeLocked(player.ID, entry)
+	}
+}
+
+// recordEffectKnowledgeLocked 已应用的效果交给施放者的角色记录（调用方持有锁）
+func (s *State) recordEffectKnowledgeLocked(effect *Effect) {
+	source, ok := s.players[effect.SourceID]
+	if !ok {
+		return
+	}
+	trigger := KnowledgeTrigger{Effect: effect}
+	if target, ok := s.players[effect.TargetID]; ok {
+		trigger.Target = s.playerInfoLocked(target)
+	}
+	s.recordRoleKnowledgeLocked(source, trigger)
+}
+
+// recordPhaseKnowledgeLocked 阶段切换时交给每个玩家的角色记录（调用方持有锁）
+func (s *State) recordPhaseKnowledgeLocked(trigger KnowledgeTrigger) {
+	for _, player := range s.players {
+		s.recordRoleKnowledgeLocked(player, trigger)
+	}
+}
+
+// ==================== 内置角色的记录 ====================
+
+// seerKnowledge 预言家记录查验的目标和阵营
+func seerKnowledge(player PlayerInfo, trigger KnowledgeTrigger) []*pb.KnowledgeEntry {
+	if trigger.Effect == nil || trigger.Effect.Type != pb.EventType_EVENT_TYPE_CHECK || trigger.Target.ID == "" {
+		return nil
+	}
+	return []*pb.KnowledgeEntry{{
+		Type:     pb.KnowledgeType_KNOWLEDGE_TYPE_CHECK,
+		TargetId: trigger.Target.ID,
+		Camp:     trigger.Target.Camp,
+	}}
+}
+
+// guardKnowledge 守卫记录每晚的守护目标
+func guardKnowledge(player PlayerInfo, trigger KnowledgeTrigger) []*pb.KnowledgeEntry {
+	if trigger.Effect == nil || trigger.Effect.Type != pb.EventType_EVENT_TYPE_PROTECT {
+		return nil
+	}
+	return []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_PROTECT, TargetId: trigger.Effect.TargetID}}
+}
+
+// witchKnowledge 进入女巫阶段时，存活的女巫得知当晚被杀目标
+func witchKnowledge(player PlayerInfo, trigger KnowledgeTrigger) []*pb.KnowledgeEntry {
+	if trigger.Enter != pb.PhaseType_PHASE_TYPE_NIGHT_WITCH || !player.Alive {
+		return nil
+	}
+	return []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_NIGHT_KILL, TargetId: trigger.Round.KillTarget}}
+}
+
+// hunterKnowledge 猎人记录被触发、开枪和放弃开枪；离开开枪阶段时被触发但没有开枪的猎人记为放弃
+func hunterKnowledge(player PlayerInfo, trigger KnowledgeTrigger) []*pb.KnowledgeEntry {
+	if trigger.Effect != nil {
+		switch trigger.Effect.Type {
+		case pb.EventType_EVENT_TYPE_HUNTER_TRIGGERED:
+			return []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_GUN_TRIGGERED}}
+		case pb.EventType_EVENT_TYPE_SHOOT:
+			return []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_GUN_FIRED, TargetId: trigger.Effect.TargetID}}
+		case pb.EventType_EVENT_TYPE_SKIP:
+			return []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_GUN_HELD}}
+		}
+		return nil
+	}
+
+	if !isHunterPhase(trigger.Exit) || player.ID != trigger.Round.TriggeredHunterID || len(trigger.Known) == 0 {
+		return nil
+	}
+	if last := trigger.Known[len(trigger.Known)-1]; last.Type == pb.KnowledgeType_KNOWLEDGE_TYPE_GUN_TRIGGERED {
+		return []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_GUN_HELD}}
+	}
+	return nil
+}
+
+// cloneKnowledge 深拷贝记录
+func cloneKnowledge(entries []*pb.KnowledgeEntry) []*pb.KnowledgeEntry {
+	if len(entries) == 0 {
+		return nil
+	}
+	result := make([]*pb.KnowledgeEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = proto.Clone(entry).(*pb.KnowledgeEntry)
+	}
+	return result
+}
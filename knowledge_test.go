@@ -0,0 +1,119 @@
+package werewolf
+
+import (
+	"errors"
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+func TestEngine_Knowledge(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("wolf2", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("seer", pb.RoleType_ROLE_TYPE_SEER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("hunter", pb.RoleType_ROLE_TYPE_HUNTER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	submit := func(player string, skill pb.SkillType, target string) {
+		t.Helper()
+		if err := engine.SubmitSkillUse(&SkillUse{PlayerID: player, Skill: skill, TargetID: target}); err != nil {
+			t.Fatalf("%s %v failed: %v", player, skill, err)
+		}
+	}
+
+	// 第一夜：守卫守 v1，狼人刀猎人，预言家查验 wolf1，猎人死亡后开枪带走 wolf2
+	submit("guard", pb.SkillType_SKILL_TYPE_PROTECT, "v1")
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	submit("wolf1", pb.SkillType_SKILL_TYPE_KILL, "hunter")
+	submit("wolf2", pb.SkillType_SKILL_TYPE_KILL, "hunter")
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_SEER)
+	submit("seer", pb.SkillType_SKILL_TYPE_CHECK, "wolf1")
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER)
+	submit("hunter", pb.SkillType_SKILL_TYPE_SHOOT, "wolf2")
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+
+	tests := []struct {
+		player string
+		want   []*pb.KnowledgeEntry
+	}{
+		{"seer", []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_CHECK, Round: 1, Phase: pb.PhaseType_PHASE_TYPE_NIGHT_SEER, TargetId: "wolf1", Camp: pb.Camp_CAMP_EVIL}}},
+		{"witch", []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_NIGHT_KILL, Round: 1, Phase: pb.PhaseType_PHASE_TYPE_NIGHT_WITCH, TargetId: "hunter"}}},
+		{"guard", []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_PROTECT, Round: 1, Phase: pb.PhaseType_PHASE_TYPE_NIGHT_GUARD, TargetId: "v1"}}},
+		{"hunter", []*pb.KnowledgeEntry{
+			{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_GUN_TRIGGERED, Round: 1, Phase: pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE},
+			{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_GUN_FIRED, Round: 1, Phase: pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER, TargetId: "wolf2"},
+		}},
+		{"wolf1", nil},
+		{"v1", nil},
+	}
+	for _, tt := range tests {
+		got, err := engine.GetKnowledge(tt.player)
+		if err != nil {
+			t.Fatalf("GetKnowledge(%s) failed: %v", tt.player, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("expected %d entries for %s, got %v", len(tt.want), tt.player, got)
+		}
+		for i := range got {
+			if got[i].String() != tt.want[i].String() {
+				t.Errorf("%s entry %d: expected %v, got %v", tt.player, i, tt.want[i], got[i])
+			}
+		}
+
+		// 玩家视角只包含自己的记录
+		view, _ := engine.GetPlayerView(tt.player)
+		if len(view.GetKnowledge()) != len(tt.want) {
+			t.Errorf("expected %d entries in %s's view, got %d", len(tt.want), tt.player, len(view.GetKnowledge()))
+		}
+	}
+
+	// 快照中的记录是副本
+	snapshot := engine.Snapshot()
+	for _, player := range snapshot.Players {
+		if player.ID == "seer" {
+			player.Knowledge[0].TargetId = "changed"
+		}
+	}
+	if got, _ := engine.GetKnowledge("seer"); got[0].TargetId != "wolf1" {
+		t.Errorf("expected snapshot to copy knowledge, got %v", got[0])
+	}
+
+	if _, err := engine.GetKnowledge("ghost"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("expected ErrPlayerNotFound, got %v", err)
+	}
+}
+
+func TestEngine_Knowledge_PeacefulNightAndHeldGun(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("hunter", pb.RoleType_ROLE_TYPE_HUNTER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	// 狼人空刀：女巫得知无人被杀
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+	witch, _ := engine.GetKnowledge("witch")
+	if len(witch) != 1 || witch[0].Type != pb.KnowledgeType_KNOWLEDGE_TYPE_NIGHT_KILL || witch[0].TargetId != "" {
+		t.Fatalf("expected an empty kill notice, got %v", witch)
+	}
+
+	// 猎人被放逐后没有开枪，离开开枪阶段时记为放弃
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_VOTE)
+	for _, id := range []string{"wolf1", "witch", "v1"} {
+		engine.SubmitSkillUse(&SkillUse{PlayerID: id, Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "hunter"})
+	}
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY_HUNTER)
+	engine.EndPhase()
+
+	hunter, _ := engine.GetKnowledge("hunter")
+	if len(hunter) != 2 || hunter[1].Type != pb.KnowledgeType_KNOWLEDGE_TYPE_GUN_HELD || hunter[1].Phase != pb.PhaseType_PHASE_TYPE_DAY_HUNTER {
+		t.Errorf("expected triggered then held gun, got %v", hunter)
+	}
+}
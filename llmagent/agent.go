@@ -0,0 +1,178 @@
+package llmagent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Zereker/werewolf"
+	"github.com/Zereker/werewolf/bot"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// Config 代理配置
+type Config struct {
+	SystemPrompt    string        // 系统提示词，为空时使用 DefaultSystemPrompt
+	Timeout         time.Duration // 单次模型调用超时，0 表示不限制
+	MaxHistory      int           // 提示词中保留的最近记忆条数，0 表示不限制
+	MaxParseRetries int           // 回复无法解析时的最大重试次数
+}
+
+// DefaultConfig 默认代理配置
+func DefaultConfig() Config {
+	return Config{
+		SystemPrompt:    DefaultSystemPrompt,
+		Timeout:         30 * time.Second,
+		MaxHistory:      50,
+		MaxParseRetries: 2,
+	}
+}
+
+// errorHints 错误码对应的反馈（告诉模型应如何修正）
+var errorHints = map[pb.ErrorCode]string{
+	pb.ErrorCode_ERROR_CODE_TARGET_NOT_FOUND:    "目标不存在，请从可选目标中选择",
+	pb.ErrorCode_ERROR_CODE_TARGET_DEAD:         "目标已出局，请选择存活玩家",
+	pb.ErrorCode_ERROR_CODE_SKILL_NOT_ALLOWED:   "当前阶段不能使用该技能，请只使用可用技能",
+	pb.ErrorCode_ERROR_CODE_MESSAGE_NOT_ALLOWED: "当前阶段不能发言",
+	pb.ErrorCode_ERROR_CODE_PLAYER_DEAD:         "你已出局，不能行动",
+	pb.ErrorCode_ERROR_CODE_GUARD_REPEAT:        "不能连续两晚守护同一名玩家",
+	pb.ErrorCode_ERROR_CODE_NO_ANTIDOTE:         "解药已经用过",
+	pb.ErrorCode_ERROR_CODE_NO_POISON:           "毒药已经用过",
+	pb.ErrorCode_ERROR_CODE_TARGET_NOT_DYING:    "解药只能救今晚被杀的玩家",
+	pb.ErrorCode_ERROR_CODE_SELF_TARGET:         "不能以自己为目标",
+}
+
+// Agent 语言模型玩家
+// 实现 bot.Agent 和 bot.Retrier，可直接交给 bot.Driver 驱动
+type Agent struct {
+	playerID string
+	client   ModelClient
+	config   Config
+
+	mu           sync.Mutex
+	history      []string      // 收到的事件和消息（已按迷雾过滤）
+	conversation []ChatMessage // 当前行动提示的对话（重试时追加反馈）
+	lastErr      error         // 最近一次模型调用或解析失败的原因
+}
+
+// New 创建语言模型玩家
+func New(playerID string, client ModelClient, config Config) *Agent {
+	if config.SystemPrompt == "" {
+		config.SystemPrompt = DefaultSystemPrompt
+	}
+	return &Agent{
+		playerID: playerID,
+		client:   client,
+		config:   config,
+	}
+}
+
+// History 代理记忆（用于调试）
+func (a *Agent) History() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.history...)
+}
+
+// LastError 最近一次模型调用或回复解析失败的原因
+func (a *Agent) LastError() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastErr
+}
+
+// OnEvent 实现 bot.Agent 接口
+func (a *Agent) OnEvent(event *pb.Event) {
+	if line := describeEvent(event); line != "" {
+		a.remember(line)
+	}
+}
+
+// OnMessage 实现 bot.Agent 接口
+func (a *Agent) OnMessage(msg *werewolf.Message) {
+	a.remember(describeMessage(msg))
+}
+
+// Act 实现 bot.Agent 接口
+// 构建提示词并调用模型；回复无法解析时附上原因重新询问，超过次数则放弃本次行动
+func (a *Agent) Act(turn *bot.Turn) []bot.Action {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.conversation = []ChatMessage{
+		{Role: ChatRoleSystem, Content: a.config.SystemPrompt},
+		{Role: ChatRoleUser, Content: BuildPrompt(turn, a.recentHistory())},
+	}
+	return a.ask()
+}
+
+// Retry 实现 bot.Retrier 接口
+// 把被拒绝的动作和错误码反馈给模型，请求替代动作
+func (a *Agent) Retry(turn *bot.Turn, rejected bot.Action, err error) []bot.Action {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	code := werewolf.GetErrorCode(err)
+	feedback := fmt.Sprintf("动作 %s %s 被拒绝，错误码 %s（%v）。",
+		skillName(rejected.Skill), rejected.Target, strings.TrimPrefix(code.String(), "ERROR_CODE_"), err)
+	if hint, ok := errorHints[code]; ok {
+		feedback += hint + "。"
+	}
+	feedback += "请重新回复 JSON，只包含替代这个动作的动作。"
+
+	a.conversation = append(a.conversation, ChatMessage{Role: ChatRoleUser, Content: feedback})
+	return a.ask()
+}
+
+// ask 调用模型并解析回复（调用前需持有锁）
+func (a *Agent) ask() []bot.Action {
+	for attempt := 0; attempt <= a.config.MaxParseRetries; attempt++ {
+		text, err := a.complete()
+		if err != nil {
+			a.lastErr = err
+			return nil
+		}
+		a.conversation = append(a.conversation, ChatMessage{Role: ChatRoleAssistant, Content: text})
+
+		actions, err := ParseReply(text)
+		if err == nil {
+			return actions
+		}
+		a.lastErr = err
+		a.conversation = append(a.conversation, ChatMessage{
+			Role:    ChatRoleUser,
+			Content: fmt.Sprintf("无法解析你的回复（%v），请只回复一个 JSON 对象。", err),
+		})
+	}
+	return nil
+}
+
+// complete 调用模型（带超时）
+func (a *Agent) complete() (string, error) {
+	ctx := context.Background()
+	if a.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.config.Timeout)
+		defer cancel()
+	}
+
+	messages := append([]ChatMessage(nil), a.conversation...)
+	return a.client.Complete(ctx, messages)
+}
+
+// remember 记录一条记忆
+func (a *Agent) remember(line string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.history = append(a.history, line)
+}
+
+// recentHistory 最近的记忆（调用前需持有锁）
+func (a *Agent) recentHistory() []string {
+	if a.config.MaxHistory > 0 && len(a.history) > a.config.MaxHistory {
+		return a.history[len(a.history)-a.config.MaxHistory:]
+	}
+	return a.history
+}
@@ -0,0 +1,225 @@
+package llmagent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Zereker/werewolf"
+	"github.com/Zereker/werewolf/bot"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// newTestGame 1 狼 1 预言家 2 民，p1 为狼人
+func newTestGame(t *testing.T) (*werewolf.Engine, *bot.Driver) {
+	t.Helper()
+
+	engine, err := werewolf.NewEngine(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	engine.AddPlayer("p1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("p2", pb.RoleType_ROLE_TYPE_SEER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("p3", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("p4", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	driver := bot.NewDriver(engine)
+	if err := engine.Start(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return engine, driver
+}
+
+// advanceTo 结束阶段直到进入指定阶段
+func advanceTo(t *testing.T, engine *werewolf.Engine, phase pb.PhaseType) {
+	t.Helper()
+	for engine.GetCurrentPhase() != phase {
+		if _, err := engine.EndSubStep(); err != nil || engine.IsGameOver() {
+			t.Fatalf("failed to reach %v: %v", phase, err)
+		}
+	}
+}
+
+// lastUserMessage 最后一次调用中最后一条用户消息
+func lastUserMessage(client *ScriptedClient) string {
+	calls := client.Calls()
+	messages := calls[len(calls)-1]
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == ChatRoleUser {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+func TestAgent_PromptAndSubmit(t *testing.T) {
+	engine, driver := newTestGame(t)
+	client := NewScriptedClient(`{"actions":[{"skill":"KILL","target":"p2"}]}`)
+	driver.Add("p1", New("p1", client, DefaultConfig()))
+
+	advanceTo(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	if err := driver.Prompt("p1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	calls := client.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 model call, got %d", len(calls))
+	}
+	if calls[0][0].Role != ChatRoleSystem || calls[0][0].Content != DefaultSystemPrompt {
+		t.Error("expected default system prompt first")
+	}
+	prompt := calls[0][1].Content
+	for _, want := range []string{"身份：狼人", "可用技能：KILL", "可选目标：p2, p3, p4"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected prompt to contain %q, got:\n%s", want, prompt)
+		}
+	}
+
+	engine.EndSubStep()
+	if engine.GetNightKillTarget() != "p2" {
+		t.Errorf("expected kill target p2, got %q", engine.GetNightKillTarget())
+	}
+}
+
+func TestAgent_RetriesIllegalAction(t *testing.T) {
+	engine, driver := newTestGame(t)
+	client := NewScriptedClient(
+		`{"actions":[{"skill":"KILL","target":"p9"}]}`,
+		`{"actions":[{"skill":"KILL","target":"p3"}]}`,
+	)
+	driver.Add("p1", New("p1", client, DefaultConfig()))
+
+	var rejected []error
+	driver.OnReject(func(_ string, _ bot.Action, err error) {
+		rejected = append(rejected, err)
+	})
+
+	advanceTo(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	driver.Prompt("p1")
+
+	if len(rejected) != 1 || !werewolf.IsErrorCode(rejected[0], pb.ErrorCode_ERROR_CODE_TARGET_NOT_FOUND) {
+		t.Fatalf("expected one TARGET_NOT_FOUND rejection, got %v", rejected)
+	}
+	if feedback := lastUserMessage(client); !strings.Contains(feedback, "TARGET_NOT_FOUND") {
+		t.Errorf("expected error code in feedback, got %q", feedback)
+	}
+
+	engine.EndSubStep()
+	if engine.GetNightKillTarget() != "p3" {
+		t.Errorf("expected retried kill target p3, got %q", engine.GetNightKillTarget())
+	}
+}
+
+func TestAgent_RetryLimit(t *testing.T) {
+	engine, driver := newTestGame(t)
+	client := NewScriptedClient()
+	client.SetResponder(func([]ChatMessage) string {
+		return `{"actions":[{"skill":"KILL","target":"p9"}]}`
+	})
+	driver.Add("p1", New("p1", client, DefaultConfig()))
+	driver.SetMaxRetries(3)
+
+	advanceTo(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	driver.Prompt("p1")
+
+	if calls := len(client.Calls()); calls != 4 {
+		t.Errorf("expected 1 call plus 3 retries, got %d", calls)
+	}
+}
+
+func TestAgent_RetriesUnparsableReply(t *testing.T) {
+	engine, driver := newTestGame(t)
+	client := NewScriptedClient("我投 p3", `{"actions":[{"skill":"KILL","target":"p3"}]}`)
+	agent := New("p1", client, DefaultConfig())
+	driver.Add("p1", agent)
+
+	advanceTo(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	driver.Prompt("p1")
+
+	if agent.LastError() != ErrNoJSON {
+		t.Errorf("expected ErrNoJSON to be recorded, got %v", agent.LastError())
+	}
+	if feedback := lastUserMessage(client); !strings.Contains(feedback, "无法解析") {
+		t.Errorf("expected parse feedback, got %q", feedback)
+	}
+	engine.EndSubStep()
+	if engine.GetNightKillTarget() != "p3" {
+		t.Errorf("expected kill target p3, got %q", engine.GetNightKillTarget())
+	}
+}
+
+func TestAgent_ModelError(t *testing.T) {
+	engine, driver := newTestGame(t)
+	agent := New("p1", NewScriptedClient(), DefaultConfig())
+	driver.Add("p1", agent)
+
+	advanceTo(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	if err := driver.Prompt("p1"); err != nil {
+		t.Fatalf("expected model errors not to stop the driver, got %v", err)
+	}
+	if agent.LastError() != ErrScriptExhausted {
+		t.Errorf("expected ErrScriptExhausted, got %v", agent.LastError())
+	}
+}
+
+func TestAgent_HistoryRespectsFogOfWar(t *testing.T) {
+	engine, driver := newTestGame(t)
+
+	agents := make(map[string]*Agent)
+	for _, id := range []string{"p1", "p2", "p3", "p4"} {
+		client := NewScriptedClient()
+		client.SetResponder(RandomResponder(1))
+		agents[id] = New(id, client, DefaultConfig())
+		driver.Add(id, agents[id])
+	}
+
+	advanceTo(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	engine.SendMessage("p1", "今晚刀 p3")
+	advanceTo(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_SEER)
+	engine.SubmitSkillUse(&werewolf.SkillUse{PlayerID: "p2", Skill: pb.SkillType_SKILL_TYPE_CHECK, TargetID: "p1"})
+	engine.EndSubStep()
+
+	for id, agent := range agents {
+		history := strings.Join(agent.History(), "\n")
+		if sawWolfChat := strings.Contains(history, "狼人频道"); sawWolfChat != (id == "p1") {
+			t.Errorf("%s: unexpected wolf chat visibility:\n%s", id, history)
+		}
+		if sawCheck := strings.Contains(history, "你查验了 p1"); sawCheck != (id == "p2") {
+			t.Errorf("%s: unexpected check visibility:\n%s", id, history)
+		}
+	}
+}
+
+func TestAgent_FullGameOffline(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		board, _ := werewolf.LookupBoard(werewolf.BoardNinePlayers)
+		engine, err := werewolf.NewEngine(nil)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		engine.SetSeed(seed)
+		driver := bot.NewDriver(engine)
+		engine.SetBoard("", board)
+
+		for seat := 1; seat <= board.Size(); seat++ {
+			id := fmt.Sprintf("p%d", seat)
+			engine.Join(id)
+			engine.SetReady(id, true)
+
+			client := NewScriptedClient()
+			client.SetResponder(RandomResponder(seed + int64(seat)))
+			driver.Add(id, New(id, client, DefaultConfig()))
+		}
+		if err := engine.Start(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := driver.Run(context.Background()); err != nil {
+			t.Fatalf("seed %d: expected no error, got %v", seed, err)
+		}
+		if !engine.IsGameOver() {
+			t.Errorf("seed %d: expected game over", seed)
+		}
+	}
+}
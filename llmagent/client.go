@@ -0,0 +1,39 @@
+// Package llmagent 提供语言模型玩家适配器
+//
+// Agent 实现 bot.Agent：把玩家视角和聊天记录组织成提示词，交给抽象的 ModelClient，
+// 再把模型返回的结构化回复解析成技能使用或发言。动作被引擎拒绝时，
+// 根据 GameError 错误码给模型反馈并重试。ScriptedClient 是离线替身，便于在没有模型时测试整条链路。
+package llmagent
+
+import (
+	"context"
+)
+
+// ChatRole 对话角色
+type ChatRole string
+
+const (
+	ChatRoleSystem    ChatRole = "system"
+	ChatRoleUser      ChatRole = "user"
+	ChatRoleAssistant ChatRole = "assistant"
+)
+
+// ChatMessage 对话消息
+type ChatMessage struct {
+	Role    ChatRole
+	Content string
+}
+
+// ModelClient 模型后端
+// 实现方负责对接具体的模型服务，输入完整对话，返回模型的文本回复
+type ModelClient interface {
+	Complete(ctx context.Context, messages []ChatMessage) (string, error)
+}
+
+// ModelClientFunc 函数形式的模型后端
+type ModelClientFunc func(ctx context.Context, messages []ChatMessage) (string, error)
+
+// Complete 实现 ModelClient 接口
+func (f ModelClientFunc) Complete(ctx context.Context, messages []ChatMessage) (string, error) {
+	return f(ctx, messages)
+}
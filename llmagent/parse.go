@@ -0,0 +1,65 @@
+package llmagent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Zereker/werewolf/bot"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// ErrNoJSON 回复中找不到 JSON 对象
+var ErrNoJSON = errors.New("reply contains no JSON object")
+
+// reply 模型回复格式
+type reply struct {
+	Actions []struct {
+		Skill   string `json:"skill"`
+		Target  string `json:"target"`
+		Content string `json:"content"`
+	} `json:"actions"`
+}
+
+// ParseReply 解析模型回复为动作
+// 容忍回复前后的说明文字和 Markdown 代码块，只取第一个 '{' 到最后一个 '}' 之间的内容
+func ParseReply(text string) ([]bot.Action, error) {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, ErrNoJSON
+	}
+
+	var r reply
+	if err := json.Unmarshal([]byte(text[start:end+1]), &r); err != nil {
+		return nil, fmt.Errorf("invalid JSON reply: %w", err)
+	}
+
+	actions := make([]bot.Action, 0, len(r.Actions))
+	for _, a := range r.Actions {
+		skill, ok := parseSkill(a.Skill)
+		if !ok {
+			return nil, fmt.Errorf("unknown skill %q", a.Skill)
+		}
+		actions = append(actions, bot.Action{
+			Skill:   skill,
+			Target:  strings.TrimSpace(a.Target),
+			Content: a.Content,
+		})
+	}
+	return actions, nil
+}
+
+// parseSkill 解析技能名，接受 KILL 或 SKILL_TYPE_KILL（大小写不敏感）
+func parseSkill(name string) (pb.SkillType, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "SKILL_TYPE_") {
+		name = "SKILL_TYPE_" + name
+	}
+	value, ok := pb.SkillType_value[name]
+	if !ok || value == int32(pb.SkillType_SKILL_TYPE_UNSPECIFIED) {
+		return pb.SkillType_SKILL_TYPE_UNSPECIFIED, false
+	}
+	return pb.SkillType(value), true
+}
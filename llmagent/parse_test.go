@@ -0,0 +1,57 @@
+package llmagent
+
+import (
+	"testing"
+
+	"github.com/Zereker/werewolf/bot"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+func TestParseReply(t *testing.T) {
+	text := "好的，我的决定如下：\n```json\n" +
+		`{"actions":[{"skill":"kill","target":" p3 "},{"skill":"SKILL_TYPE_SPEAK","content":"今晚刀 p3"}]}` +
+		"\n```"
+
+	actions, err := ParseReply(text)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []bot.Action{
+		{Skill: pb.SkillType_SKILL_TYPE_KILL, Target: "p3"},
+		{Skill: pb.SkillType_SKILL_TYPE_SPEAK, Content: "今晚刀 p3"},
+	}
+	if len(actions) != len(want) {
+		t.Fatalf("expected %d actions, got %+v", len(want), actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("action %d: expected %+v, got %+v", i, want[i], actions[i])
+		}
+	}
+}
+
+func TestParseReply_Pass(t *testing.T) {
+	actions, err := ParseReply(`{"actions":[]}`)
+	if err != nil || len(actions) != 0 {
+		t.Errorf("expected empty actions, got %+v (%v)", actions, err)
+	}
+}
+
+func TestParseReply_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"no json", "我投 p3"},
+		{"invalid json", `{"actions":[{"skill":}]}`},
+		{"unknown skill", `{"actions":[{"skill":"FLY","target":"p3"}]}`},
+		{"unspecified skill", `{"actions":[{"skill":"UNSPECIFIED"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseReply(tt.text); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
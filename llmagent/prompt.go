@@ -0,0 +1,160 @@
+package llmagent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zereker/werewolf"
+	"github.com/Zereker/werewolf/bot"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// DefaultSystemPrompt 默认系统提示词：规则概要和回复格式
+const DefaultSystemPrompt = `你正在参加一局狼人杀。
+好人阵营的目标是放逐所有狼人，狼人阵营的目标是屠边（杀光所有神职或所有村民）。
+夜晚狼人刀人，守卫守护，女巫用药，预言家查验；白天依次发言，然后投票放逐。
+每次轮到你行动时，只回复一个 JSON 对象，不要输出其他内容：
+{"actions":[{"skill":"技能","target":"目标玩家ID","content":"发言内容"}]}
+skill 取值为提示中列出的可用技能；发言使用 SPEAK 并填写 content；放弃行动回复 {"actions":[]}。`
+
+var roleNames = map[pb.RoleType]string{
+	pb.RoleType_ROLE_TYPE_WEREWOLF: "狼人",
+	pb.RoleType_ROLE_TYPE_SEER:     "预言家",
+	pb.RoleType_ROLE_TYPE_WITCH:    "女巫",
+	pb.RoleType_ROLE_TYPE_HUNTER:   "猎人",
+	pb.RoleType_ROLE_TYPE_VILLAGER: "村民",
+	pb.RoleType_ROLE_TYPE_GUARD:    "守卫",
+}
+
+var phaseNames = map[pb.PhaseType]string{
+	pb.PhaseType_PHASE_TYPE_NIGHT_GUARD:  "夜晚·守卫行动",
+	pb.PhaseType_PHASE_TYPE_NIGHT_WOLF:   "夜晚·狼人行动（可在狼人频道发言）",
+	pb.PhaseType_PHASE_TYPE_NIGHT_WITCH:  "夜晚·女巫行动",
+	pb.PhaseType_PHASE_TYPE_NIGHT_SEER:   "夜晚·预言家查验",
+	pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER: "夜晚·猎人开枪",
+	pb.PhaseType_PHASE_TYPE_DAY:          "白天·自由发言",
+	pb.PhaseType_PHASE_TYPE_DAY_HUNTER:   "白天·猎人开枪",
+	pb.PhaseType_PHASE_TYPE_VOTE:         "白天·放逐投票",
+}
+
+// roleName 角色名称
+func roleName(role pb.RoleType) string {
+	if name, ok := roleNames[role]; ok {
+		return name
+	}
+	return "未知"
+}
+
+// skillName 回复中使用的技能名（去掉枚举前缀，如 KILL）
+func skillName(skill pb.SkillType) string {
+	return strings.TrimPrefix(skill.String(), "SKILL_TYPE_")
+}
+
+// BuildPrompt 根据玩家视角和记忆构建本次行动的提示词
+// 所有内容都来自迷雾过滤后的视角和代理收到的事件、消息
+func BuildPrompt(turn *bot.Turn, history []string) string {
+	view := turn.View
+	var b strings.Builder
+
+	phase := phaseNames[turn.Phase]
+	if phase == "" {
+		phase = turn.Phase.String()
+	}
+	fmt.Fprintf(&b, "第 %d 回合，当前阶段：%s\n", turn.Round, phase)
+	fmt.Fprintf(&b, "你是 %s（%d 号位），身份：%s\n", turn.PlayerID, view.GetSeat(), roleName(view.GetRole()))
+
+	if teammates := view.GetTeammates(); len(teammates) > 0 {
+		fmt.Fprintf(&b, "狼人队友：%s\n", strings.Join(teammates, ", "))
+	}
+	if view.GetRole() == pb.RoleType_ROLE_TYPE_WITCH {
+		fmt.Fprintf(&b, "解药：%s，毒药：%s\n", have(view.GetHasAntidote()), have(view.GetHasPoison()))
+		if target := view.GetNightKillTarget(); target != "" {
+			fmt.Fprintf(&b, "今晚被刀的玩家：%s\n", target)
+		}
+	}
+
+	var alive, dead []string
+	for _, p := range view.GetPlayers() {
+		if p.GetAlive() {
+			alive = append(alive, p.GetId())
+		} else {
+			dead = append(dead, p.GetId())
+		}
+	}
+	fmt.Fprintf(&b, "存活玩家：%s\n", strings.Join(alive, ", "))
+	if len(dead) > 0 {
+		fmt.Fprintf(&b, "已出局：%s\n", strings.Join(dead, ", "))
+	}
+
+	if len(history) > 0 {
+		b.WriteString("你知道的信息：\n")
+		for _, line := range history {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+
+	skills := make([]string, 0, len(view.GetAllowedSkills()))
+	for _, skill := range view.GetAllowedSkills() {
+		skills = append(skills, skillName(skill))
+	}
+	fmt.Fprintf(&b, "可用技能：%s\n", strings.Join(skills, ", "))
+	fmt.Fprintf(&b, "可选目标：%s\n", strings.Join(turn.AlivePlayers(), ", "))
+	b.WriteString("请回复 JSON。")
+
+	return b.String()
+}
+
+// describeEvent 把事件描述成一行记忆，内部事件和大厅事件返回空字符串
+func describeEvent(event *pb.Event) string {
+	switch event.Type {
+	case pb.EventType_EVENT_TYPE_NIGHT_SUMMARY:
+		if dead := event.GetNightSummary().GetDeadPlayerIds(); len(dead) > 0 {
+			return fmt.Sprintf("%s 在夜里死亡", strings.Join(dead, "、"))
+		}
+		return "昨夜是平安夜"
+	case pb.EventType_EVENT_TYPE_POISON:
+		return fmt.Sprintf("你对 %s 使用了毒药", event.TargetId)
+	case pb.EventType_EVENT_TYPE_PROTECT:
+		return fmt.Sprintf("你守护了 %s", event.TargetId)
+	case pb.EventType_EVENT_TYPE_SAVE:
+		return fmt.Sprintf("你对 %s 使用了解药", event.TargetId)
+	case pb.EventType_EVENT_TYPE_CHECK:
+		result := "狼人"
+		if event.GetCheck().GetIsGood() {
+			result = "好人"
+		}
+		return fmt.Sprintf("你查验了 %s：%s", event.TargetId, result)
+	case pb.EventType_EVENT_TYPE_VOTE_RESULT:
+		tallies := make([]string, 0, len(event.GetVoteResult().GetTallies()))
+		for _, tally := range event.GetVoteResult().GetTallies() {
+			tallies = append(tallies, fmt.Sprintf("%s %d 票", tally.GetTargetId(), tally.GetVotes()))
+		}
+		if len(tallies) == 0 {
+			return "投票结果：无人投票"
+		}
+		return fmt.Sprintf("投票结果：%s", strings.Join(tallies, "，"))
+	case pb.EventType_EVENT_TYPE_ELIMINATE:
+		return fmt.Sprintf("%s 被投票放逐", event.TargetId)
+	case pb.EventType_EVENT_TYPE_SHOOT:
+		return fmt.Sprintf("猎人 %s 开枪带走了 %s", event.SourceId, event.TargetId)
+	case pb.EventType_EVENT_TYPE_GAME_ENDED:
+		return fmt.Sprintf("游戏结束，获胜方：%s", event.GetGameEnded().GetWinner())
+	default:
+		return ""
+	}
+}
+
+// describeMessage 把消息描述成一行记忆
+func describeMessage(msg *werewolf.Message) string {
+	if msg.Phase == pb.PhaseType_PHASE_TYPE_NIGHT_WOLF {
+		return fmt.Sprintf("第 %d 夜 %s（狼人频道）：%s", msg.Round, msg.SenderID, msg.Content)
+	}
+	return fmt.Sprintf("第 %d 天 %s 发言：%s", msg.Round, msg.SenderID, msg.Content)
+}
+
+func have(ok bool) string {
+	if ok {
+		return "有"
+	}
+	return "无"
+}
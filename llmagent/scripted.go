@@ -0,0 +1,131 @@
+package llmagent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"strings"
+	"sync"
+)
+
+// ErrScriptExhausted 脚本回复已用完且没有设置 Responder
+var ErrScriptExhausted = errors.New("scripted client has no more replies")
+
+// Responder 根据对话生成回复（脚本回复用完后使用）
+type Responder func(messages []ChatMessage) string
+
+// ScriptedClient 离线替身模型
+// 依次返回预设的回复，用完后交给 Responder；同时记录每次调用的对话，便于测试断言
+type ScriptedClient struct {
+	mu        sync.Mutex
+	replies   []string
+	responder Responder
+	calls     [][]ChatMessage
+}
+
+// NewScriptedClient 创建脚本模型
+func NewScriptedClient(replies ...string) *ScriptedClient {
+	return &ScriptedClient{replies: replies}
+}
+
+// SetResponder 设置脚本回复用完后的回复生成函数
+func (c *ScriptedClient) SetResponder(responder Responder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responder = responder
+}
+
+// Complete 实现 ModelClient 接口
+func (c *ScriptedClient) Complete(ctx context.Context, messages []ChatMessage) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, messages)
+	if len(c.replies) > 0 {
+		reply := c.replies[0]
+		c.replies = c.replies[1:]
+		return reply, nil
+	}
+	if c.responder != nil {
+		return c.responder(messages), nil
+	}
+	return "", ErrScriptExhausted
+}
+
+// Calls 每次调用收到的对话
+func (c *ScriptedClient) Calls() [][]ChatMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][]ChatMessage(nil), c.calls...)
+}
+
+// RandomResponder 随机回复：读取提示词中的"可用技能"和"可选目标"，
+// 随机选一个技能和目标；白天只发言，被拒绝后的反馈同样随机重选
+func RandomResponder(seed int64) Responder {
+	rng := rand.New(rand.NewSource(seed))
+	var mu sync.Mutex
+
+	return func(messages []ChatMessage) string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		skills, targets := promptOptions(messages)
+
+		type action struct {
+			Skill   string `json:"skill"`
+			Target  string `json:"target,omitempty"`
+			Content string `json:"content,omitempty"`
+		}
+		var actions []action
+		if len(skills) > 0 {
+			skill := skills[rng.Intn(len(skills))]
+			switch {
+			case skill == "SPEAK":
+				actions = append(actions, action{Skill: skill, Content: "过。"})
+			case skill == "SKIP" || len(targets) == 0:
+				actions = append(actions, action{Skill: "SKIP"})
+			default:
+				actions = append(actions, action{Skill: skill, Target: targets[rng.Intn(len(targets))]})
+			}
+		}
+
+		data, _ := json.Marshal(map[string]interface{}{"actions": actions})
+		return string(data)
+	}
+}
+
+// promptOptions 从对话中最近的行动提示里读取可用技能和可选目标
+func promptOptions(messages []ChatMessage) (skills, targets []string) {
+	for i := len(messages) - 1; i >= 0; i-- {
+		msg := messages[i]
+		if msg.Role != ChatRoleUser || !strings.Contains(msg.Content, "可用技能：") {
+			continue
+		}
+		for _, line := range strings.Split(msg.Content, "\n") {
+			if v, ok := strings.CutPrefix(line, "可用技能："); ok {
+				skills = splitList(v)
+			}
+			if v, ok := strings.CutPrefix(line, "可选目标："); ok {
+				targets = splitList(v)
+			}
+		}
+		return skills, targets
+	}
+	return nil, nil
+}
+
+// splitList 拆分逗号分隔的列表
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,408 @@
+package werewolf
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// ==================== 板子 ====================
+
+// Board 板子（每个座位一个角色）
+type Board struct {
+	Name  string        // 板子名称
+	Roles []pb.RoleType // 角色列表，长度即座位数
+}
+
+// Size 座位数
+func (b Board) Size() int {
+	return len(b.Roles)
+}
+
+// clone 深拷贝，避免调用方修改内部状态
+func (b Board) clone() Board {
+	return Board{Name: b.Name, Roles: append([]pb.RoleType(nil), b.Roles...)}
+}
+
+// 预设板子名称
+const (
+	BoardSixPlayers    = "6p-basic"    // 2 狼 + 预言家、女巫 + 2 民
+	BoardNinePlayers   = "9p-standard" // 3 狼 + 预言家、女巫、猎人 + 3 民
+	BoardTwelvePlayers = "12p-guard"   // 4 狼 + 预言家、女巫、猎人、守卫 + 4 民
+)
+
+// PresetBoards 获取所有预设板子（按座位数排序）
+func PresetBoards() []Board {
+	wolf := pb.RoleType_ROLE_TYPE_WEREWOLF
+	seer := pb.RoleType_ROLE_TYPE_SEER
+	witch := pb.RoleType_ROLE_TYPE_WITCH
+	hunter := pb.RoleType_ROLE_TYPE_HUNTER
+	guard := pb.RoleType_ROLE_TYPE_GUARD
+	villager := pb.RoleType_ROLE_TYPE_VILLAGER
+
+	return []Board{
+		{Name: BoardSixPlayers, Roles: []pb.RoleType{wolf, wolf, seer, witch, villager, villager}},
+		{Name: BoardNinePlayers, Roles: []pb.RoleType{wolf, wolf, wolf, seer, witch, hunter, villager, villager, villager}},
+		{Name: BoardTwelvePlayers, Roles: []pb.RoleType{
+			wolf, wolf, wolf, wolf, seer, witch, hunter, guard, villager, villager, villager, villager,
+		}},
+	}
+}
+
+// LookupBoard 按名称查找预设板子
+func LookupBoard(name string) (Board, bool) {
+	for _, board := range PresetBoards() {
+		if board.Name == name {
+			return board, true
+		}
+	}
+	return Board{}, false
+}
+
+// ==================== 大厅 ====================
+
+// Seat 座位信息
+type Seat struct {
+	Number   int    // 座位号（从 1 开始）
+	PlayerID string // 玩家ID
+	Ready    bool   // 是否已准备
+}
+
+// LobbyInfo 大厅信息（只读快照）
+type LobbyInfo struct {
+	HostID string // 房主（第一个入座的玩家）
+	Board  Board  // 当前板子（未选择时 Name 为空且没有角色）
+	Rules  Rules  // 当前规则变体
+	Seats  []Seat // 按座位号排序
+}
+
+// lobby 开始前的大厅状态（由 Engine 的锁保护）
+type lobby struct {
+	hostID string
+	board  *Board
+	seats  []*Seat // 按入座顺序，离座后后续玩家依次前移
+}
+
+// seatOf 查找玩家座位
+func (l *lobby) seatOf(playerID string) (*Seat, int) {
+	for i, seat := range l.seats {
+		if seat.PlayerID == playerID {
+			return seat, i
+		}
+	}
+	return nil, -1
+}
+
+// Join 玩家入座，返回座位号
+// 第一个入座的玩家成为房主；已选择板子时座位数不能超过板子人数
+func (e *Engine) Join(playerID string) (int, error) {
+	var events []*pb.Event
+
+	e.mu.Lock()
+	if err := e.checkLobbyOpenLocked(); err != nil {
+		e.mu.Unlock()
+		return 0, err
+	}
+	if playerID == "" {
+		e.mu.Unlock()
+		return 0, NewGameError(pb.ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND, "player id must not be empty")
+	}
+	if seat, _ := e.lobby.seatOf(playerID); seat != nil {
+		e.mu.Unlock()
+		return 0, ErrPlayerExists
+	}
+	if _, ok := e.state.getPlayer(playerID); ok {
+		e.mu.Unlock()
+		return 0, ErrPlayerExists
+	}
+	if e.lobby.board != nil && len(e.lobby.seats) >= e.lobby.board.Size() {
+		e.mu.Unlock()
+		return 0, ErrLobbyFull
+	}
+
+	number := len(e.lobby.seats) + 1
+	e.lobby.seats = append(e.lobby.seats, &Seat{Number: number, PlayerID: playerID})
+	events = append(events, &pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_PLAYER_JOINED,
+		SourceId:  playerID,
+		PhaseType: pb.PhaseType_PHASE_TYPE_START,
+		Data:      map[string]string{"seat": strconv.Itoa(number)},
+	})
+	if e.lobby.hostID == "" {
+		e.lobby.hostID = playerID
+		events = append(events, hostChangedEvent(playerID))
+	}
+
+	e.logger.Debug("player joined", PlayerField(playerID), F("seat", number))
+	e.mu.Unlock()
+
+	for _, event := range events {
+		e.publishEvent(event)
+	}
+	return number, nil
+}
+
+// Leave 玩家离座（仅开始前）
+// 房主离开时由座位号最小的玩家接任
+func (e *Engine) Leave(playerID string) error {
+	var events []*pb.Event
+
+	e.mu.Lock()
+	if err := e.checkLobbyOpenLocked(); err != nil {
+		e.mu.Unlock()
+		return err
+	}
+	seat, index := e.lobby.seatOf(playerID)
+	if seat == nil {
+		e.mu.Unlock()
+		return ErrPlayerNotFound
+	}
+
+	e.lobby.seats = append(e.lobby.seats[:index], e.lobby.seats[index+1:]...)
+	for i, s := range e.lobby.seats {
+		s.Number = i + 1
+	}
+	events = append(events, &pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_PLAYER_LEFT,
+		SourceId:  playerID,
+		PhaseType: pb.PhaseType_PHASE_TYPE_START,
+		Data:      map[string]string{"seat": strconv.Itoa(seat.Number)},
+	})
+	if e.lobby.hostID == playerID {
+		e.lobby.hostID = ""
+		if len(e.lobby.seats) > 0 {
+			e.lobby.hostID = e.lobby.seats[0].PlayerID
+		}
+		events = append(events, hostChangedEvent(e.lobby.hostID))
+	}
+
+	e.logger.Debug("player left", PlayerField(playerID))
+	e.mu.Unlock()
+
+	for _, event := range events {
+		e.publishEvent(event)
+	}
+	return nil
+}
+
+// SetReady 设置玩家准备状态
+func (e *Engine) SetReady(playerID string, ready bool) error {
+	e.mu.Lock()
+	if err := e.checkLobbyOpenLocked(); err != nil {
+		e.mu.Unlock()
+		return err
+	}
+	seat, _ := e.lobby.seatOf(playerID)
+	if seat == nil {
+		e.mu.Unlock()
+		return ErrPlayerNotFound
+	}
+	seat.Ready = ready
+	e.mu.Unlock()
+
+	e.publishEvent(&pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_PLAYER_READY,
+		SourceId:  playerID,
+		PhaseType: pb.PhaseType_PHASE_TYPE_START,
+		Data:      map[string]string{"ready": strconv.FormatBool(ready)},
+	})
+	return nil
+}
+
+// SetBoard 房主选择板子
+// 还没有玩家入座时（没有房主）任何调用方都可以设置，便于服务端建房时预设板子
+func (e *Engine) SetBoard(hostID string, board Board) error {
+	e.mu.Lock()
+	if err := e.checkHostLocked(hostID); err != nil {
+		e.mu.Unlock()
+		return err
+	}
+	if board.Size() == 0 {
+		e.mu.Unlock()
+		return NewGameError(pb.ErrorCode_ERROR_CODE_SEAT_MISMATCH, "board has no roles")
+	}
+	if len(e.lobby.seats) > board.Size() {
+		e.mu.Unlock()
+		return ErrSeatMismatch
+	}
+	if err := e.config.ValidateBoard(board); err != nil {
+		e.mu.Unlock()
+		return err
+	}
+
+	board = board.clone()
+	e.lobby.board = &board
+	e.mu.Unlock()
+
+	e.publishEvent(&pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_BOARD_CHANGED,
+		SourceId:  hostID,
+		PhaseType: pb.PhaseType_PHASE_TYPE_START,
+		Data: map[string]string{
+			"name":  board.Name,
+			"seats": strconv.Itoa(board.Size()),
+			"roles": joinRoles(board.Roles),
+		},
+	})
+	return nil
+}
+
+// SetRules 房主选择规则变体
+// 权限规则同 SetBoard
+func (e *Engine) SetRules(hostID string, rules Rules) error {
+	e.mu.Lock()
+	if err := e.checkHostLocked(hostID); err != nil {
+		e.mu.Unlock()
+		return err
+	}
+
+	// 复制配置，避免影响共享同一配置的其他引擎
+	config := *e.config
+	config.ApplyRules(rules)
+	e.config = &config
+	e.phase = NewPhase(e.config) // 阶段管理器和解析器使用同一份配置
+	e.mu.Unlock()
+
+	e.publishEvent(&pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_RULES_CHANGED,
+		SourceId:  hostID,
+		PhaseType: pb.PhaseType_PHASE_TYPE_START,
+		Data: map[string]string{
+			"witch_can_save_self":      strconv.FormatBool(rules.WitchCanSaveSelf),
+			"guard_can_protect_self":   strconv.FormatBool(rules.GuardCanProtectSelf),
+			"guard_can_repeat":         strconv.FormatBool(rules.GuardCanRepeat),
+			"same_guard_kill_is_empty": strconv.FormatBool(rules.SameGuardKillIsEmpty),
+			"wolf_kill_unanimous":      strconv.FormatBool(rules.WolfKillUnanimous),
+			"wolf_tie_policy":          rules.WolfTiePolicy.String(),
+		},
+	})
+	return nil
+}
+
+// GetLobby 获取大厅信息
+func (e *Engine) GetLobby() LobbyInfo {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	info := LobbyInfo{
+		HostID: e.lobby.hostID,
+		Rules:  e.config.GetRules(),
+		Seats:  make([]Seat, 0, len(e.lobby.seats)),
+	}
+	if e.lobby.board != nil {
+		info.Board = e.lobby.board.clone()
+	}
+	for _, seat := range e.lobby.seats {
+		info.Seats = append(info.Seats, *seat)
+	}
+	return info
+}
+
+// HasPlayer 玩家是否在游戏中（已入座或已添加）
+func (e *Engine) HasPlayer(playerID string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.hasPlayerLocked(playerID)
+}
+
+// hasPlayerLocked 玩家是否在游戏中（调用前需持有锁）
+func (e *Engine) hasPlayerLocked(playerID string) bool {
+	if _, ok := e.state.getPlayer(playerID); ok {
+		return true
+	}
+	seat, _ := e.lobby.seatOf(playerID)
+	return seat != nil
+}
+
+// playerCount 玩家数（开始前为入座人数，调用方不需持有锁）
+func (e *Engine) playerCount() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if count := e.state.playerCount(); count > 0 {
+		return count
+	}
+	return len(e.lobby.seats)
+}
+
+// dealRolesLocked 按板子随机发牌（调用前需持有锁）
+// 要求座位数与板子人数一致且所有玩家已准备
+func (e *Engine) dealRolesLocked() error {
+	if e.lobby.board == nil {
+		return NewGameError(pb.ErrorCode_ERROR_CODE_SEAT_MISMATCH, "no board selected")
+	}
+	if len(e.lobby.seats) != e.lobby.board.Size() {
+		return ErrSeatMismatch
+	}
+	for _, seat := range e.lobby.seats {
+		if !seat.Ready {
+			return ErrPlayersNotReady
+		}
+	}
+
+	roles := append([]pb.RoleType(nil), e.lobby.board.Roles...)
+	e.rng.Shuffle(len(roles), func(i, j int) { roles[i], roles[j] = roles[j], roles[i] })
+
+	for i, seat := range e.lobby.seats {
+		if !e.state.AddPlayerIfNotExists(seat.PlayerID, roles[i], DefaultCamp(roles[i])) {
+			return ErrPlayerExists
+		}
+	}
+	return nil
+}
+
+// boardInPlayLocked 本局使用的板子：经过大厅时为选择的板子，否则由已加入玩家的角色组成（调用前需持有锁）
+func (e *Engine) boardInPlayLocked() Board {
+	if len(e.lobby.seats) > 0 {
+		if e.lobby.board == nil {
+			return Board{}
+		}
+		return *e.lobby.board
+	}
+
+	roles := make([]pb.RoleType, 0, len(e.state.players))
+	for _, player := range e.state.players {
+		roles = append(roles, player.Role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+	return Board{Roles: roles}
+}
+
+// checkLobbyOpenLocked 检查大厅是否可修改（调用前需持有锁）
+func (e *Engine) checkLobbyOpenLocked() error {
+	if e.state.Phase != pb.PhaseType_PHASE_TYPE_START {
+		return ErrGameStarted
+	}
+	return nil
+}
+
+// checkHostLocked 检查房主权限（调用前需持有锁）
+func (e *Engine) checkHostLocked(hostID string) error {
+	if err := e.checkLobbyOpenLocked(); err != nil {
+		return err
+	}
+	if e.lobby.hostID != "" && e.lobby.hostID != hostID {
+		return ErrNotHost
+	}
+	return nil
+}
+
+// hostChangedEvent 房主变更事件（新房主为空表示大厅已空）
+func hostChangedEvent(hostID string) *pb.Event {
+	return &pb.Event{
+		Type:      pb.EventType_EVENT_TYPE_HOST_CHANGED,
+		TargetId:  hostID,
+		PhaseType: pb.PhaseType_PHASE_TYPE_START,
+	}
+}
+
+// joinRoles 将角色列表编码为逗号分隔的枚举名
+func joinRoles(roles []pb.RoleType) string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = role.String()
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,314 @@
+package werewolf
+
+import (
+	"sort"
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// smallBoard 1 狼 1 预言家 1 民
+func smallBoard() Board {
+	return Board{Name: "test-3p", Roles: []pb.RoleType{
+		pb.RoleType_ROLE_TYPE_WEREWOLF,
+		pb.RoleType_ROLE_TYPE_SEER,
+		pb.RoleType_ROLE_TYPE_VILLAGER,
+	}}
+}
+
+// recordEvents 记录引擎发布的事件类型
+func recordEvents(engine *Engine) *[]pb.EventType {
+	var types []pb.EventType
+	engine.OnEvent(func(event *pb.Event) {
+		types = append(types, event.Type)
+	})
+	return &types
+}
+
+func TestLobby_JoinLeaveAndHost(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	events := recordEvents(engine)
+
+	seat, err := engine.Join("p1")
+	if err != nil || seat != 1 {
+		t.Fatalf("expected seat 1, got %d (%v)", seat, err)
+	}
+	engine.Join("p2")
+	engine.Join("p3")
+
+	if _, err := engine.Join("p2"); err != ErrPlayerExists {
+		t.Errorf("expected ErrPlayerExists for duplicate join, got %v", err)
+	}
+
+	lobby := engine.GetLobby()
+	if lobby.HostID != "p1" {
+		t.Errorf("expected p1 to be host, got %s", lobby.HostID)
+	}
+
+	// 房主离开，座位前移，下一位成为房主
+	if err := engine.Leave("p1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	lobby = engine.GetLobby()
+	if lobby.HostID != "p2" {
+		t.Errorf("expected p2 to become host, got %s", lobby.HostID)
+	}
+	if len(lobby.Seats) != 2 || lobby.Seats[0].PlayerID != "p2" || lobby.Seats[0].Number != 1 {
+		t.Errorf("unexpected seats after leave: %+v", lobby.Seats)
+	}
+	if err := engine.Leave("p1"); err != ErrPlayerNotFound {
+		t.Errorf("expected ErrPlayerNotFound, got %v", err)
+	}
+
+	expected := []pb.EventType{
+		pb.EventType_EVENT_TYPE_PLAYER_JOINED,
+		pb.EventType_EVENT_TYPE_HOST_CHANGED,
+		pb.EventType_EVENT_TYPE_PLAYER_JOINED,
+		pb.EventType_EVENT_TYPE_PLAYER_JOINED,
+		pb.EventType_EVENT_TYPE_PLAYER_LEFT,
+		pb.EventType_EVENT_TYPE_HOST_CHANGED,
+	}
+	if len(*events) != len(expected) {
+		t.Fatalf("expected %d events, got %v", len(expected), *events)
+	}
+	for i, typ := range expected {
+		if (*events)[i] != typ {
+			t.Errorf("event %d: expected %v, got %v", i, typ, (*events)[i])
+		}
+	}
+}
+
+func TestLobby_HostOnlySettings(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.Join("host")
+	engine.Join("guest")
+
+	if err := engine.SetBoard("guest", smallBoard()); err != ErrNotHost {
+		t.Errorf("expected ErrNotHost, got %v", err)
+	}
+	if err := engine.SetBoard("host", smallBoard()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	rules := Rules{WitchCanSaveSelf: true, GuardCanRepeat: true, WolfKillUnanimous: true, WolfTiePolicy: WolfTieLeadWolf}
+	if err := engine.SetRules("guest", rules); err != ErrNotHost {
+		t.Errorf("expected ErrNotHost, got %v", err)
+	}
+	if err := engine.SetRules("host", rules); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lobby := engine.GetLobby()
+	if lobby.Board.Name != "test-3p" || lobby.Board.Size() != 3 {
+		t.Errorf("unexpected board: %+v", lobby.Board)
+	}
+	if lobby.Rules != rules {
+		t.Errorf("expected rules %+v, got %+v", rules, lobby.Rules)
+	}
+
+	// 座位数超过板子人数时不能更换板子
+	engine.Join("third")
+	twoSeats := Board{Name: "2p", Roles: []pb.RoleType{pb.RoleType_ROLE_TYPE_WEREWOLF, pb.RoleType_ROLE_TYPE_VILLAGER}}
+	if err := engine.SetBoard("host", twoSeats); err != ErrSeatMismatch {
+		t.Errorf("expected ErrSeatMismatch, got %v", err)
+	}
+	if _, err := engine.Join("fourth"); err != ErrLobbyFull {
+		t.Errorf("expected ErrLobbyFull, got %v", err)
+	}
+}
+
+func TestLobby_SetRulesDoesNotMutateSharedConfig(t *testing.T) {
+	config := DefaultGameConfig()
+	engine := newTestEngine(t, config)
+
+	if err := engine.SetRules("", Rules{WitchCanSaveSelf: true}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if config.WitchCanSaveSelf {
+		t.Error("expected shared config to be unchanged")
+	}
+}
+
+func TestLobby_SetRulesAppliesToValidation(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	if err := engine.SetRules("", Rules{GuardCanRepeat: true}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if engine.phase.config != engine.config {
+		t.Error("expected phase manager to use the config set by SetRules")
+	}
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	// 大厅中选择的连守规则同样适用于提交校验
+	protect := &SkillUse{PlayerID: "guard", Skill: pb.SkillType_SKILL_TYPE_PROTECT, TargetID: "v1"}
+	if err := engine.SubmitSkillUse(protect); err != nil {
+		t.Fatalf("round 1 protect failed: %v", err)
+	}
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_GUARD)
+	if err := engine.SubmitSkillUse(&SkillUse{PlayerID: "guard", Skill: pb.SkillType_SKILL_TYPE_PROTECT, TargetID: "v1"}); err != nil {
+		t.Errorf("expected repeated protect to be allowed after SetRules, got %v", err)
+	}
+}
+
+func TestLobby_StartDealsRoles(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.SetSeed(42)
+	engine.SetBoard("", smallBoard())
+	for _, id := range []string{"p1", "p2", "p3"} {
+		engine.Join(id)
+	}
+
+	engine.SetReady("p1", true)
+	engine.SetReady("p2", true)
+	if err := engine.Start(); err != ErrPlayersNotReady {
+		t.Fatalf("expected ErrPlayersNotReady, got %v", err)
+	}
+
+	engine.SetReady("p3", true)
+	if err := engine.Start(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var roles []string
+	for i, id := range []string{"p1", "p2", "p3"} {
+		info, ok := engine.GetPlayerInfo(id)
+		if !ok {
+			t.Fatalf("expected %s to be dealt a role", id)
+		}
+		if info.Seat != i+1 {
+			t.Errorf("expected %s at seat %d, got %d", id, i+1, info.Seat)
+		}
+		if info.Camp != DefaultCamp(info.Role) {
+			t.Errorf("expected default camp for %v, got %v", info.Role, info.Camp)
+		}
+		roles = append(roles, info.Role.String())
+	}
+	sort.Strings(roles)
+	expected := []string{"ROLE_TYPE_SEER", "ROLE_TYPE_VILLAGER", "ROLE_TYPE_WEREWOLF"}
+	for i := range expected {
+		if roles[i] != expected[i] {
+			t.Errorf("expected dealt roles %v, got %v", expected, roles)
+			break
+		}
+	}
+
+	// 开始后大厅关闭
+	if _, err := engine.Join("late"); err != ErrGameStarted {
+		t.Errorf("expected ErrGameStarted, got %v", err)
+	}
+	if err := engine.SetReady("p1", false); err != ErrGameStarted {
+		t.Errorf("expected ErrGameStarted, got %v", err)
+	}
+}
+
+func TestLobby_StartRequiresMatchingSeats(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.Join("p1")
+	engine.SetReady("p1", true)
+
+	if err := engine.Start(); !IsErrorCode(err, pb.ErrorCode_ERROR_CODE_SEAT_MISMATCH) {
+		t.Errorf("expected seat mismatch without board, got %v", err)
+	}
+
+	engine.SetBoard("p1", smallBoard())
+	if err := engine.Start(); err != ErrSeatMismatch {
+		t.Errorf("expected ErrSeatMismatch, got %v", err)
+	}
+	if engine.GetCurrentPhase() != pb.PhaseType_PHASE_TYPE_START {
+		t.Error("expected game to stay in lobby")
+	}
+}
+
+func TestLobby_SetBoardValidatesConfig(t *testing.T) {
+	config := DefaultGameConfig()
+	delete(config.Phases, pb.PhaseType_PHASE_TYPE_NIGHT_SEER)
+	config.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_WITCH].NextPhase = pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE
+	engine := newTestEngine(t, config)
+
+	board, _ := LookupBoard(BoardSixPlayers)
+	err := engine.SetBoard("", board)
+	if !IsErrorCode(err, pb.ErrorCode_ERROR_CODE_INVALID_CONFIG) {
+		t.Fatalf("expected INVALID_CONFIG error, got %v", err)
+	}
+	if info := engine.GetLobby(); info.Board.Size() != 0 {
+		t.Errorf("expected board not to be set, got %+v", info.Board)
+	}
+}
+
+func TestLobby_SeedIsDeterministic(t *testing.T) {
+	deal := func() []pb.RoleType {
+		engine := newTestEngine(t, nil)
+		engine.SetSeed(7)
+		board, _ := LookupBoard(BoardNinePlayers)
+		engine.SetBoard("", board)
+		var ids []string
+		for i := 1; i <= board.Size(); i++ {
+			id := string(rune('a' + i))
+			ids = append(ids, id)
+			engine.Join(id)
+			engine.SetReady(id, true)
+		}
+		if err := engine.Start(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		var roles []pb.RoleType
+		for _, id := range ids {
+			info, _ := engine.GetPlayerInfo(id)
+			roles = append(roles, info.Role)
+		}
+		return roles
+	}
+
+	first, second := deal(), deal()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("expected same deal for same seed, got %v and %v", first, second)
+		}
+	}
+}
+
+func TestEngine_AddPlayer_Duplicate(t *testing.T) {
+	engine := newTestEngine(t, nil)
+
+	if err := engine.AddPlayer("p1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := engine.AddPlayer("p1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL); err != ErrPlayerExists {
+		t.Errorf("expected ErrPlayerExists, got %v", err)
+	}
+	info, _ := engine.GetPlayerInfo("p1")
+	if info.Role != pb.RoleType_ROLE_TYPE_VILLAGER {
+		t.Errorf("expected original role to be kept, got %v", info.Role)
+	}
+
+	engine.Join("p2")
+	if err := engine.AddPlayer("p2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD); err != ErrPlayerExists {
+		t.Errorf("expected ErrPlayerExists for seated player, got %v", err)
+	}
+}
+
+func TestPresetBoards(t *testing.T) {
+	for _, board := range PresetBoards() {
+		found, ok := LookupBoard(board.Name)
+		if !ok || found.Size() != board.Size() {
+			t.Errorf("expected to look up board %s", board.Name)
+		}
+		wolves := 0
+		for _, role := range board.Roles {
+			if role == pb.RoleType_ROLE_TYPE_WEREWOLF {
+				wolves++
+			}
+		}
+		if wolves == 0 || wolves*2 >= board.Size() {
+			t.Errorf("board %s has unbalanced wolves: %d of %d", board.Name, wolves, board.Size())
+		}
+	}
+	if _, ok := LookupBoard("missing"); ok {
+		t.Error("expected unknown board not to be found")
+	}
+}
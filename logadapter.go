@@ -0,0 +1,123 @@
+package werewolf
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ==================== 日志适配器 ====================
+//
+// SlogLogger 把 Logger 接到标准库 log/slog；FuncLogger 是通用适配器，
+// 任何日志库（如 zap、zerolog）只需提供一个按级别输出的函数即可接入：
+//
+//	engine.SetLogger(werewolf.NewSlogLogger(slog.Default()))
+//
+//	sugar := zapLogger.Sugar()
+//	engine.SetLogger(werewolf.NewFuncLogger(func(level werewolf.LogLevel, msg string, fields []werewolf.Field) {
+//		sugar.Logw(zapLevels[level], msg, werewolf.FieldsToArgs(fields)...)
+//	}))
+
+// SlogLogger 基于 log/slog 的日志实现
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger 创建 slog 日志适配器，logger 为 nil 时使用 slog.Default()
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{logger: logger}
+}
+
+func (l *SlogLogger) Debug(msg string, fields ...Field) { l.log(slog.LevelDebug, msg, fields) }
+func (l *SlogLogger) Info(msg string, fields ...Field)  { l.log(slog.LevelInfo, msg, fields) }
+func (l *SlogLogger) Warn(msg string, fields ...Field)  { l.log(slog.LevelWarn, msg, fields) }
+func (l *SlogLogger) Error(msg string, fields ...Field) { l.log(slog.LevelError, msg, fields) }
+
+// With 派生带上下文字段的子日志
+func (l *SlogLogger) With(fields ...Field) Logger {
+	return &SlogLogger{logger: l.logger.With(FieldsToArgs(fields)...)}
+}
+
+func (l *SlogLogger) log(level slog.Level, msg string, fields []Field) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := make([]slog.Attr, len(fields))
+	for i, f := range fields {
+		attrs[i] = slog.Any(f.Key, f.Value)
+	}
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// LogLevel 日志级别
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// String 级别名称（debug、info、warn、error）
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// LogFunc 通用日志输出函数，fields 中 With 累积的上下文字段在前、本条日志的字段在后
+type LogFunc func(level LogLevel, msg string, fields []Field)
+
+// FuncLogger 通用日志适配器，把日志转交给 LogFunc
+type FuncLogger struct {
+	fn     LogFunc
+	fields []Field // With 累积的上下文字段
+}
+
+// NewFuncLogger 创建通用日志适配器
+func NewFuncLogger(fn LogFunc) *FuncLogger {
+	return &FuncLogger{fn: fn}
+}
+
+func (l *FuncLogger) Debug(msg string, fields ...Field) { l.log(LogLevelDebug, msg, fields) }
+func (l *FuncLogger) Info(msg string, fields ...Field)  { l.log(LogLevelInfo, msg, fields) }
+func (l *FuncLogger) Warn(msg string, fields ...Field)  { l.log(LogLevelWarn, msg, fields) }
+func (l *FuncLogger) Error(msg string, fields ...Field) { l.log(LogLevelError, msg, fields) }
+
+// With 派生带上下文字段的子日志
+func (l *FuncLogger) With(fields ...Field) Logger {
+	return &FuncLogger{fn: l.fn, fields: joinFields(l.fields, fields)}
+}
+
+func (l *FuncLogger) log(level LogLevel, msg string, fields []Field) {
+	l.fn(level, msg, joinFields(l.fields, fields))
+}
+
+// FieldsToArgs 把字段展开为 key、value 交替的参数（slog、zap SugaredLogger 等使用的格式）
+func FieldsToArgs(fields []Field) []any {
+	args := make([]any, 0, len(fields)*2)
+	for _, f := range fields {
+		args = append(args, f.Key, f.Value)
+	}
+	return args
+}
+
+// joinFields 拼接字段（总是返回新切片，不修改输入）
+func joinFields(a, b []Field) []Field {
+	result := make([]Field, 0, len(a)+len(b))
+	result = append(result, a...)
+	return append(result, b...)
+}
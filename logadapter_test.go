@@ -0,0 +1,144 @@
+package werewolf
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// slogRecords 解析 JSON handler 输出的日志
+func slogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	child := logger.With(GameIDField("g1"))
+	child.Info("hello", PlayerField("p1"))
+	child.Debug("hidden")
+	logger.Warn("parent")
+
+	records := slogRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records (debug filtered), got %v", records)
+	}
+	if records[0]["msg"] != "hello" || records[0]["game_id"] != "g1" || records[0]["player_id"] != "p1" {
+		t.Errorf("expected child fields on record, got %v", records[0])
+	}
+	if records[1]["level"] != "WARN" || records[1]["game_id"] != nil {
+		t.Errorf("expected parent logger without child fields, got %v", records[1])
+	}
+}
+
+func TestFuncLogger(t *testing.T) {
+	type entry struct {
+		level  LogLevel
+		msg    string
+		fields []Field
+	}
+	var entries []entry
+	logger := NewFuncLogger(func(level LogLevel, msg string, fields []Field) {
+		entries = append(entries, entry{level, msg, fields})
+	})
+
+	parent := logger.With(F("a", 1))
+	first := parent.With(F("b", 2))
+	second := parent.With(F("c", 3)) // 派生不影响兄弟日志
+	first.Error("first", F("x", true))
+	second.Debug("second")
+
+	if len(entries) != 2 || entries[0].level != LogLevelError || entries[1].level != LogLevelDebug {
+		t.Fatalf("unexpected entries %v", entries)
+	}
+	if got := FieldsToArgs(entries[0].fields); len(got) != 6 || got[0] != "a" || got[2] != "b" || got[4] != "x" {
+		t.Errorf("expected context fields before call fields, got %v", got)
+	}
+	if got := FieldsToArgs(entries[1].fields); len(got) != 4 || got[2] != "c" {
+		t.Errorf("expected sibling fields to be independent, got %v", got)
+	}
+}
+
+func TestEngine_LoggerContext(t *testing.T) {
+	var buf bytes.Buffer
+	manager := NewGameManager(GameManagerConfig{})
+	engine, _ := manager.CreateGame(nil)
+	engine.SetLogger(NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"})
+
+	// 每条日志都带游戏ID，回合和阶段跟随游戏进度
+	var submitted map[string]any
+	for _, record := range slogRecords(t, &buf) {
+		if record["game_id"] != engine.ID() {
+			t.Errorf("expected game_id %s on every record, got %v", engine.ID(), record)
+		}
+		if record["msg"] == "skill submitted" {
+			submitted = record
+		}
+	}
+	if submitted == nil || submitted["phase"] != pb.PhaseType_PHASE_TYPE_NIGHT_WOLF.String() || submitted["round"] != float64(1) {
+		t.Errorf("expected NIGHT_WOLF round 1 context on skill submission, got %v", submitted)
+	}
+}
+
+func TestEngine_LoggerConcurrentPhaseChange(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.SetLogger(NewFuncLogger(func(LogLevel, string, []Field) {}))
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	// 发言与阶段流转并发时，锁外的日志不能读到正在重新派生的上下文日志（-race 检查）
+	var sent atomic.Int64
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				if engine.SendMessage("v1", "hello") == nil {
+					sent.Add(1)
+				}
+			}
+		}
+	}()
+	// 狼人不行动、白天不投票，游戏在夜晚和白天之间循环；白天等到有消息发出后再结束阶段
+	for i := 0; i < 100; i++ {
+		if engine.GetCurrentPhase() == pb.PhaseType_PHASE_TYPE_DAY {
+			for before := sent.Load(); sent.Load() == before; {
+				runtime.Gosched()
+			}
+		}
+		engine.EndPhase()
+	}
+	close(stop)
+	<-done
+}
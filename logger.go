@@ -1,11 +1,14 @@
 package werewolf
 
 import (
+	"time"
+
 	pb "github.com/Zereker/werewolf/proto"
 )
 
 // Logger 日志接口
 // 允许外部注入日志实现，用于记录游戏事件和调试信息
+// 引擎通过 With 派生带 game_id/round/phase 的子日志，多局并发时可以按这些字段关联日志
 type Logger interface {
 	// Debug 调试级别日志
 	Debug(msg string, fields ...Field)
@@ -15,6 +18,8 @@ type Logger interface {
 	Warn(msg string, fields ...Field)
 	// Error 错误级别日志
 	Error(msg string, fields ...Field)
+	// With 派生子日志，之后的每条日志都带上 fields（不影响原日志）
+	With(fields ...Field) Logger
 }
 
 // Field 日志字段
@@ -28,6 +33,11 @@ func F(key string, value interface{}) Field {
 	return Field{Key: key, Value: value}
 }
 
+// GameIDField 创建游戏ID字段
+func GameIDField(gameID string) Field {
+	return Field{Key: "game_id", Value: gameID}
+}
+
 // PhaseField 创建阶段字段
 func PhaseField(phase pb.PhaseType) Field {
 	return Field{Key: "phase", Value: phase.String()}
@@ -65,6 +75,7 @@ func (l *NopLogger) Debug(msg string, fields ...Field) {}
 func (l *NopLogger) Info(msg string, fields ...Field)  {}
 func (l *NopLogger) Warn(msg string, fields ...Field)  {}
 func (l *NopLogger) Error(msg string, fields ...Field) {}
+func (l *NopLogger) With(fields ...Field) Logger       { return l }
 
 // NewNopLogger 创建空日志
 func NewNopLogger() *NopLogger {
@@ -82,15 +93,30 @@ type Metrics interface {
 	IncGameEnded(winner pb.Camp)
 	// IncEffectApplied 效果应用计数
 	IncEffectApplied(eventType pb.EventType)
+	// IncHandlerPanic 处理器 panic 计数，kind 为 "event"、"message" 或 "game_removed"（管理器移除回调）
+	IncHandlerPanic(kind string)
+	// IncValidationFailure 技能校验失败计数（按错误码）
+	IncValidationFailure(code pb.ErrorCode)
+	// ObservePhaseDuration 阶段持续时间（进入阶段到结束阶段）
+	ObservePhaseDuration(phase pb.PhaseType, d time.Duration)
+	// ObserveTimeToAction 玩家行动耗时（阶段开始到提交或修改技能）
+	ObserveTimeToAction(skill pb.SkillType, d time.Duration)
+	// AddGamesInProgress 进行中的游戏数变化（Start 成功时 +1，游戏结束或未结束就被 GameManager 移除时 -1）
+	AddGamesInProgress(delta int)
 }
 
 // NopMetrics 空指标实现（默认）
 type NopMetrics struct{}
 
-func (m *NopMetrics) IncSkillSubmitted(skill pb.SkillType)   {}
-func (m *NopMetrics) IncPhaseEnded(phase pb.PhaseType)       {}
-func (m *NopMetrics) IncGameEnded(winner pb.Camp)            {}
-func (m *NopMetrics) IncEffectApplied(eventType pb.EventType) {}
+func (m *NopMetrics) IncSkillSubmitted(skill pb.SkillType)                     {}
+func (m *NopMetrics) IncPhaseEnded(phase pb.PhaseType)                         {}
+func (m *NopMetrics) IncGameEnded(winner pb.Camp)                              {}
+func (m *NopMetrics) IncEffectApplied(eventType pb.EventType)                  {}
+func (m *NopMetrics) IncHandlerPanic(kind string)                              {}
+func (m *NopMetrics) IncValidationFailure(code pb.ErrorCode)                   {}
+func (m *NopMetrics) ObservePhaseDuration(phase pb.PhaseType, d time.Duration) {}
+func (m *NopMetrics) ObserveTimeToAction(skill pb.SkillType, d time.Duration)  {}
+func (m *NopMetrics) AddGamesInProgress(delta int)                             {}
 
 // NewNopMetrics 创建空指标收集器
 func NewNopMetrics() *NopMetrics {
@@ -0,0 +1,432 @@
+package werewolf
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// 游戏管理器默认值
+const (
+	DefaultFinishedGameTTL = 10 * time.Minute // 已结束游戏默认保留时长
+	DefaultGCInterval      = time.Minute      // 默认回收间隔
+)
+
+// GameStatus 游戏生命周期状态
+type GameStatus int
+
+const (
+	GameStatusWaiting  GameStatus = iota // 等待开始
+	GameStatusRunning                    // 进行中
+	GameStatusFinished                   // 已结束
+)
+
+// String 返回状态名称
+func (s GameStatus) String() string {
+	switch s {
+	case GameStatusWaiting:
+		return "waiting"
+	case GameStatusRunning:
+		return "running"
+	case GameStatusFinished:
+		return "finished"
+	default:
+		return "unknown"
+	}
+}
+
+// GameManagerConfig 游戏管理器配置
+type GameManagerConfig struct {
+	MaxGames    int           // 最大并发（未结束）游戏数，0 表示不限制
+	FinishedTTL time.Duration // 已结束游戏保留时长，超时后被回收
+	GCInterval  time.Duration // 后台回收间隔，<= 0 表示不启动后台回收（可手动调用 CollectGarbage）
+}
+
+// DefaultGameManagerConfig 默认游戏管理器配置
+func DefaultGameManagerConfig() GameManagerConfig {
+	return GameManagerConfig{
+		MaxGames:    0,
+		FinishedTTL: DefaultFinishedGameTTL,
+		GCInterval:  DefaultGCInterval,
+	}
+}
+
+// GameSummary 游戏摘要（只读快照）
+type GameSummary struct {
+	ID          string
+	Status      GameStatus
+	Phase       pb.PhaseType
+	Round       int
+	PlayerCount int
+	Winner      pb.Camp // 仅已结束游戏有效
+	CreatedAt   time.Time
+	FinishedAt  time.Time // 仅已结束游戏有效
+}
+
+// ManagerMetrics 游戏管理器聚合指标
+type ManagerMetrics struct {
+	Waiting   int    // 等待开始的游戏数
+	Running   int    // 进行中的游戏数
+	Finished  int    // 已结束（尚未回收）的游戏数
+	Players   int    // 所有托管游戏的玩家总数
+	Created   uint64 // 累计创建数
+	Rejected  uint64 // 因并发上限被拒绝的创建数
+	Collected uint64 // 累计回收数
+}
+
+// GameRemovedHook 游戏被移除（回收、手动移除或关闭）时的回调
+type GameRemovedHook func(id string, engine *Engine)
+
+// managedGame 托管的游戏
+type managedGame struct {
+	engine     *Engine
+	createdAt  time.Time
+	finishedAt time.Time
+	winner     pb.Camp
+}
+
+// GameManager 多游戏管理器（并发安全）
+// 负责分配游戏ID、查找、列举、回收已结束游戏、限制并发数和统一关闭
+type GameManager struct {
+	mu sync.RWMutex
+
+	config       GameManagerConfig
+	games        map[string]*managedGame
+	removedHooks []GameRemovedHook
+	closed       bool
+
+	logger  Logger
+	metrics Metrics
+
+	created   uint64
+	rejected  uint64
+	collected uint64
+
+	now     func() time.Time // 时间源（测试可替换）
+	stopGC  chan struct{}
+	gcDone  chan struct{}
+	closeMu sync.Once
+}
+
+// NewGameManager 创建游戏管理器
+// GCInterval > 0 时启动后台回收协程，通过 Shutdown 停止
+func NewGameManager(config GameManagerConfig) *GameManager {
+	m := &GameManager{
+		config:  config,
+		games:   make(map[string]*managedGame),
+		logger:  NewNopLogger(),
+		metrics: NewNopMetrics(),
+		now:     time.Now,
+		stopGC:  make(chan struct{}),
+		gcDone:  make(chan struct{}),
+	}
+
+	if config.GCInterval > 0 {
+		go m.gcLoop(config.GCInterval)
+	} else {
+		close(m.gcDone)
+	}
+
+	return m
+}
+
+// SetLogger 设置日志接口（记录移除回调 panic 等管理器事件）
+func (m *GameManager) SetLogger(logger Logger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if logger != nil {
+		m.logger = logger
+	}
+}
+
+// SetMetrics 设置指标收集器（移除回调 panic 计入 IncHandlerPanic("game_removed")）
+func (m *GameManager) SetMetrics(metrics Metrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if metrics != nil {
+		m.metrics = metrics
+	}
+}
+
+// OnGameRemoved 注册游戏移除回调（用于传输层清理连接等资源）
+func (m *GameManager) OnGameRemoved(hook GameRemovedHook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.removedHooks = append(m.removedHooks, hook)
+}
+
+// CreateGame 创建游戏并分配ID
+// 超过并发上限返回 ErrTooManyGames，关闭后返回 ErrShuttingDown，配置无效时返回 ERROR_CODE_INVALID_CONFIG
+func (m *GameManager) CreateGame(config *GameConfig) (*Engine, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrShuttingDown
+	}
+	if m.config.MaxGames > 0 && m.activeCountLocked() >= m.config.MaxGames {
+		m.rejected++
+		return nil, ErrTooManyGames
+	}
+
+	id := newGameID()
+	for m.games[id] != nil {
+		id = newGameID()
+	}
+
+	engine, err := NewEngine(config)
+	if err != nil {
+		return nil, err
+	}
+	engine.setID(id)
+
+	game := &managedGame{engine: engine, createdAt: m.now()}
+	engine.OnEvent(func(event *pb.Event) {
+		if event.Type == pb.EventType_EVENT_TYPE_GAME_ENDED {
+			m.markFinished(game, event)
+		}
+	})
+
+	m.games[id] = game
+	m.created++
+
+	return engine, nil
+}
+
+// GetGame 按ID查找游戏
+func (m *GameManager) GetGame(id string) (*Engine, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	game, ok := m.games[id]
+	if !ok {
+		return nil, false
+	}
+	return game.engine, true
+}
+
+// ListGames 列出游戏摘要（按创建时间排序）
+// statuses 为空时返回所有游戏，否则只返回指定状态的游戏
+func (m *GameManager) ListGames(statuses ...GameStatus) []GameSummary {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	want := make(map[GameStatus]bool, len(statuses))
+	for _, s := range statuses {
+		want[s] = true
+	}
+
+	result := make([]GameSummary, 0, len(m.games))
+	for id, game := range m.games {
+		summary := m.summarizeLocked(id, game)
+		if len(want) == 0 || want[summary.Status] {
+			result = append(result, summary)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// RemoveGame 立即移除游戏（无论是否结束）
+func (m *GameManager) RemoveGame(id string) bool {
+	m.mu.Lock()
+	game, ok := m.games[id]
+	if ok {
+		delete(m.games, id)
+	}
+	hooks := m.copyHooksLocked()
+	m.mu.Unlock()
+
+	if ok {
+		m.runRemovedHooks(hooks, id, game.engine)
+	}
+	return ok
+}
+
+// CollectGarbage 回收已结束超过 TTL 的游戏，返回回收数量
+// 没有记录结束时间的已结束游戏（未收到 GAME_ENDED）从第一次回收检查时开始计时
+func (m *GameManager) CollectGarbage() int {
+	m.mu.Lock()
+	now := m.now()
+	removed := make(map[string]*Engine)
+	for id, game := range m.games {
+		if m.statusLocked(game) != GameStatusFinished {
+			continue
+		}
+		if game.finishedAt.IsZero() {
+			game.finishedAt = now
+		}
+		if now.Sub(game.finishedAt) >= m.config.FinishedTTL {
+			removed[id] = game.engine
+			delete(m.games, id)
+		}
+	}
+	m.collected += uint64(len(removed))
+	hooks := m.copyHooksLocked()
+	m.mu.Unlock()
+
+	for id, engine := range removed {
+		m.runRemovedHooks(hooks, id, engine)
+	}
+	return len(removed)
+}
+
+// Metrics 获取聚合指标
+func (m *GameManager) Metrics() ManagerMetrics {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	metrics := ManagerMetrics{
+		Created:   m.created,
+		Rejected:  m.rejected,
+		Collected: m.collected,
+	}
+	for _, game := range m.games {
+		switch m.statusLocked(game) {
+		case GameStatusWaiting:
+			metrics.Waiting++
+		case GameStatusRunning:
+			metrics.Running++
+		case GameStatusFinished:
+			metrics.Finished++
+		}
+		metrics.Players += game.engine.playerCount()
+	}
+	return metrics
+}
+
+// Shutdown 关闭管理器：拒绝新游戏、停止后台回收并移除所有游戏
+// ctx 到期前后台回收未退出时返回 ctx.Err()，但游戏仍会被移除
+func (m *GameManager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	games := m.games
+	m.games = make(map[string]*managedGame)
+	hooks := m.copyHooksLocked()
+	m.mu.Unlock()
+
+	m.closeMu.Do(func() { close(m.stopGC) })
+
+	for id, game := range games {
+		m.runRemovedHooks(hooks, id, game.engine)
+	}
+
+	select {
+	case <-m.gcDone:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// gcLoop 后台回收协程
+func (m *GameManager) gcLoop(interval time.Duration) {
+	defer close(m.gcDone)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopGC:
+			return
+		case <-ticker.C:
+			m.CollectGarbage()
+		}
+	}
+}
+
+// markFinished 记录游戏结束时间和获胜方
+func (m *GameManager) markFinished(game *managedGame, event *pb.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	game.finishedAt = m.now()
+	game.winner = event.GetGameEnded().GetWinner()
+}
+
+// statusLocked 计算游戏状态（调用前需持有锁）
+func (m *GameManager) statusLocked(game *managedGame) GameStatus {
+	switch {
+	case !game.finishedAt.IsZero() || game.engine.IsGameOver():
+		return GameStatusFinished
+	case game.engine.GetCurrentPhase() == pb.PhaseType_PHASE_TYPE_START:
+		return GameStatusWaiting
+	default:
+		return GameStatusRunning
+	}
+}
+
+// activeCountLocked 未结束游戏数（调用前需持有锁）
+func (m *GameManager) activeCountLocked() int {
+	count := 0
+	for _, game := range m.games {
+		if m.statusLocked(game) != GameStatusFinished {
+			count++
+		}
+	}
+	return count
+}
+
+// summarizeLocked 生成游戏摘要（调用前需持有锁）
+func (m *GameManager) summarizeLocked(id string, game *managedGame) GameSummary {
+	return GameSummary{
+		ID:          id,
+		Status:      m.statusLocked(game),
+		Phase:       game.engine.GetCurrentPhase(),
+		Round:       game.engine.GetCurrentRound(),
+		PlayerCount: game.engine.playerCount(),
+		Winner:      game.winner,
+		CreatedAt:   game.createdAt,
+		FinishedAt:  game.finishedAt,
+	}
+}
+
+// copyHooksLocked 复制回调列表（调用前需持有锁）
+func (m *GameManager) copyHooksLocked() []GameRemovedHook {
+	hooks := make([]GameRemovedHook, len(m.removedHooks))
+	copy(hooks, m.removedHooks)
+	return hooks
+}
+
+// runRemovedHooks 执行移除回调（调用时不持有锁），单个回调 panic 不影响其他回调，
+// panic 通过 Logger 记录并计入 Metrics.IncHandlerPanic("game_removed")。
+// 未结束就被移除的游戏先从进行中的游戏数中扣除
+func (m *GameManager) runRemovedHooks(hooks []GameRemovedHook, id string, engine *Engine) {
+	engine.abandon()
+	for _, hook := range hooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					m.mu.RLock()
+					logger, metrics := m.logger, m.metrics
+					m.mu.RUnlock()
+					logger.Error("game removed hook panicked", GameIDField(id), F("panic", fmt.Sprint(r)))
+					metrics.IncHandlerPanic("game_removed")
+				}
+			}()
+			hook(id, engine)
+		}()
+	}
+}
+
+// newGameID 生成随机游戏ID
+func newGameID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic("werewolf: generate game id: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,327 @@
+package werewolf
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// newTestManager 创建不启动后台回收、时间可控的管理器
+func newTestManager(maxGames int, ttl time.Duration) (*GameManager, *time.Time) {
+	m := NewGameManager(GameManagerConfig{MaxGames: maxGames, FinishedTTL: ttl})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+// finishGame 让游戏快速结束（1 狼 1 民，第一夜狼人击杀）
+func finishGame(t *testing.T, engine *Engine) {
+	t.Helper()
+	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+	engine.EndPhase() // NIGHT_GUARD -> NIGHT_WOLF
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"})
+	for !engine.IsGameOver() {
+		if _, err := engine.EndPhase(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestGameManager_CreateAndGet(t *testing.T) {
+	m, _ := newTestManager(0, time.Minute)
+
+	engine, err := m.CreateGame(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if engine.ID() == "" {
+		t.Fatal("expected game ID to be assigned")
+	}
+
+	got, ok := m.GetGame(engine.ID())
+	if !ok || got != engine {
+		t.Error("expected to find created game")
+	}
+	if _, ok := m.GetGame("missing"); ok {
+		t.Error("expected missing game not to be found")
+	}
+}
+
+func TestGameManager_CreateGameInvalidConfig(t *testing.T) {
+	m, _ := newTestManager(0, time.Minute)
+
+	config := DefaultGameConfig()
+	delete(config.Phases, pb.PhaseType_PHASE_TYPE_NIGHT_GUARD)
+	if _, err := m.CreateGame(config); !IsErrorCode(err, pb.ErrorCode_ERROR_CODE_INVALID_CONFIG) {
+		t.Fatalf("expected INVALID_CONFIG error, got %v", err)
+	}
+	if games := m.ListGames(); len(games) != 0 {
+		t.Errorf("expected no games, got %d", len(games))
+	}
+}
+
+func TestGameManager_MaxGames(t *testing.T) {
+	m, _ := newTestManager(2, time.Minute)
+
+	first, _ := m.CreateGame(nil)
+	m.CreateGame(nil)
+
+	if _, err := m.CreateGame(nil); err != ErrTooManyGames {
+		t.Fatalf("expected ErrTooManyGames, got %v", err)
+	}
+
+	// 结束的游戏不计入并发数
+	finishGame(t, first)
+	if _, err := m.CreateGame(nil); err != nil {
+		t.Errorf("expected finished game to free a slot, got %v", err)
+	}
+
+	if rejected := m.Metrics().Rejected; rejected != 1 {
+		t.Errorf("expected 1 rejected, got %d", rejected)
+	}
+}
+
+func TestGameManager_ListGames(t *testing.T) {
+	m, now := newTestManager(0, time.Minute)
+
+	waiting, _ := m.CreateGame(nil)
+	*now = now.Add(time.Second)
+	running, _ := m.CreateGame(nil)
+	running.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	running.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	running.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	running.Start()
+	*now = now.Add(time.Second)
+	finished, _ := m.CreateGame(nil)
+	finishGame(t, finished)
+
+	all := m.ListGames()
+	if len(all) != 3 {
+		t.Fatalf("expected 3 games, got %d", len(all))
+	}
+	if all[0].ID != waiting.ID() || all[1].ID != running.ID() || all[2].ID != finished.ID() {
+		t.Error("expected games sorted by creation time")
+	}
+
+	active := m.ListGames(GameStatusWaiting, GameStatusRunning)
+	if len(active) != 2 {
+		t.Errorf("expected 2 active games, got %d", len(active))
+	}
+
+	done := m.ListGames(GameStatusFinished)
+	if len(done) != 1 {
+		t.Fatalf("expected 1 finished game, got %d", len(done))
+	}
+	if done[0].Winner != pb.Camp_CAMP_EVIL {
+		t.Errorf("expected EVIL winner, got %v", done[0].Winner)
+	}
+	if done[0].FinishedAt.IsZero() {
+		t.Error("expected FinishedAt to be recorded")
+	}
+}
+
+func TestGameManager_CollectGarbage(t *testing.T) {
+	m, now := newTestManager(0, time.Minute)
+
+	var removed []string
+	m.OnGameRemoved(func(id string, engine *Engine) {
+		removed = append(removed, id)
+	})
+
+	active, _ := m.CreateGame(nil)
+	finished, _ := m.CreateGame(nil)
+	finishGame(t, finished)
+
+	// TTL 未到，不回收
+	*now = now.Add(30 * time.Second)
+	if n := m.CollectGarbage(); n != 0 {
+		t.Errorf("expected 0 collected before TTL, got %d", n)
+	}
+
+	*now = now.Add(31 * time.Second)
+	if n := m.CollectGarbage(); n != 1 {
+		t.Errorf("expected 1 collected after TTL, got %d", n)
+	}
+	if _, ok := m.GetGame(finished.ID()); ok {
+		t.Error("expected finished game to be collected")
+	}
+	if _, ok := m.GetGame(active.ID()); !ok {
+		t.Error("expected active game to be kept")
+	}
+	if len(removed) != 1 || removed[0] != finished.ID() {
+		t.Errorf("expected removed hook for %s, got %v", finished.ID(), removed)
+	}
+	if m.Metrics().Collected != 1 {
+		t.Errorf("expected Collected=1, got %d", m.Metrics().Collected)
+	}
+}
+
+func TestGameManager_CollectGarbageWithoutFinishTime(t *testing.T) {
+	m, now := newTestManager(0, time.Minute)
+	finished, _ := m.CreateGame(nil)
+	finishGame(t, finished)
+
+	// 没有记录结束时间的已结束游戏从第一次回收检查时开始计时，而不是立即回收
+	m.mu.Lock()
+	m.games[finished.ID()].finishedAt = time.Time{}
+	m.mu.Unlock()
+	if n := m.CollectGarbage(); n != 0 {
+		t.Errorf("expected game without finish time to be kept, got %d collected", n)
+	}
+	if summary := m.ListGames()[0]; !summary.FinishedAt.Equal(*now) {
+		t.Errorf("expected FinishedAt to be set on first GC, got %v", summary.FinishedAt)
+	}
+
+	*now = now.Add(time.Minute)
+	if n := m.CollectGarbage(); n != 1 {
+		t.Errorf("expected 1 collected after TTL, got %d", n)
+	}
+}
+
+func TestGameManager_RemovedHookPanic(t *testing.T) {
+	m, _ := newTestManager(0, time.Minute)
+	recorder := &panicRecorder{}
+	m.SetLogger(recorder)
+	m.SetMetrics(recorder)
+
+	called := false
+	m.OnGameRemoved(func(id string, engine *Engine) { panic("boom") })
+	m.OnGameRemoved(func(id string, engine *Engine) { called = true })
+
+	engine, _ := m.CreateGame(nil)
+	m.RemoveGame(engine.ID())
+
+	if !called {
+		t.Error("expected later hook to run after a panicking hook")
+	}
+	if len(recorder.errors) != 1 || recorder.panics["game_removed"] != 1 {
+		t.Errorf("expected hook panic to be logged and counted, got errors=%v panics=%v", recorder.errors, recorder.panics)
+	}
+}
+
+func TestGameManager_Metrics(t *testing.T) {
+	m, _ := newTestManager(0, time.Minute)
+
+	m.CreateGame(nil)
+	finished, _ := m.CreateGame(nil)
+	finishGame(t, finished)
+
+	metrics := m.Metrics()
+	if metrics.Waiting != 1 || metrics.Finished != 1 || metrics.Running != 0 {
+		t.Errorf("unexpected status counts: %+v", metrics)
+	}
+	if metrics.Players != 3 {
+		t.Errorf("expected 3 players, got %d", metrics.Players)
+	}
+	if metrics.Created != 2 {
+		t.Errorf("expected Created=2, got %d", metrics.Created)
+	}
+}
+
+func TestGameManager_RemoveGameReleasesInProgress(t *testing.T) {
+	m, _ := newTestManager(0, time.Minute)
+	recorder := &metricsRecorder{
+		phaseDurations: make(map[pb.PhaseType]time.Duration),
+		timeToAction:   make(map[pb.SkillType]time.Duration),
+		failures:       make(map[pb.ErrorCode]int),
+	}
+	create := func() *Engine {
+		engine, _ := m.CreateGame(nil)
+		engine.SetMetrics(recorder)
+		return engine
+	}
+
+	waiting := create()
+	finished := create()
+	finishGame(t, finished)
+	running := create()
+	running.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	running.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	running.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	running.Start()
+	if recorder.inProgress != 1 {
+		t.Fatalf("expected 1 game in progress, got %d", recorder.inProgress)
+	}
+
+	// 移除未结束的游戏扣除计数，等待中和已结束的游戏不重复扣除
+	for _, engine := range []*Engine{running, waiting, finished} {
+		m.RemoveGame(engine.ID())
+	}
+	if recorder.inProgress != 0 {
+		t.Errorf("expected no games in progress after removal, got %d", recorder.inProgress)
+	}
+
+	// 被移除的游戏继续推进到结束也不会再次扣除
+	running.EndPhase() // NIGHT_GUARD -> NIGHT_WOLF
+	running.SubmitSkillUse(&SkillUse{PlayerID: "wolf", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"})
+	for !running.IsGameOver() {
+		if _, err := running.EndPhase(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if recorder.inProgress != 0 {
+		t.Errorf("expected removed game not to be released twice, got %d", recorder.inProgress)
+	}
+}
+
+func TestGameManager_Shutdown(t *testing.T) {
+	m := NewGameManager(GameManagerConfig{FinishedTTL: time.Minute, GCInterval: time.Millisecond})
+
+	removed := 0
+	m.OnGameRemoved(func(id string, engine *Engine) {
+		removed++
+	})
+	m.CreateGame(nil)
+	m.CreateGame(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := m.Shutdown(ctx); err != nil {
+		t.Fatalf("expected clean shutdown, got %v", err)
+	}
+
+	if removed != 2 {
+		t.Errorf("expected 2 removed hooks, got %d", removed)
+	}
+	if len(m.ListGames()) != 0 {
+		t.Error("expected no games after shutdown")
+	}
+	if _, err := m.CreateGame(nil); err != ErrShuttingDown {
+		t.Errorf("expected ErrShuttingDown, got %v", err)
+	}
+	// 重复关闭是安全的
+	if err := m.Shutdown(ctx); err != nil {
+		t.Errorf("expected repeated shutdown to succeed, got %v", err)
+	}
+}
+
+func TestGameManager_Concurrency(t *testing.T) {
+	m, _ := newTestManager(50, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if engine, err := m.CreateGame(nil); err == nil {
+				m.GetGame(engine.ID())
+				m.ListGames()
+				m.Metrics()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := len(m.ListGames()); n != 50 {
+		t.Errorf("expected 50 games (max), got %d", n)
+	}
+	if rejected := m.Metrics().Rejected; rejected != 50 {
+		t.Errorf("expected 50 rejected, got %d", rejected)
+	}
+}
@@ -12,28 +12,10 @@ type Phase struct {
 
 // NewPhase 创建阶段管理器
 func NewPhase(config *GameConfig) *Phase {
-	p := &Phase{
+	return &Phase{
 		config:    config,
-		resolvers: make(map[pb.PhaseType]Resolver),
+		resolvers: registeredResolvers(),
 	}
-
-	// 注册解析器
-	p.resolvers[pb.PhaseType_PHASE_TYPE_DAY] = NewDayResolver()
-	p.resolvers[pb.PhaseType_PHASE_TYPE_VOTE] = NewVoteResolver()
-
-	// 注册夜晚子阶段解析器
-	p.resolvers[pb.PhaseType_PHASE_TYPE_NIGHT_GUARD] = NewGuardResolver()
-	p.resolvers[pb.PhaseType_PHASE_TYPE_NIGHT_WOLF] = NewWolfResolver()
-	p.resolvers[pb.PhaseType_PHASE_TYPE_NIGHT_WITCH] = NewWitchResolver()
-	p.resolvers[pb.PhaseType_PHASE_TYPE_NIGHT_SEER] = NewSeerResolver()
-	p.resolvers[pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE] = NewNightResolveResolver()
-
-	// 注册猎人阶段解析器（夜晚和白天共用）
-	hunterResolver := NewHunterResolver()
-	p.resolvers[pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER] = hunterResolver
-	p.resolvers[pb.PhaseType_PHASE_TYPE_DAY_HUNTER] = hunterResolver
-
-	return p
 }
 
 // GetPhaseConfig 获取阶段配置
@@ -113,7 +95,7 @@ func (p *Phase) GetCurrentStepRole(phase pb.PhaseType, subStep int) pb.RoleType
 func (p *Phase) NextSubPhase(current pb.PhaseType) pb.PhaseType {
 	// 游戏开始阶段的特殊处理
 	if current == pb.PhaseType_PHASE_TYPE_START {
-		return pb.PhaseType_PHASE_TYPE_NIGHT_GUARD
+		return FirstPhase
 	}
 
 	// 从配置中获取下一阶段
@@ -126,7 +108,21 @@ func (p *Phase) NextSubPhase(current pb.PhaseType) pb.PhaseType {
 	return pb.PhaseType_PHASE_TYPE_END
 }
 
+// NextPhaseFor 根据状态计算下一阶段
+// 按顺序检查阶段配置的条件转换，第一个满足条件的转换生效；都不满足时使用 NextSubPhase
+func (p *Phase) NextPhaseFor(current pb.PhaseType, state *State) pb.PhaseType {
+	if config := p.GetPhaseConfig(current); config != nil {
+		for _, transition := range config.Transitions {
+			if condition, ok := LookupCondition(transition.When); ok && condition(state) {
+				return transition.Target
+			}
+		}
+	}
+	return p.NextSubPhase(current)
+}
+
 // ValidateSkillUse 验证技能使用是否合法
+// 通用校验之后由角色定义的 ValidateSkillUse 按阶段管理器的配置校验游戏规则
 func (p *Phase) ValidateSkillUse(use *SkillUse, state *State) error {
 	// 检查玩家是否存在
 	player, ok := state.getPlayer(use.PlayerID)
@@ -134,12 +130,11 @@ func (p *Phase) ValidateSkillUse(use *SkillUse, state *State) error {
 		return ErrPlayerNotFound
 	}
 
-	// 猎人阶段特殊处理：死亡的猎人可以使用技能
-	isHunterPhase := state.Phase == pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER ||
-		state.Phase == pb.PhaseType_PHASE_TYPE_DAY_HUNTER
-	if !player.Alive && !isHunterPhase {
+	// 角色定义决定死亡后能否行动（如被触发的猎人开枪）
+	if info, _ := state.GetPlayerInfo(player.ID); !canAct(info, state) {
 		return ErrPlayerDead
 	}
+	def, _ := LookupRole(player.Role)
 
 	// 检查技能是否在当前阶段允许
 	allowedSkills := p.GetAllowedSkills(state.Phase, player.Role)
@@ -156,7 +151,7 @@ func (p *Phase) ValidateSkillUse(use *SkillUse, state *State) error {
 
 	// SKIP 技能不需要目标
 	if use.Skill == pb.SkillType_SKILL_TYPE_SKIP {
-		return nil
+		return validateRoleSkillUse(def, use, state, p.config)
 	}
 
 	// 检查目标是否有效
@@ -165,11 +160,19 @@ func (p *Phase) ValidateSkillUse(use *SkillUse, state *State) error {
 		if !ok {
 			return ErrTargetNotFound
 		}
-		// 某些技能需要目标存活
-		if !target.Alive && use.Skill != pb.SkillType_SKILL_TYPE_ANTIDOTE {
+		// 技能默认需要目标存活，角色定义可以放开（如女巫解药）
+		if !target.Alive && (def.CanTargetDead == nil || !def.CanTargetDead(use.Skill)) {
 			return ErrTargetDead
 		}
 	}
 
-	return nil
+	return validateRoleSkillUse(def, use, state, p.config)
+}
+
+// validateRoleSkillUse 调用角色定义的额外技能校验（如守卫、女巫的游戏规则）
+func validateRoleSkillUse(def RoleDefinition, use *SkillUse, state *State, config *GameConfig) error {
+	if def.ValidateSkillUse == nil {
+		return nil
+	}
+	return def.ValidateSkillUse(use, state, config)
 }
@@ -399,6 +399,57 @@ func TestValidateSkillUse_Valid(t *testing.T) {
 	}
 }
 
+func TestValidateSkillUse_Rules(t *testing.T) {
+	newState := func(phase pb.PhaseType) *State {
+		state := NewState()
+		state.AddPlayer("guard", pb.RoleType_ROLE_TYPE_GUARD, pb.Camp_CAMP_GOOD)
+		state.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+		state.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+		state.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+		state.Phase = phase
+		state.SetLastProtectedTarget("guard", "v1")
+		state.RoundCtx.KillTarget = "v1"
+		return state
+	}
+
+	tests := []struct {
+		name   string
+		config func(*GameConfig)
+		setup  func(*State)
+		use    *SkillUse
+		want   error
+	}{
+		{"guard repeat", nil, nil, &SkillUse{PlayerID: "guard", Skill: pb.SkillType_SKILL_TYPE_PROTECT, TargetID: "v1"}, ErrGuardRepeat},
+		{"guard repeat allowed", func(c *GameConfig) { c.GuardCanRepeat = true }, nil, &SkillUse{PlayerID: "guard", Skill: pb.SkillType_SKILL_TYPE_PROTECT, TargetID: "v1"}, nil},
+		{"guard self", func(c *GameConfig) { c.GuardCanProtectSelf = false }, nil, &SkillUse{PlayerID: "guard", Skill: pb.SkillType_SKILL_TYPE_PROTECT, TargetID: "guard"}, ErrSelfTarget},
+		{"antidote used", nil, func(s *State) { s.players["witch"].HasAntidote = false }, &SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v1"}, ErrNoAntidote},
+		{"antidote not dying", nil, nil, &SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v2"}, ErrTargetNotDying},
+		{"antidote nobody dying", nil, func(s *State) { s.RoundCtx.KillTarget = "" }, &SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v1"}, ErrTargetNotDying},
+		{"antidote self", nil, func(s *State) { s.RoundCtx.KillTarget = "witch" }, &SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "witch"}, ErrSelfTarget},
+		{"antidote ok", nil, nil, &SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v1"}, nil},
+		{"poison used", nil, func(s *State) { s.players["witch"].HasPoison = false }, &SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_POISON, TargetID: "v2"}, ErrNoPoison},
+		{"poison self", nil, nil, &SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_POISON, TargetID: "witch"}, ErrSelfTarget},
+	}
+	for _, tt := range tests {
+		config := DefaultGameConfig()
+		if tt.config != nil {
+			tt.config(config)
+		}
+		phase := pb.PhaseType_PHASE_TYPE_NIGHT_WITCH
+		if tt.use.Skill == pb.SkillType_SKILL_TYPE_PROTECT {
+			phase = pb.PhaseType_PHASE_TYPE_NIGHT_GUARD
+		}
+		state := newState(phase)
+		if tt.setup != nil {
+			tt.setup(state)
+		}
+
+		if err := NewPhase(config).ValidateSkillUse(tt.use, state); err != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
 func TestValidateSkillUse_PlayerNotFound(t *testing.T) {
 	config := DefaultGameConfig()
 	p := NewPhase(config)
@@ -517,6 +568,7 @@ func TestValidateSkillUse_AntidoteOnDead(t *testing.T) {
 	state.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
 	state.AddPlayer("victim", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
 	state.players["victim"].Alive = false
+	state.RoundCtx.KillTarget = "victim"
 	state.Phase = pb.PhaseType_PHASE_TYPE_NIGHT_WITCH
 
 	// Antidote can be used on dead target
@@ -0,0 +1,176 @@
+// Package prommetrics 提供基于 Prometheus 的指标实现
+//
+// Metrics 实现 werewolf.Metrics，同时也是 prometheus.Collector：
+// 可以通过 Handler 直接暴露 /metrics，也可以注册到已有的 Registry 中。
+//
+//	metrics := prommetrics.New(prommetrics.DefaultConfig())
+//	engine.SetMetrics(metrics)
+//	http.Handle("/metrics", metrics.Handler())
+//
+// 同一个 Metrics 可以注入多个引擎，指标按标签（阶段、技能、错误码等）聚合。
+// 枚举标签去掉类型前缀，如 phase="NIGHT_WOLF"、skill="KILL"、code="TARGET_DEAD"。
+package prommetrics
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// Config 指标配置
+type Config struct {
+	Namespace            string    // 指标名前缀，如 werewolf_phase_duration_seconds
+	PhaseDurationBuckets []float64 // 阶段持续时间的分桶（秒）
+	TimeToActionBuckets  []float64 // 行动耗时的分桶（秒）
+}
+
+// DefaultConfig 默认指标配置
+func DefaultConfig() Config {
+	return Config{
+		Namespace:            "werewolf",
+		PhaseDurationBuckets: []float64{1, 5, 10, 15, 30, 60, 120, 300, 600},
+		TimeToActionBuckets:  []float64{0.5, 1, 2, 5, 10, 15, 30, 60, 120},
+	}
+}
+
+// Metrics Prometheus 指标收集器（并发安全）
+type Metrics struct {
+	registry *prometheus.Registry
+
+	skillSubmitted     *prometheus.CounterVec
+	phaseEnded         *prometheus.CounterVec
+	gameEnded          *prometheus.CounterVec
+	effectApplied      *prometheus.CounterVec
+	handlerPanics      *prometheus.CounterVec
+	validationFailures *prometheus.CounterVec
+	phaseDuration      *prometheus.HistogramVec
+	timeToAction       *prometheus.HistogramVec
+	gamesInProgress    prometheus.Gauge
+}
+
+var _ werewolf.Metrics = (*Metrics)(nil)
+
+// New 创建指标收集器，并注册到自己的 Registry（见 Registry、Handler）
+func New(config Config) *Metrics {
+	counter := func(name, help string, label string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      name,
+			Help:      help,
+		}, []string{label})
+	}
+	histogram := func(name, help string, label string, buckets []float64) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: config.Namespace,
+			Name:      name,
+			Help:      help,
+			Buckets:   buckets,
+		}, []string{label})
+	}
+
+	m := &Metrics{
+		registry:           prometheus.NewRegistry(),
+		skillSubmitted:     counter("skills_submitted_total", "Skill uses submitted or changed, by skill.", "skill"),
+		phaseEnded:         counter("phases_ended_total", "Phases ended, by phase.", "phase"),
+		gameEnded:          counter("games_ended_total", "Games ended, by winning camp.", "winner"),
+		effectApplied:      counter("effects_applied_total", "Published effects applied, by event type.", "event"),
+		handlerPanics:      counter("handler_panics_total", "Event, message and game-removed handler panics, by handler kind.", "kind"),
+		validationFailures: counter("validation_failures_total", "Rejected skill uses, by error code.", "code"),
+		phaseDuration:      histogram("phase_duration_seconds", "Time from entering a phase to ending it.", "phase", config.PhaseDurationBuckets),
+		timeToAction:       histogram("time_to_action_seconds", "Time from phase start to a skill submission.", "skill", config.TimeToActionBuckets),
+		gamesInProgress: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: config.Namespace,
+			Name:      "games_in_progress",
+			Help:      "Games started and not yet ended or removed.",
+		}),
+	}
+	m.registry.MustRegister(m)
+	return m
+}
+
+// Registry 指标所在的 Registry（可用于 Gather 或与其他指标合并）
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler 以 Prometheus 文本格式暴露指标的 HTTP 处理器
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Describe 实现 prometheus.Collector
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect 实现 prometheus.Collector
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.skillSubmitted,
+		m.phaseEnded,
+		m.gameEnded,
+		m.effectApplied,
+		m.handlerPanics,
+		m.validationFailures,
+		m.phaseDuration,
+		m.timeToAction,
+		m.gamesInProgress,
+	}
+}
+
+// ==================== werewolf.Metrics ====================
+
+func (m *Metrics) IncSkillSubmitted(skill pb.SkillType) {
+	m.skillSubmitted.WithLabelValues(label(skill.String(), "SKILL_TYPE_")).Inc()
+}
+
+func (m *Metrics) IncPhaseEnded(phase pb.PhaseType) {
+	m.phaseEnded.WithLabelValues(label(phase.String(), "PHASE_TYPE_")).Inc()
+}
+
+func (m *Metrics) IncGameEnded(winner pb.Camp) {
+	m.gameEnded.WithLabelValues(label(winner.String(), "CAMP_")).Inc()
+}
+
+func (m *Metrics) IncEffectApplied(eventType pb.EventType) {
+	m.effectApplied.WithLabelValues(label(eventType.String(), "EVENT_TYPE_")).Inc()
+}
+
+func (m *Metrics) IncHandlerPanic(kind string) {
+	m.handlerPanics.WithLabelValues(kind).Inc()
+}
+
+func (m *Metrics) IncValidationFailure(code pb.ErrorCode) {
+	m.validationFailures.WithLabelValues(label(code.String(), "ERROR_CODE_")).Inc()
+}
+
+func (m *Metrics) ObservePhaseDuration(phase pb.PhaseType, d time.Duration) {
+	m.phaseDuration.WithLabelValues(label(phase.String(), "PHASE_TYPE_")).Observe(d.Seconds())
+}
+
+func (m *Metrics) ObserveTimeToAction(skill pb.SkillType, d time.Duration) {
+	m.timeToAction.WithLabelValues(label(skill.String(), "SKILL_TYPE_")).Observe(d.Seconds())
+}
+
+func (m *Metrics) AddGamesInProgress(delta int) {
+	m.gamesInProgress.Add(float64(delta))
+}
+
+// label 枚举名去掉类型前缀作为标签值
+func label(name, prefix string) string {
+	return strings.TrimPrefix(name, prefix)
+}
@@ -0,0 +1,120 @@
+package prommetrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Zereker/werewolf"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// scrape 通过 Handler 抓取文本格式的指标
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	server := httptest.NewServer(m.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New(DefaultConfig())
+	m.IncSkillSubmitted(pb.SkillType_SKILL_TYPE_KILL)
+	m.IncPhaseEnded(pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	m.IncGameEnded(pb.Camp_CAMP_GOOD)
+	m.IncEffectApplied(pb.EventType_EVENT_TYPE_KILL)
+	m.IncHandlerPanic("event")
+	m.IncValidationFailure(pb.ErrorCode_ERROR_CODE_TARGET_DEAD)
+	m.IncValidationFailure(pb.ErrorCode_ERROR_CODE_TARGET_DEAD)
+	m.ObservePhaseDuration(pb.PhaseType_PHASE_TYPE_DAY, 12*time.Second)
+	m.ObserveTimeToAction(pb.SkillType_SKILL_TYPE_VOTE, 3*time.Second)
+	m.AddGamesInProgress(2)
+	m.AddGamesInProgress(-1)
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`werewolf_skills_submitted_total{skill="KILL"} 1`,
+		`werewolf_phases_ended_total{phase="NIGHT_WOLF"} 1`,
+		`werewolf_games_ended_total{winner="GOOD"} 1`,
+		`werewolf_effects_applied_total{event="KILL"} 1`,
+		`werewolf_handler_panics_total{kind="event"} 1`,
+		`werewolf_validation_failures_total{code="TARGET_DEAD"} 2`,
+		`werewolf_phase_duration_seconds_bucket{phase="DAY",le="10"} 0`,
+		`werewolf_phase_duration_seconds_bucket{phase="DAY",le="15"} 1`,
+		`werewolf_phase_duration_seconds_sum{phase="DAY"} 12`,
+		`werewolf_time_to_action_seconds_count{skill="VOTE"} 1`,
+		`werewolf_games_in_progress 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in scrape output:\n%s", want, body)
+		}
+	}
+}
+
+func TestMetrics_Engine(t *testing.T) {
+	m := New(DefaultConfig())
+	engine, err := werewolf.NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine failed: %v", err)
+	}
+	engine.SetMetrics(m)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	for engine.GetCurrentPhase() != pb.PhaseType_PHASE_TYPE_NIGHT_WOLF {
+		engine.EndPhase()
+	}
+	engine.SubmitSkillUse(&werewolf.SkillUse{PlayerID: "v1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v2"})
+	engine.SubmitSkillUse(&werewolf.SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"})
+	engine.EndPhase()
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`werewolf_games_in_progress 1`,
+		`werewolf_validation_failures_total{code="SKILL_NOT_ALLOWED"} 1`,
+		`werewolf_time_to_action_seconds_count{skill="KILL"} 1`,
+		`werewolf_phase_duration_seconds_count{phase="NIGHT_WOLF"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in scrape output:\n%s", want, body)
+		}
+	}
+}
+
+func TestMetrics_Collector(t *testing.T) {
+	// 可以注册到其他 Registry
+	m := New(Config{Namespace: "game"})
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(m); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	m.AddGamesInProgress(1)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("gather failed: %v", err)
+	}
+	var found bool
+	for _, family := range families {
+		if family.GetName() == "game_games_in_progress" {
+			found = family.GetMetric()[0].GetGauge().GetValue() == 1
+		}
+	}
+	if !found {
+		t.Error("expected game_games_in_progress gauge in custom registry")
+	}
+}
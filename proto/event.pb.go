@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -311,6 +312,20 @@ const (
 	EventType_EVENT_TYPE_ELIMINATE EventType = 8  // 投票出局
 	EventType_EVENT_TYPE_SHOOT     EventType = 9  // 猎人开枪
 	EventType_EVENT_TYPE_SKIP      EventType = 10 // 跳过行动
+	// 大厅（开始前）
+	EventType_EVENT_TYPE_PLAYER_JOINED EventType = 11 // 玩家入座
+	EventType_EVENT_TYPE_PLAYER_LEFT   EventType = 12 // 玩家离座
+	EventType_EVENT_TYPE_PLAYER_READY  EventType = 13 // 玩家准备状态变更
+	EventType_EVENT_TYPE_BOARD_CHANGED EventType = 14 // 房主更换板子
+	EventType_EVENT_TYPE_RULES_CHANGED EventType = 15 // 房主更换规则变体
+	EventType_EVENT_TYPE_HOST_CHANGED  EventType = 16 // 房主变更
+	// 流程（回合与阶段）
+	EventType_EVENT_TYPE_ROUND_STARTED EventType = 17 // 新回合开始
+	EventType_EVENT_TYPE_PHASE_STARTED EventType = 18 // 进入阶段
+	EventType_EVENT_TYPE_PHASE_ENDED   EventType = 19 // 离开阶段
+	EventType_EVENT_TYPE_VOTE_RESULT   EventType = 20 // 投票结果（包括平票和弃票）
+	EventType_EVENT_TYPE_NIGHT_SUMMARY EventType = 21 // 夜晚结果（平安夜或死亡名单）
+	EventType_EVENT_TYPE_WOLF_TALLY    EventType = 22 // 狼人实时票型（仅狼人可见）
 	// 内部状态变更（不对外发布）
 	EventType_EVENT_TYPE_SET_NIGHT_KILL     EventType = 100 // 设置夜晚击杀目标
 	EventType_EVENT_TYPE_CLEAR_NIGHT_KILL   EventType = 101 // 清除夜晚击杀目标（被救）
@@ -334,6 +349,18 @@ var (
 		8:   "EVENT_TYPE_ELIMINATE",
 		9:   "EVENT_TYPE_SHOOT",
 		10:  "EVENT_TYPE_SKIP",
+		11:  "EVENT_TYPE_PLAYER_JOINED",
+		12:  "EVENT_TYPE_PLAYER_LEFT",
+		13:  "EVENT_TYPE_PLAYER_READY",
+		14:  "EVENT_TYPE_BOARD_CHANGED",
+		15:  "EVENT_TYPE_RULES_CHANGED",
+		16:  "EVENT_TYPE_HOST_CHANGED",
+		17:  "EVENT_TYPE_ROUND_STARTED",
+		18:  "EVENT_TYPE_PHASE_STARTED",
+		19:  "EVENT_TYPE_PHASE_ENDED",
+		20:  "EVENT_TYPE_VOTE_RESULT",
+		21:  "EVENT_TYPE_NIGHT_SUMMARY",
+		22:  "EVENT_TYPE_WOLF_TALLY",
 		100: "EVENT_TYPE_SET_NIGHT_KILL",
 		101: "EVENT_TYPE_CLEAR_NIGHT_KILL",
 		102: "EVENT_TYPE_SET_LAST_PROTECTED",
@@ -353,6 +380,18 @@ var (
 		"EVENT_TYPE_ELIMINATE":          8,
 		"EVENT_TYPE_SHOOT":              9,
 		"EVENT_TYPE_SKIP":               10,
+		"EVENT_TYPE_PLAYER_JOINED":      11,
+		"EVENT_TYPE_PLAYER_LEFT":        12,
+		"EVENT_TYPE_PLAYER_READY":       13,
+		"EVENT_TYPE_BOARD_CHANGED":      14,
+		"EVENT_TYPE_RULES_CHANGED":      15,
+		"EVENT_TYPE_HOST_CHANGED":       16,
+		"EVENT_TYPE_ROUND_STARTED":      17,
+		"EVENT_TYPE_PHASE_STARTED":      18,
+		"EVENT_TYPE_PHASE_ENDED":        19,
+		"EVENT_TYPE_VOTE_RESULT":        20,
+		"EVENT_TYPE_NIGHT_SUMMARY":      21,
+		"EVENT_TYPE_WOLF_TALLY":         22,
 		"EVENT_TYPE_SET_NIGHT_KILL":     100,
 		"EVENT_TYPE_CLEAR_NIGHT_KILL":   101,
 		"EVENT_TYPE_SET_LAST_PROTECTED": 102,
@@ -393,43 +432,97 @@ func (EventType) EnumDescriptor() ([]byte, []int) {
 type ErrorCode int32
 
 const (
-	ErrorCode_ERROR_CODE_UNSPECIFIED         ErrorCode = 0
-	ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND    ErrorCode = 1 // 玩家未找到
-	ErrorCode_ERROR_CODE_PLAYER_DEAD         ErrorCode = 2 // 玩家已死亡
-	ErrorCode_ERROR_CODE_TARGET_NOT_FOUND    ErrorCode = 3 // 目标未找到
-	ErrorCode_ERROR_CODE_TARGET_DEAD         ErrorCode = 4 // 目标已死亡
-	ErrorCode_ERROR_CODE_SKILL_NOT_ALLOWED   ErrorCode = 5 // 技能不允许在此阶段使用
-	ErrorCode_ERROR_CODE_GAME_NOT_STARTED    ErrorCode = 6 // 游戏未开始
-	ErrorCode_ERROR_CODE_GAME_ENDED          ErrorCode = 7 // 游戏已结束
-	ErrorCode_ERROR_CODE_INVALID_PHASE       ErrorCode = 8 // 无效阶段
-	ErrorCode_ERROR_CODE_MESSAGE_NOT_ALLOWED ErrorCode = 9 // 当前阶段不允许发言
+	ErrorCode_ERROR_CODE_UNSPECIFIED          ErrorCode = 0
+	ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND     ErrorCode = 1  // 玩家未找到
+	ErrorCode_ERROR_CODE_PLAYER_DEAD          ErrorCode = 2  // 玩家已死亡
+	ErrorCode_ERROR_CODE_TARGET_NOT_FOUND     ErrorCode = 3  // 目标未找到
+	ErrorCode_ERROR_CODE_TARGET_DEAD          ErrorCode = 4  // 目标已死亡
+	ErrorCode_ERROR_CODE_SKILL_NOT_ALLOWED    ErrorCode = 5  // 技能不允许在此阶段使用
+	ErrorCode_ERROR_CODE_GAME_NOT_STARTED     ErrorCode = 6  // 游戏未开始
+	ErrorCode_ERROR_CODE_GAME_ENDED           ErrorCode = 7  // 游戏已结束
+	ErrorCode_ERROR_CODE_INVALID_PHASE        ErrorCode = 8  // 无效阶段
+	ErrorCode_ERROR_CODE_MESSAGE_NOT_ALLOWED  ErrorCode = 9  // 当前阶段不允许发言
+	ErrorCode_ERROR_CODE_GAME_NOT_FOUND       ErrorCode = 10 // 游戏未找到
+	ErrorCode_ERROR_CODE_TOO_MANY_GAMES       ErrorCode = 11 // 超过最大并发游戏数
+	ErrorCode_ERROR_CODE_SHUTTING_DOWN        ErrorCode = 12 // 服务正在关闭
+	ErrorCode_ERROR_CODE_PLAYER_EXISTS        ErrorCode = 13 // 玩家已存在
+	ErrorCode_ERROR_CODE_LOBBY_FULL           ErrorCode = 14 // 座位已满
+	ErrorCode_ERROR_CODE_NOT_HOST             ErrorCode = 15 // 非房主操作
+	ErrorCode_ERROR_CODE_PLAYERS_NOT_READY    ErrorCode = 16 // 有玩家未准备
+	ErrorCode_ERROR_CODE_SEAT_MISMATCH        ErrorCode = 17 // 座位数与板子不匹配
+	ErrorCode_ERROR_CODE_GAME_STARTED         ErrorCode = 18 // 游戏已开始
+	ErrorCode_ERROR_CODE_INVALID_CONFIG       ErrorCode = 19 // 游戏配置无效
+	ErrorCode_ERROR_CODE_SUBSCRIBER_OVERFLOW  ErrorCode = 20 // 订阅者缓冲区已满被断开
+	ErrorCode_ERROR_CODE_DUPLICATE_SKILL_USE  ErrorCode = 21 // 本阶段已提交过相同的技能使用
+	ErrorCode_ERROR_CODE_NO_PENDING_SKILL_USE ErrorCode = 22 // 本阶段没有可修改或撤回的技能使用
+	ErrorCode_ERROR_CODE_GUARD_REPEAT         ErrorCode = 23 // 守卫不能连续两晚守护同一目标
+	ErrorCode_ERROR_CODE_NO_ANTIDOTE          ErrorCode = 24 // 女巫解药已用完
+	ErrorCode_ERROR_CODE_NO_POISON            ErrorCode = 25 // 女巫毒药已用完
+	ErrorCode_ERROR_CODE_TARGET_NOT_DYING     ErrorCode = 26 // 解药目标不是今晚被杀的玩家
+	ErrorCode_ERROR_CODE_SELF_TARGET          ErrorCode = 27 // 技能不能以自己为目标（自守、自救、自毒）
 )
 
 // Enum value maps for ErrorCode.
 var (
 	ErrorCode_name = map[int32]string{
-		0: "ERROR_CODE_UNSPECIFIED",
-		1: "ERROR_CODE_PLAYER_NOT_FOUND",
-		2: "ERROR_CODE_PLAYER_DEAD",
-		3: "ERROR_CODE_TARGET_NOT_FOUND",
-		4: "ERROR_CODE_TARGET_DEAD",
-		5: "ERROR_CODE_SKILL_NOT_ALLOWED",
-		6: "ERROR_CODE_GAME_NOT_STARTED",
-		7: "ERROR_CODE_GAME_ENDED",
-		8: "ERROR_CODE_INVALID_PHASE",
-		9: "ERROR_CODE_MESSAGE_NOT_ALLOWED",
+		0:  "ERROR_CODE_UNSPECIFIED",
+		1:  "ERROR_CODE_PLAYER_NOT_FOUND",
+		2:  "ERROR_CODE_PLAYER_DEAD",
+		3:  "ERROR_CODE_TARGET_NOT_FOUND",
+		4:  "ERROR_CODE_TARGET_DEAD",
+		5:  "ERROR_CODE_SKILL_NOT_ALLOWED",
+		6:  "ERROR_CODE_GAME_NOT_STARTED",
+		7:  "ERROR_CODE_GAME_ENDED",
+		8:  "ERROR_CODE_INVALID_PHASE",
+		9:  "ERROR_CODE_MESSAGE_NOT_ALLOWED",
+		10: "ERROR_CODE_GAME_NOT_FOUND",
+		11: "ERROR_CODE_TOO_MANY_GAMES",
+		12: "ERROR_CODE_SHUTTING_DOWN",
+		13: "ERROR_CODE_PLAYER_EXISTS",
+		14: "ERROR_CODE_LOBBY_FULL",
+		15: "ERROR_CODE_NOT_HOST",
+		16: "ERROR_CODE_PLAYERS_NOT_READY",
+		17: "ERROR_CODE_SEAT_MISMATCH",
+		18: "ERROR_CODE_GAME_STARTED",
+		19: "ERROR_CODE_INVALID_CONFIG",
+		20: "ERROR_CODE_SUBSCRIBER_OVERFLOW",
+		21: "ERROR_CODE_DUPLICATE_SKILL_USE",
+		22: "ERROR_CODE_NO_PENDING_SKILL_USE",
+		23: "ERROR_CODE_GUARD_REPEAT",
+		24: "ERROR_CODE_NO_ANTIDOTE",
+		25: "ERROR_CODE_NO_POISON",
+		26: "ERROR_CODE_TARGET_NOT_DYING",
+		27: "ERROR_CODE_SELF_TARGET",
 	}
 	ErrorCode_value = map[string]int32{
-		"ERROR_CODE_UNSPECIFIED":         0,
-		"ERROR_CODE_PLAYER_NOT_FOUND":    1,
-		"ERROR_CODE_PLAYER_DEAD":         2,
-		"ERROR_CODE_TARGET_NOT_FOUND":    3,
-		"ERROR_CODE_TARGET_DEAD":         4,
-		"ERROR_CODE_SKILL_NOT_ALLOWED":   5,
-		"ERROR_CODE_GAME_NOT_STARTED":    6,
-		"ERROR_CODE_GAME_ENDED":          7,
-		"ERROR_CODE_INVALID_PHASE":       8,
-		"ERROR_CODE_MESSAGE_NOT_ALLOWED": 9,
+		"ERROR_CODE_UNSPECIFIED":          0,
+		"ERROR_CODE_PLAYER_NOT_FOUND":     1,
+		"ERROR_CODE_PLAYER_DEAD":          2,
+		"ERROR_CODE_TARGET_NOT_FOUND":     3,
+		"ERROR_CODE_TARGET_DEAD":          4,
+		"ERROR_CODE_SKILL_NOT_ALLOWED":    5,
+		"ERROR_CODE_GAME_NOT_STARTED":     6,
+		"ERROR_CODE_GAME_ENDED":           7,
+		"ERROR_CODE_INVALID_PHASE":        8,
+		"ERROR_CODE_MESSAGE_NOT_ALLOWED":  9,
+		"ERROR_CODE_GAME_NOT_FOUND":       10,
+		"ERROR_CODE_TOO_MANY_GAMES":       11,
+		"ERROR_CODE_SHUTTING_DOWN":        12,
+		"ERROR_CODE_PLAYER_EXISTS":        13,
+		"ERROR_CODE_LOBBY_FULL":           14,
+		"ERROR_CODE_NOT_HOST":             15,
+		"ERROR_CODE_PLAYERS_NOT_READY":    16,
+		"ERROR_CODE_SEAT_MISMATCH":        17,
+		"ERROR_CODE_GAME_STARTED":         18,
+		"ERROR_CODE_INVALID_CONFIG":       19,
+		"ERROR_CODE_SUBSCRIBER_OVERFLOW":  20,
+		"ERROR_CODE_DUPLICATE_SKILL_USE":  21,
+		"ERROR_CODE_NO_PENDING_SKILL_USE": 22,
+		"ERROR_CODE_GUARD_REPEAT":         23,
+		"ERROR_CODE_NO_ANTIDOTE":          24,
+		"ERROR_CODE_NO_POISON":            25,
+		"ERROR_CODE_TARGET_NOT_DYING":     26,
+		"ERROR_CODE_SELF_TARGET":          27,
 	}
 )
 
@@ -460,32 +553,682 @@ func (ErrorCode) EnumDescriptor() ([]byte, []int) {
 	return file_proto_event_proto_rawDescGZIP(), []int{5}
 }
 
+// VictoryReason 胜负原因
+type VictoryReason int32
+
+const (
+	VictoryReason_VICTORY_REASON_UNSPECIFIED       VictoryReason = 0
+	VictoryReason_VICTORY_REASON_WOLVES_ELIMINATED VictoryReason = 1 // 狼人全部出局，好人胜利
+	VictoryReason_VICTORY_REASON_WOLVES_OUTNUMBER  VictoryReason = 2 // 好人数量不多于狼人，狼人胜利
+)
+
+// Enum value maps for VictoryReason.
+var (
+	VictoryReason_name = map[int32]string{
+		0: "VICTORY_REASON_UNSPECIFIED",
+		1: "VICTORY_REASON_WOLVES_ELIMINATED",
+		2: "VICTORY_REASON_WOLVES_OUTNUMBER",
+	}
+	VictoryReason_value = map[string]int32{
+		"VICTORY_REASON_UNSPECIFIED":       0,
+		"VICTORY_REASON_WOLVES_ELIMINATED": 1,
+		"VICTORY_REASON_WOLVES_OUTNUMBER":  2,
+	}
+)
+
+func (x VictoryReason) Enum() *VictoryReason {
+	p := new(VictoryReason)
+	*p = x
+	return p
+}
+
+func (x VictoryReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VictoryReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_event_proto_enumTypes[6].Descriptor()
+}
+
+func (VictoryReason) Type() protoreflect.EnumType {
+	return &file_proto_event_proto_enumTypes[6]
+}
+
+func (x VictoryReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VictoryReason.Descriptor instead.
+func (VictoryReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{6}
+}
+
+// ErrorDetail 结构化错误（用于传输 GameError），上下文字段未知时为空
+type ErrorDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ErrorCode              `protobuf:"varint,1,opt,name=code,proto3,enum=werewolf.ErrorCode" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 提交技能的玩家
+	Skill         SkillType              `protobuf:"varint,4,opt,name=skill,proto3,enum=werewolf.SkillType" json:"skill,omitempty"`
+	TargetId      string                 `protobuf:"bytes,5,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Phase         PhaseType              `protobuf:"varint,6,opt,name=phase,proto3,enum=werewolf.PhaseType" json:"phase,omitempty"`
+	Round         int32                  `protobuf:"varint,7,opt,name=round,proto3" json:"round,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	mi := &file_proto_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail) ProtoMessage() {}
+
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ErrorDetail) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *ErrorDetail) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetSkill() SkillType {
+	if x != nil {
+		return x.Skill
+	}
+	return SkillType_SKILL_TYPE_UNSPECIFIED
+}
+
+func (x *ErrorDetail) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetPhase() PhaseType {
+	if x != nil {
+		return x.Phase
+	}
+	return PhaseType_PHASE_TYPE_UNSPECIFIED
+}
+
+func (x *ErrorDetail) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
 // Event 游戏事件（轻量级，用于外部通知）
 type Event struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Type     EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=werewolf.EventType" json:"type,omitempty"`
+	SourceId string                 `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`                                                   // 事件来源玩家
+	TargetId string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`                                                   // 事件目标玩家
+	Data     map[string]string      `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 附加数据（字符串形式，为兼容保留，新客户端请使用 payload）
+	// 类型化的事件负载
+	//
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Event_Kill
+	//	*Event_Check
+	//	*Event_VoteResult
+	//	*Event_GameEnded
+	//	*Event_GameStarted
+	//	*Event_Phase
+	//	*Event_RoundStarted
+	//	*Event_NightSummary
+	//	*Event_WolfTally
+	Payload  isEvent_Payload `protobuf_oneof:"payload"`
+	Sequence uint64          `protobuf:"varint,13,opt,name=sequence,proto3" json:"sequence,omitempty"` // 本局内的发布序号（事件和消息共用，从 1 递增）
+	// 事件所属的阶段：阶段结算和 PHASE_ENDED 属于结束的阶段，ROUND_STARTED/PHASE_STARTED
+	// 属于新阶段，GAME_STARTED 属于第一个阶段，GAME_ENDED 属于 END，大厅事件属于 START
+	PhaseType PhaseType `protobuf:"varint,14,opt,name=phase_type,json=phaseType,proto3,enum=werewolf.PhaseType" json:"phase_type,omitempty"`
+	// 事件的接收者，非空时只有这些玩家可见（优先于按事件类型的可见性规则）
+	AudienceIds   []string `protobuf:"bytes,15,rep,name=audience_ids,json=audienceIds,proto3" json:"audience_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_proto_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Event) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *Event) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *Event) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Event) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Event) GetPayload() isEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetKill() *KillEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Kill); ok {
+			return x.Kill
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetCheck() *CheckEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Check); ok {
+			return x.Check
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetVoteResult() *VoteResultEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_VoteResult); ok {
+			return x.VoteResult
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetGameEnded() *GameEndedEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_GameEnded); ok {
+			return x.GameEnded
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetGameStarted() *GameStartedEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_GameStarted); ok {
+			return x.GameStarted
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPhase() *PhaseEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Phase); ok {
+			return x.Phase
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetRoundStarted() *RoundStartedEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_RoundStarted); ok {
+			return x.RoundStarted
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetNightSummary() *NightSummaryEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_NightSummary); ok {
+			return x.NightSummary
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetWolfTally() *WolfTallyEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_WolfTally); ok {
+			return x.WolfTally
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Event) GetPhaseType() PhaseType {
+	if x != nil {
+		return x.PhaseType
+	}
+	return PhaseType_PHASE_TYPE_UNSPECIFIED
+}
+
+func (x *Event) GetAudienceIds() []string {
+	if x != nil {
+		return x.AudienceIds
+	}
+	return nil
+}
+
+type isEvent_Payload interface {
+	isEvent_Payload()
+}
+
+type Event_Kill struct {
+	Kill *KillEvent `protobuf:"bytes,5,opt,name=kill,proto3,oneof"` // KILL / POISON / ELIMINATE / SHOOT
+}
+
+type Event_Check struct {
+	Check *CheckEvent `protobuf:"bytes,6,opt,name=check,proto3,oneof"` // CHECK
+}
+
+type Event_VoteResult struct {
+	VoteResult *VoteResultEvent `protobuf:"bytes,7,opt,name=vote_result,json=voteResult,proto3,oneof"` // VOTE_RESULT
+}
+
+type Event_GameEnded struct {
+	GameEnded *GameEndedEvent `protobuf:"bytes,8,opt,name=game_ended,json=gameEnded,proto3,oneof"` // GAME_ENDED
+}
+
+type Event_GameStarted struct {
+	GameStarted *GameStartedEvent `protobuf:"bytes,9,opt,name=game_started,json=gameStarted,proto3,oneof"` // GAME_STARTED
+}
+
+type Event_Phase struct {
+	Phase *PhaseEvent `protobuf:"bytes,10,opt,name=phase,proto3,oneof"` // PHASE_STARTED / PHASE_ENDED
+}
+
+type Event_RoundStarted struct {
+	RoundStarted *RoundStartedEvent `protobuf:"bytes,11,opt,name=round_started,json=roundStarted,proto3,oneof"` // ROUND_STARTED
+}
+
+type Event_NightSummary struct {
+	NightSummary *NightSummaryEvent `protobuf:"bytes,12,opt,name=night_summary,json=nightSummary,proto3,oneof"` // NIGHT_SUMMARY
+}
+
+type Event_WolfTally struct {
+	WolfTally *WolfTallyEvent `protobuf:"bytes,16,opt,name=wolf_tally,json=wolfTally,proto3,oneof"` // WOLF_TALLY
+}
+
+func (*Event_Kill) isEvent_Payload() {}
+
+func (*Event_Check) isEvent_Payload() {}
+
+func (*Event_VoteResult) isEvent_Payload() {}
+
+func (*Event_GameEnded) isEvent_Payload() {}
+
+func (*Event_GameStarted) isEvent_Payload() {}
+
+func (*Event_Phase) isEvent_Payload() {}
+
+func (*Event_RoundStarted) isEvent_Payload() {}
+
+func (*Event_NightSummary) isEvent_Payload() {}
+
+func (*Event_WolfTally) isEvent_Payload() {}
+
+// KillEvent 玩家死亡
+type KillEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`    // 死亡的玩家
+	Cause         EventType              `protobuf:"varint,2,opt,name=cause,proto3,enum=werewolf.EventType" json:"cause,omitempty"` // 死因：KILL / POISON / ELIMINATE / SHOOT
+	KillerId      string                 `protobuf:"bytes,3,opt,name=killer_id,json=killerId,proto3" json:"killer_id,omitempty"`    // 行凶者（猎人开枪时为猎人，夜晚结算和投票为空）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KillEvent) Reset() {
+	*x = KillEvent{}
+	mi := &file_proto_event_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KillEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KillEvent) ProtoMessage() {}
+
+func (x *KillEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KillEvent.ProtoReflect.Descriptor instead.
+func (*KillEvent) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KillEvent) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *KillEvent) GetCause() EventType {
+	if x != nil {
+		return x.Cause
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *KillEvent) GetKillerId() string {
+	if x != nil {
+		return x.KillerId
+	}
+	return ""
+}
+
+// CheckEvent 预言家查验结果
+type CheckEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetId      string                 `protobuf:"bytes,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Camp          Camp                   `protobuf:"varint,2,opt,name=camp,proto3,enum=werewolf.Camp" json:"camp,omitempty"`
+	IsGood        bool                   `protobuf:"varint,3,opt,name=is_good,json=isGood,proto3" json:"is_good,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckEvent) Reset() {
+	*x = CheckEvent{}
+	mi := &file_proto_event_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckEvent) ProtoMessage() {}
+
+func (x *CheckEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckEvent.ProtoReflect.Descriptor instead.
+func (*CheckEvent) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckEvent) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *CheckEvent) GetCamp() Camp {
+	if x != nil {
+		return x.Camp
+	}
+	return Camp_CAMP_UNSPECIFIED
+}
+
+func (x *CheckEvent) GetIsGood() bool {
+	if x != nil {
+		return x.IsGood
+	}
+	return false
+}
+
+// VoteTally 单个目标的得票
+type VoteTally struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetId      string                 `protobuf:"bytes,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Votes         int32                  `protobuf:"varint,2,opt,name=votes,proto3" json:"votes,omitempty"`
+	VoterIds      []string               `protobuf:"bytes,3,rep,name=voter_ids,json=voterIds,proto3" json:"voter_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteTally) Reset() {
+	*x = VoteTally{}
+	mi := &file_proto_event_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteTally) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteTally) ProtoMessage() {}
+
+func (x *VoteTally) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteTally.ProtoReflect.Descriptor instead.
+func (*VoteTally) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VoteTally) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *VoteTally) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+func (x *VoteTally) GetVoterIds() []string {
+	if x != nil {
+		return x.VoterIds
+	}
+	return nil
+}
+
+// VoteResultEvent 投票结果
+type VoteResultEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tallies       []*VoteTally           `protobuf:"bytes,1,rep,name=tallies,proto3" json:"tallies,omitempty"`                               // 按得票数从高到低，同票按目标 ID 排序
+	EliminatedId  string                 `protobuf:"bytes,2,opt,name=eliminated_id,json=eliminatedId,proto3" json:"eliminated_id,omitempty"` // 被放逐的玩家（平票或无票时为空）
+	Tied          bool                   `protobuf:"varint,3,opt,name=tied,proto3" json:"tied,omitempty"`                                    // 是否平票
+	AbstainedIds  []string               `protobuf:"bytes,4,rep,name=abstained_ids,json=abstainedIds,proto3" json:"abstained_ids,omitempty"` // 存活但未投票的玩家（按座位排序）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteResultEvent) Reset() {
+	*x = VoteResultEvent{}
+	mi := &file_proto_event_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteResultEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteResultEvent) ProtoMessage() {}
+
+func (x *VoteResultEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteResultEvent.ProtoReflect.Descriptor instead.
+func (*VoteResultEvent) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VoteResultEvent) GetTallies() []*VoteTally {
+	if x != nil {
+		return x.Tallies
+	}
+	return nil
+}
+
+func (x *VoteResultEvent) GetEliminatedId() string {
+	if x != nil {
+		return x.EliminatedId
+	}
+	return ""
+}
+
+func (x *VoteResultEvent) GetTied() bool {
+	if x != nil {
+		return x.Tied
+	}
+	return false
+}
+
+func (x *VoteResultEvent) GetAbstainedIds() []string {
+	if x != nil {
+		return x.AbstainedIds
+	}
+	return nil
+}
+
+// GameEndedEvent 游戏结束
+type GameEndedEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=werewolf.EventType" json:"type,omitempty"`
-	SourceId      string                 `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`                                                   // 事件来源玩家
-	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`                                                   // 事件目标玩家
-	Data          map[string]string      `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 附加数据
+	Winner        Camp                   `protobuf:"varint,1,opt,name=winner,proto3,enum=werewolf.Camp" json:"winner,omitempty"`
+	Reason        VictoryReason          `protobuf:"varint,2,opt,name=reason,proto3,enum=werewolf.VictoryReason" json:"reason,omitempty"`
+	Round         int32                  `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"` // 结束时的回合
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
-	mi := &file_proto_event_proto_msgTypes[0]
+func (x *GameEndedEvent) Reset() {
+	*x = GameEndedEvent{}
+	mi := &file_proto_event_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Event) String() string {
+func (x *GameEndedEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*GameEndedEvent) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_event_proto_msgTypes[0]
+func (x *GameEndedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,52 +1239,468 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
-	return file_proto_event_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use GameEndedEvent.ProtoReflect.Descriptor instead.
+func (*GameEndedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Event) GetType() EventType {
+func (x *GameEndedEvent) GetWinner() Camp {
 	if x != nil {
-		return x.Type
+		return x.Winner
 	}
-	return EventType_EVENT_TYPE_UNSPECIFIED
+	return Camp_CAMP_UNSPECIFIED
 }
 
-func (x *Event) GetSourceId() string {
+func (x *GameEndedEvent) GetReason() VictoryReason {
 	if x != nil {
-		return x.SourceId
+		return x.Reason
 	}
-	return ""
+	return VictoryReason_VICTORY_REASON_UNSPECIFIED
 }
 
-func (x *Event) GetTargetId() string {
+func (x *GameEndedEvent) GetRound() int32 {
 	if x != nil {
-		return x.TargetId
+		return x.Round
 	}
-	return ""
+	return 0
 }
 
-func (x *Event) GetData() map[string]string {
+// GameStartedEvent 游戏开始
+type GameStartedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Board         []RoleType             `protobuf:"varint,1,rep,packed,name=board,proto3,enum=werewolf.RoleType" json:"board,omitempty"` // 本局角色配置（按角色排序，不对应座位）
+	PlayerIds     []string               `protobuf:"bytes,2,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`       // 玩家（按座位排序）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameStartedEvent) Reset() {
+	*x = GameStartedEvent{}
+	mi := &file_proto_event_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameStartedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameStartedEvent) ProtoMessage() {}
+
+func (x *GameStartedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[7]
 	if x != nil {
-		return x.Data
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameStartedEvent.ProtoReflect.Descriptor instead.
+func (*GameStartedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GameStartedEvent) GetBoard() []RoleType {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *GameStartedEvent) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+// PhaseEvent 阶段进入/离开
+type PhaseEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         PhaseType              `protobuf:"varint,1,opt,name=phase,proto3,enum=werewolf.PhaseType" json:"phase,omitempty"`
+	Round         int32                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deadline,proto3" json:"deadline,omitempty"`                                             // 阶段超时时间（仅 PHASE_STARTED）
+	NextPhase     PhaseType              `protobuf:"varint,4,opt,name=next_phase,json=nextPhase,proto3,enum=werewolf.PhaseType" json:"next_phase,omitempty"` // 下一阶段（仅 PHASE_ENDED，游戏结束时为 END）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseEvent) Reset() {
+	*x = PhaseEvent{}
+	mi := &file_proto_event_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseEvent) ProtoMessage() {}
+
+func (x *PhaseEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseEvent.ProtoReflect.Descriptor instead.
+func (*PhaseEvent) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PhaseEvent) GetPhase() PhaseType {
+	if x != nil {
+		return x.Phase
+	}
+	return PhaseType_PHASE_TYPE_UNSPECIFIED
+}
+
+func (x *PhaseEvent) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *PhaseEvent) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *PhaseEvent) GetNextPhase() PhaseType {
+	if x != nil {
+		return x.NextPhase
+	}
+	return PhaseType_PHASE_TYPE_UNSPECIFIED
+}
+
+// RoundStartedEvent 新回合开始
+type RoundStartedEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Round          int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	AlivePlayerIds []string               `protobuf:"bytes,2,rep,name=alive_player_ids,json=alivePlayerIds,proto3" json:"alive_player_ids,omitempty"` // 存活玩家（按座位排序）
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RoundStartedEvent) Reset() {
+	*x = RoundStartedEvent{}
+	mi := &file_proto_event_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoundStartedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundStartedEvent) ProtoMessage() {}
+
+func (x *RoundStartedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundStartedEvent.ProtoReflect.Descriptor instead.
+func (*RoundStartedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RoundStartedEvent) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *RoundStartedEvent) GetAlivePlayerIds() []string {
+	if x != nil {
+		return x.AlivePlayerIds
+	}
+	return nil
+}
+
+// NightSummaryEvent 夜晚结果（不公开死因）
+type NightSummaryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Round         int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	DeadPlayerIds []string               `protobuf:"bytes,2,rep,name=dead_player_ids,json=deadPlayerIds,proto3" json:"dead_player_ids,omitempty"` // 昨夜死亡的玩家（按座位排序）
+	Peaceful      bool                   `protobuf:"varint,3,opt,name=peaceful,proto3" json:"peaceful,omitempty"`                                 // 是否平安夜
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NightSummaryEvent) Reset() {
+	*x = NightSummaryEvent{}
+	mi := &file_proto_event_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NightSummaryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NightSummaryEvent) ProtoMessage() {}
+
+func (x *NightSummaryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NightSummaryEvent.ProtoReflect.Descriptor instead.
+func (*NightSummaryEvent) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NightSummaryEvent) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *NightSummaryEvent) GetDeadPlayerIds() []string {
+	if x != nil {
+		return x.DeadPlayerIds
+	}
+	return nil
+}
+
+func (x *NightSummaryEvent) GetPeaceful() bool {
+	if x != nil {
+		return x.Peaceful
+	}
+	return false
+}
+
+// WolfTallyEvent 狼人阶段的实时票型
+type WolfTallyEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Round         int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Picks         []*WolfPick            `protobuf:"bytes,2,rep,name=picks,proto3" json:"picks,omitempty"`     // 每只存活狼人当前的选择（按座位，未选择的 target_id 为空）
+	Tallies       []*VoteTally           `protobuf:"bytes,3,rep,name=tallies,proto3" json:"tallies,omitempty"` // 按得票排序的统计
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WolfTallyEvent) Reset() {
+	*x = WolfTallyEvent{}
+	mi := &file_proto_event_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WolfTallyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WolfTallyEvent) ProtoMessage() {}
+
+func (x *WolfTallyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WolfTallyEvent.ProtoReflect.Descriptor instead.
+func (*WolfTallyEvent) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WolfTallyEvent) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *WolfTallyEvent) GetPicks() []*WolfPick {
+	if x != nil {
+		return x.Picks
+	}
+	return nil
+}
+
+func (x *WolfTallyEvent) GetTallies() []*VoteTally {
+	if x != nil {
+		return x.Tallies
 	}
 	return nil
 }
 
+// WolfPick 一只狼人的选择
+type WolfPick struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WolfId        string                 `protobuf:"bytes,1,opt,name=wolf_id,json=wolfId,proto3" json:"wolf_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WolfPick) Reset() {
+	*x = WolfPick{}
+	mi := &file_proto_event_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WolfPick) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WolfPick) ProtoMessage() {}
+
+func (x *WolfPick) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_event_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WolfPick.ProtoReflect.Descriptor instead.
+func (*WolfPick) Descriptor() ([]byte, []int) {
+	return file_proto_event_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WolfPick) GetWolfId() string {
+	if x != nil {
+		return x.WolfId
+	}
+	return ""
+}
+
+func (x *WolfPick) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
 var File_proto_event_proto protoreflect.FileDescriptor
 
 const file_proto_event_proto_rawDesc = "" +
 	"\n" +
-	"\x11proto/event.proto\x12\bwerewolf\"\xd2\x01\n" +
+	"\x11proto/event.proto\x12\bwerewolf\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf6\x01\n" +
+	"\vErrorDetail\x12'\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x13.werewolf.ErrorCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\tR\bplayerId\x12)\n" +
+	"\x05skill\x18\x04 \x01(\x0e2\x13.werewolf.SkillTypeR\x05skill\x12\x1b\n" +
+	"\ttarget_id\x18\x05 \x01(\tR\btargetId\x12)\n" +
+	"\x05phase\x18\x06 \x01(\x0e2\x13.werewolf.PhaseTypeR\x05phase\x12\x14\n" +
+	"\x05round\x18\a \x01(\x05R\x05round\"\xd4\x06\n" +
 	"\x05Event\x12'\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x13.werewolf.EventTypeR\x04type\x12\x1b\n" +
 	"\tsource_id\x18\x02 \x01(\tR\bsourceId\x12\x1b\n" +
 	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12-\n" +
-	"\x04data\x18\x04 \x03(\v2\x19.werewolf.Event.DataEntryR\x04data\x1a7\n" +
+	"\x04data\x18\x04 \x03(\v2\x19.werewolf.Event.DataEntryR\x04data\x12)\n" +
+	"\x04kill\x18\x05 \x01(\v2\x13.werewolf.KillEventH\x00R\x04kill\x12,\n" +
+	"\x05check\x18\x06 \x01(\v2\x14.werewolf.CheckEventH\x00R\x05check\x12<\n" +
+	"\vvote_result\x18\a \x01(\v2\x19.werewolf.VoteResultEventH\x00R\n" +
+	"voteResult\x129\n" +
+	"\n" +
+	"game_ended\x18\b \x01(\v2\x18.werewolf.GameEndedEventH\x00R\tgameEnded\x12?\n" +
+	"\fgame_started\x18\t \x01(\v2\x1a.werewolf.GameStartedEventH\x00R\vgameStarted\x12,\n" +
+	"\x05phase\x18\n" +
+	" \x01(\v2\x14.werewolf.PhaseEventH\x00R\x05phase\x12B\n" +
+	"\rround_started\x18\v \x01(\v2\x1b.werewolf.RoundStartedEventH\x00R\froundStarted\x12B\n" +
+	"\rnight_summary\x18\f \x01(\v2\x1b.werewolf.NightSummaryEventH\x00R\fnightSummary\x129\n" +
+	"\n" +
+	"wolf_tally\x18\x10 \x01(\v2\x18.werewolf.WolfTallyEventH\x00R\twolfTally\x12\x1a\n" +
+	"\bsequence\x18\r \x01(\x04R\bsequence\x122\n" +
+	"\n" +
+	"phase_type\x18\x0e \x01(\x0e2\x13.werewolf.PhaseTypeR\tphaseType\x12!\n" +
+	"\faudience_ids\x18\x0f \x03(\tR\vaudienceIds\x1a7\n" +
 	"\tDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*\xd4\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
+	"\apayload\"p\n" +
+	"\tKillEvent\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12)\n" +
+	"\x05cause\x18\x02 \x01(\x0e2\x13.werewolf.EventTypeR\x05cause\x12\x1b\n" +
+	"\tkiller_id\x18\x03 \x01(\tR\bkillerId\"f\n" +
+	"\n" +
+	"CheckEvent\x12\x1b\n" +
+	"\ttarget_id\x18\x01 \x01(\tR\btargetId\x12\"\n" +
+	"\x04camp\x18\x02 \x01(\x0e2\x0e.werewolf.CampR\x04camp\x12\x17\n" +
+	"\ais_good\x18\x03 \x01(\bR\x06isGood\"[\n" +
+	"\tVoteTally\x12\x1b\n" +
+	"\ttarget_id\x18\x01 \x01(\tR\btargetId\x12\x14\n" +
+	"\x05votes\x18\x02 \x01(\x05R\x05votes\x12\x1b\n" +
+	"\tvoter_ids\x18\x03 \x03(\tR\bvoterIds\"\x9e\x01\n" +
+	"\x0fVoteResultEvent\x12-\n" +
+	"\atallies\x18\x01 \x03(\v2\x13.werewolf.VoteTallyR\atallies\x12#\n" +
+	"\reliminated_id\x18\x02 \x01(\tR\feliminatedId\x12\x12\n" +
+	"\x04tied\x18\x03 \x01(\bR\x04tied\x12#\n" +
+	"\rabstained_ids\x18\x04 \x03(\tR\fabstainedIds\"\x7f\n" +
+	"\x0eGameEndedEvent\x12&\n" +
+	"\x06winner\x18\x01 \x01(\x0e2\x0e.werewolf.CampR\x06winner\x12/\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x17.werewolf.VictoryReasonR\x06reason\x12\x14\n" +
+	"\x05round\x18\x03 \x01(\x05R\x05round\"[\n" +
+	"\x10GameStartedEvent\x12(\n" +
+	"\x05board\x18\x01 \x03(\x0e2\x12.werewolf.RoleTypeR\x05board\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x02 \x03(\tR\tplayerIds\"\xb9\x01\n" +
+	"\n" +
+	"PhaseEvent\x12)\n" +
+	"\x05phase\x18\x01 \x01(\x0e2\x13.werewolf.PhaseTypeR\x05phase\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x05R\x05round\x126\n" +
+	"\bdeadline\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x122\n" +
+	"\n" +
+	"next_phase\x18\x04 \x01(\x0e2\x13.werewolf.PhaseTypeR\tnextPhase\"S\n" +
+	"\x11RoundStartedEvent\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12(\n" +
+	"\x10alive_player_ids\x18\x02 \x03(\tR\x0ealivePlayerIds\"m\n" +
+	"\x11NightSummaryEvent\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12&\n" +
+	"\x0fdead_player_ids\x18\x02 \x03(\tR\rdeadPlayerIds\x12\x1a\n" +
+	"\bpeaceful\x18\x03 \x01(\bR\bpeaceful\"\x7f\n" +
+	"\x0eWolfTallyEvent\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12(\n" +
+	"\x05picks\x18\x02 \x03(\v2\x12.werewolf.WolfPickR\x05picks\x12-\n" +
+	"\atallies\x18\x03 \x03(\v2\x13.werewolf.VoteTallyR\atallies\"@\n" +
+	"\bWolfPick\x12\x17\n" +
+	"\awolf_id\x18\x01 \x01(\tR\x06wolfId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId*\xd4\x02\n" +
 	"\tPhaseType\x12\x1a\n" +
 	"\x16PHASE_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10PHASE_TYPE_START\x10\n" +
@@ -582,7 +1741,7 @@ const file_proto_event_proto_rawDesc = "" +
 	"\x10SKILL_TYPE_SHOOT\x10\b\x12\x17\n" +
 	"\x13SKILL_TYPE_ANNOUNCE\x10\t\x12\x13\n" +
 	"\x0fSKILL_TYPE_SKIP\x10\n" +
-	"*\xcf\x03\n" +
+	"*\xac\x06\n" +
 	"\tEventType\x12\x1a\n" +
 	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17EVENT_TYPE_GAME_STARTED\x10\x01\x12\x19\n" +
@@ -595,13 +1754,25 @@ const file_proto_event_proto_rawDesc = "" +
 	"\x14EVENT_TYPE_ELIMINATE\x10\b\x12\x14\n" +
 	"\x10EVENT_TYPE_SHOOT\x10\t\x12\x13\n" +
 	"\x0fEVENT_TYPE_SKIP\x10\n" +
-	"\x12\x1d\n" +
+	"\x12\x1c\n" +
+	"\x18EVENT_TYPE_PLAYER_JOINED\x10\v\x12\x1a\n" +
+	"\x16EVENT_TYPE_PLAYER_LEFT\x10\f\x12\x1b\n" +
+	"\x17EVENT_TYPE_PLAYER_READY\x10\r\x12\x1c\n" +
+	"\x18EVENT_TYPE_BOARD_CHANGED\x10\x0e\x12\x1c\n" +
+	"\x18EVENT_TYPE_RULES_CHANGED\x10\x0f\x12\x1b\n" +
+	"\x17EVENT_TYPE_HOST_CHANGED\x10\x10\x12\x1c\n" +
+	"\x18EVENT_TYPE_ROUND_STARTED\x10\x11\x12\x1c\n" +
+	"\x18EVENT_TYPE_PHASE_STARTED\x10\x12\x12\x1a\n" +
+	"\x16EVENT_TYPE_PHASE_ENDED\x10\x13\x12\x1a\n" +
+	"\x16EVENT_TYPE_VOTE_RESULT\x10\x14\x12\x1c\n" +
+	"\x18EVENT_TYPE_NIGHT_SUMMARY\x10\x15\x12\x19\n" +
+	"\x15EVENT_TYPE_WOLF_TALLY\x10\x16\x12\x1d\n" +
 	"\x19EVENT_TYPE_SET_NIGHT_KILL\x10d\x12\x1f\n" +
 	"\x1bEVENT_TYPE_CLEAR_NIGHT_KILL\x10e\x12!\n" +
 	"\x1dEVENT_TYPE_SET_LAST_PROTECTED\x10f\x12\x1b\n" +
 	"\x17EVENT_TYPE_USE_ANTIDOTE\x10g\x12\x19\n" +
 	"\x15EVENT_TYPE_USE_POISON\x10h\x12\x1f\n" +
-	"\x1bEVENT_TYPE_HUNTER_TRIGGERED\x10i*\xc1\x02\n" +
+	"\x1bEVENT_TYPE_HUNTER_TRIGGERED\x10i*\xe8\x06\n" +
 	"\tErrorCode\x12\x1a\n" +
 	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x1f\n" +
 	"\x1bERROR_CODE_PLAYER_NOT_FOUND\x10\x01\x12\x1a\n" +
@@ -612,7 +1783,30 @@ const file_proto_event_proto_rawDesc = "" +
 	"\x1bERROR_CODE_GAME_NOT_STARTED\x10\x06\x12\x19\n" +
 	"\x15ERROR_CODE_GAME_ENDED\x10\a\x12\x1c\n" +
 	"\x18ERROR_CODE_INVALID_PHASE\x10\b\x12\"\n" +
-	"\x1eERROR_CODE_MESSAGE_NOT_ALLOWED\x10\tB#Z!github.com/Zereker/werewolf/protob\x06proto3"
+	"\x1eERROR_CODE_MESSAGE_NOT_ALLOWED\x10\t\x12\x1d\n" +
+	"\x19ERROR_CODE_GAME_NOT_FOUND\x10\n" +
+	"\x12\x1d\n" +
+	"\x19ERROR_CODE_TOO_MANY_GAMES\x10\v\x12\x1c\n" +
+	"\x18ERROR_CODE_SHUTTING_DOWN\x10\f\x12\x1c\n" +
+	"\x18ERROR_CODE_PLAYER_EXISTS\x10\r\x12\x19\n" +
+	"\x15ERROR_CODE_LOBBY_FULL\x10\x0e\x12\x17\n" +
+	"\x13ERROR_CODE_NOT_HOST\x10\x0f\x12 \n" +
+	"\x1cERROR_CODE_PLAYERS_NOT_READY\x10\x10\x12\x1c\n" +
+	"\x18ERROR_CODE_SEAT_MISMATCH\x10\x11\x12\x1b\n" +
+	"\x17ERROR_CODE_GAME_STARTED\x10\x12\x12\x1d\n" +
+	"\x19ERROR_CODE_INVALID_CONFIG\x10\x13\x12\"\n" +
+	"\x1eERROR_CODE_SUBSCRIBER_OVERFLOW\x10\x14\x12\"\n" +
+	"\x1eERROR_CODE_DUPLICATE_SKILL_USE\x10\x15\x12#\n" +
+	"\x1fERROR_CODE_NO_PENDING_SKILL_USE\x10\x16\x12\x1b\n" +
+	"\x17ERROR_CODE_GUARD_REPEAT\x10\x17\x12\x1a\n" +
+	"\x16ERROR_CODE_NO_ANTIDOTE\x10\x18\x12\x18\n" +
+	"\x14ERROR_CODE_NO_POISON\x10\x19\x12\x1f\n" +
+	"\x1bERROR_CODE_TARGET_NOT_DYING\x10\x1a\x12\x1a\n" +
+	"\x16ERROR_CODE_SELF_TARGET\x10\x1b*z\n" +
+	"\rVictoryReason\x12\x1e\n" +
+	"\x1aVICTORY_REASON_UNSPECIFIED\x10\x00\x12$\n" +
+	" VICTORY_REASON_WOLVES_ELIMINATED\x10\x01\x12#\n" +
+	"\x1fVICTORY_REASON_WOLVES_OUTNUMBER\x10\x02B#Z!github.com/Zereker/werewolf/protob\x06proto3"
 
 var (
 	file_proto_event_proto_rawDescOnce sync.Once
@@ -626,26 +1820,64 @@ func file_proto_event_proto_rawDescGZIP() []byte {
 	return file_proto_event_proto_rawDescData
 }
 
-var file_proto_event_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_proto_event_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_event_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_proto_event_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_event_proto_goTypes = []any{
-	(PhaseType)(0), // 0: werewolf.PhaseType
-	(Camp)(0),      // 1: werewolf.Camp
-	(RoleType)(0),  // 2: werewolf.RoleType
-	(SkillType)(0), // 3: werewolf.SkillType
-	(EventType)(0), // 4: werewolf.EventType
-	(ErrorCode)(0), // 5: werewolf.ErrorCode
-	(*Event)(nil),  // 6: werewolf.Event
-	nil,            // 7: werewolf.Event.DataEntry
+	(PhaseType)(0),                // 0: werewolf.PhaseType
+	(Camp)(0),                     // 1: werewolf.Camp
+	(RoleType)(0),                 // 2: werewolf.RoleType
+	(SkillType)(0),                // 3: werewolf.SkillType
+	(EventType)(0),                // 4: werewolf.EventType
+	(ErrorCode)(0),                // 5: werewolf.ErrorCode
+	(VictoryReason)(0),            // 6: werewolf.VictoryReason
+	(*ErrorDetail)(nil),           // 7: werewolf.ErrorDetail
+	(*Event)(nil),                 // 8: werewolf.Event
+	(*KillEvent)(nil),             // 9: werewolf.KillEvent
+	(*CheckEvent)(nil),            // 10: werewolf.CheckEvent
+	(*VoteTally)(nil),             // 11: werewolf.VoteTally
+	(*VoteResultEvent)(nil),       // 12: werewolf.VoteResultEvent
+	(*GameEndedEvent)(nil),        // 13: werewolf.GameEndedEvent
+	(*GameStartedEvent)(nil),      // 14: werewolf.GameStartedEvent
+	(*PhaseEvent)(nil),            // 15: werewolf.PhaseEvent
+	(*RoundStartedEvent)(nil),     // 16: werewolf.RoundStartedEvent
+	(*NightSummaryEvent)(nil),     // 17: werewolf.NightSummaryEvent
+	(*WolfTallyEvent)(nil),        // 18: werewolf.WolfTallyEvent
+	(*WolfPick)(nil),              // 19: werewolf.WolfPick
+	nil,                           // 20: werewolf.Event.DataEntry
+	(*timestamppb.Timestamp)(nil), // 21: google.protobuf.Timestamp
 }
 var file_proto_event_proto_depIdxs = []int32{
-	4, // 0: werewolf.Event.type:type_name -> werewolf.EventType
-	7, // 1: werewolf.Event.data:type_name -> werewolf.Event.DataEntry
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	5,  // 0: werewolf.ErrorDetail.code:type_name -> werewolf.ErrorCode
+	3,  // 1: werewolf.ErrorDetail.skill:type_name -> werewolf.SkillType
+	0,  // 2: werewolf.ErrorDetail.phase:type_name -> werewolf.PhaseType
+	4,  // 3: werewolf.Event.type:type_name -> werewolf.EventType
+	20, // 4: werewolf.Event.data:type_name -> werewolf.Event.DataEntry
+	9,  // 5: werewolf.Event.kill:type_name -> werewolf.KillEvent
+	10, // 6: werewolf.Event.check:type_name -> werewolf.CheckEvent
+	12, // 7: werewolf.Event.vote_result:type_name -> werewolf.VoteResultEvent
+	13, // 8: werewolf.Event.game_ended:type_name -> werewolf.GameEndedEvent
+	14, // 9: werewolf.Event.game_started:type_name -> werewolf.GameStartedEvent
+	15, // 10: werewolf.Event.phase:type_name -> werewolf.PhaseEvent
+	16, // 11: werewolf.Event.round_started:type_name -> werewolf.RoundStartedEvent
+	17, // 12: werewolf.Event.night_summary:type_name -> werewolf.NightSummaryEvent
+	18, // 13: werewolf.Event.wolf_tally:type_name -> werewolf.WolfTallyEvent
+	0,  // 14: werewolf.Event.phase_type:type_name -> werewolf.PhaseType
+	4,  // 15: werewolf.KillEvent.cause:type_name -> werewolf.EventType
+	1,  // 16: werewolf.CheckEvent.camp:type_name -> werewolf.Camp
+	11, // 17: werewolf.VoteResultEvent.tallies:type_name -> werewolf.VoteTally
+	1,  // 18: werewolf.GameEndedEvent.winner:type_name -> werewolf.Camp
+	6,  // 19: werewolf.GameEndedEvent.reason:type_name -> werewolf.VictoryReason
+	2,  // 20: werewolf.GameStartedEvent.board:type_name -> werewolf.RoleType
+	0,  // 21: werewolf.PhaseEvent.phase:type_name -> werewolf.PhaseType
+	21, // 22: werewolf.PhaseEvent.deadline:type_name -> google.protobuf.Timestamp
+	0,  // 23: werewolf.PhaseEvent.next_phase:type_name -> werewolf.PhaseType
+	19, // 24: werewolf.WolfTallyEvent.picks:type_name -> werewolf.WolfPick
+	11, // 25: werewolf.WolfTallyEvent.tallies:type_name -> werewolf.VoteTally
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_proto_event_proto_init() }
@@ -653,13 +1885,24 @@ func file_proto_event_proto_init() {
 	if File_proto_event_proto != nil {
 		return
 	}
+	file_proto_event_proto_msgTypes[1].OneofWrappers = []any{
+		(*Event_Kill)(nil),
+		(*Event_Check)(nil),
+		(*Event_VoteResult)(nil),
+		(*Event_GameEnded)(nil),
+		(*Event_GameStarted)(nil),
+		(*Event_Phase)(nil),
+		(*Event_RoundStarted)(nil),
+		(*Event_NightSummary)(nil),
+		(*Event_WolfTally)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_event_proto_rawDesc), len(file_proto_event_proto_rawDesc)),
-			NumEnums:      6,
-			NumMessages:   2,
+			NumEnums:      7,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,1888 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.0
+// source: proto/servicepb/game_service.proto
+
+package servicepb
+
+import (
+	proto "github.com/Zereker/werewolf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GameRules 规则变体（未设置时使用默认配置）
+type GameRules struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	WitchCanSaveSelf     bool                   `protobuf:"varint,1,opt,name=witch_can_save_self,json=witchCanSaveSelf,proto3" json:"witch_can_save_self,omitempty"`
+	GuardCanProtectSelf  bool                   `protobuf:"varint,2,opt,name=guard_can_protect_self,json=guardCanProtectSelf,proto3" json:"guard_can_protect_self,omitempty"`
+	GuardCanRepeat       bool                   `protobuf:"varint,3,opt,name=guard_can_repeat,json=guardCanRepeat,proto3" json:"guard_can_repeat,omitempty"`
+	SameGuardKillIsEmpty bool                   `protobuf:"varint,4,opt,name=same_guard_kill_is_empty,json=sameGuardKillIsEmpty,proto3" json:"same_guard_kill_is_empty,omitempty"`
+	WolfKillUnanimous    bool                   `protobuf:"varint,5,opt,name=wolf_kill_unanimous,json=wolfKillUnanimous,proto3" json:"wolf_kill_unanimous,omitempty"`
+	WolfTiePolicy        string                 `protobuf:"bytes,6,opt,name=wolf_tie_policy,json=wolfTiePolicy,proto3" json:"wolf_tie_policy,omitempty"` // 狼人刀人平票：no_kill（默认）、random、lead_wolf
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GameRules) Reset() {
+	*x = GameRules{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameRules) ProtoMessage() {}
+
+func (x *GameRules) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameRules.ProtoReflect.Descriptor instead.
+func (*GameRules) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GameRules) GetWitchCanSaveSelf() bool {
+	if x != nil {
+		return x.WitchCanSaveSelf
+	}
+	return false
+}
+
+func (x *GameRules) GetGuardCanProtectSelf() bool {
+	if x != nil {
+		return x.GuardCanProtectSelf
+	}
+	return false
+}
+
+func (x *GameRules) GetGuardCanRepeat() bool {
+	if x != nil {
+		return x.GuardCanRepeat
+	}
+	return false
+}
+
+func (x *GameRules) GetSameGuardKillIsEmpty() bool {
+	if x != nil {
+		return x.SameGuardKillIsEmpty
+	}
+	return false
+}
+
+func (x *GameRules) GetWolfKillUnanimous() bool {
+	if x != nil {
+		return x.WolfKillUnanimous
+	}
+	return false
+}
+
+func (x *GameRules) GetWolfTiePolicy() string {
+	if x != nil {
+		return x.WolfTiePolicy
+	}
+	return ""
+}
+
+type CreateGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []proto.RoleType       `protobuf:"varint,1,rep,packed,name=roles,proto3,enum=werewolf.RoleType" json:"roles,omitempty"` // 板子：每个座位一个角色（可为空，由房主稍后选择）
+	Rules         *GameRules             `protobuf:"bytes,2,opt,name=rules,proto3" json:"rules,omitempty"`
+	Board         string                 `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"` // 预设板子名称（roles 为空时使用）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameRequest) Reset() {
+	*x = CreateGameRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameRequest) ProtoMessage() {}
+
+func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
+func (*CreateGameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateGameRequest) GetRoles() []proto.RoleType {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *CreateGameRequest) GetRules() *GameRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *CreateGameRequest) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+type CreateGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameResponse) Reset() {
+	*x = CreateGameResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameResponse) ProtoMessage() {}
+
+func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameResponse.ProtoReflect.Descriptor instead.
+func (*CreateGameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateGameResponse) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+type JoinGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinGameRequest) Reset() {
+	*x = JoinGameRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGameRequest) ProtoMessage() {}
+
+func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGameRequest.ProtoReflect.Descriptor instead.
+func (*JoinGameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JoinGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *JoinGameRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type JoinGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"`  // 座位号（从 1 开始）
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // 座位令牌，之后以该玩家身份的请求都要携带
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinGameResponse) Reset() {
+	*x = JoinGameResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGameResponse) ProtoMessage() {}
+
+func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGameResponse.ProtoReflect.Descriptor instead.
+func (*JoinGameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *JoinGameResponse) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *JoinGameResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type LeaveGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveGameRequest) Reset() {
+	*x = LeaveGameRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveGameRequest) ProtoMessage() {}
+
+func (x *LeaveGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveGameRequest.ProtoReflect.Descriptor instead.
+func (*LeaveGameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LeaveGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *LeaveGameRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *LeaveGameRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type LeaveGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveGameResponse) Reset() {
+	*x = LeaveGameResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveGameResponse) ProtoMessage() {}
+
+func (x *LeaveGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveGameResponse.ProtoReflect.Descriptor instead.
+func (*LeaveGameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{6}
+}
+
+type SetReadyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Ready         bool                   `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReadyRequest) Reset() {
+	*x = SetReadyRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReadyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReadyRequest) ProtoMessage() {}
+
+func (x *SetReadyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReadyRequest.ProtoReflect.Descriptor instead.
+func (*SetReadyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetReadyRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *SetReadyRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *SetReadyRequest) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *SetReadyRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SetReadyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReadyResponse) Reset() {
+	*x = SetReadyResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReadyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReadyResponse) ProtoMessage() {}
+
+func (x *SetReadyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReadyResponse.ProtoReflect.Descriptor instead.
+func (*SetReadyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{8}
+}
+
+type SetBoardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	HostId        string                 `protobuf:"bytes,2,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	Roles         []proto.RoleType       `protobuf:"varint,3,rep,packed,name=roles,proto3,enum=werewolf.RoleType" json:"roles,omitempty"` // 自定义板子
+	Board         string                 `protobuf:"bytes,4,opt,name=board,proto3" json:"board,omitempty"`                                // 预设板子名称（roles 为空时使用）
+	Token         string                 `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`                                // 房主的座位令牌
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBoardRequest) Reset() {
+	*x = SetBoardRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBoardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBoardRequest) ProtoMessage() {}
+
+func (x *SetBoardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBoardRequest.ProtoReflect.Descriptor instead.
+func (*SetBoardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetBoardRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *SetBoardRequest) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *SetBoardRequest) GetRoles() []proto.RoleType {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *SetBoardRequest) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *SetBoardRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SetBoardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBoardResponse) Reset() {
+	*x = SetBoardResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBoardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBoardResponse) ProtoMessage() {}
+
+func (x *SetBoardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBoardResponse.ProtoReflect.Descriptor instead.
+func (*SetBoardResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{10}
+}
+
+type SetRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	HostId        string                 `protobuf:"bytes,2,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	Rules         *GameRules             `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"` // 房主的座位令牌
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRulesRequest) Reset() {
+	*x = SetRulesRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRulesRequest) ProtoMessage() {}
+
+func (x *SetRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRulesRequest.ProtoReflect.Descriptor instead.
+func (*SetRulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetRulesRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *SetRulesRequest) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *SetRulesRequest) GetRules() *GameRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *SetRulesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SetRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRulesResponse) Reset() {
+	*x = SetRulesResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRulesResponse) ProtoMessage() {}
+
+func (x *SetRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRulesResponse.ProtoReflect.Descriptor instead.
+func (*SetRulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{12}
+}
+
+type GetLobbyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLobbyRequest) Reset() {
+	*x = GetLobbyRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLobbyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLobbyRequest) ProtoMessage() {}
+
+func (x *GetLobbyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLobbyRequest.ProtoReflect.Descriptor instead.
+func (*GetLobbyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetLobbyRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+// Seat 座位
+type Seat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Ready         bool                   `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Seat) Reset() {
+	*x = Seat{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Seat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Seat) ProtoMessage() {}
+
+func (x *Seat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Seat.ProtoReflect.Descriptor instead.
+func (*Seat) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Seat) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Seat) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Seat) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+type GetLobbyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HostId        string                 `protobuf:"bytes,1,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	Board         string                 `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`                                // 板子名称
+	Roles         []proto.RoleType       `protobuf:"varint,3,rep,packed,name=roles,proto3,enum=werewolf.RoleType" json:"roles,omitempty"` // 板子角色
+	Rules         *GameRules             `protobuf:"bytes,4,opt,name=rules,proto3" json:"rules,omitempty"`
+	Seats         []*Seat                `protobuf:"bytes,5,rep,name=seats,proto3" json:"seats,omitempty"`
+	Started       bool                   `protobuf:"varint,6,opt,name=started,proto3" json:"started,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLobbyResponse) Reset() {
+	*x = GetLobbyResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLobbyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLobbyResponse) ProtoMessage() {}
+
+func (x *GetLobbyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLobbyResponse.ProtoReflect.Descriptor instead.
+func (*GetLobbyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetLobbyResponse) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *GetLobbyResponse) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *GetLobbyResponse) GetRoles() []proto.RoleType {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *GetLobbyResponse) GetRules() *GameRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *GetLobbyResponse) GetSeats() []*Seat {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *GetLobbyResponse) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+type StartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	HostId        string                 `protobuf:"bytes,2,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"` // 房主的座位令牌
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartRequest) Reset() {
+	*x = StartRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartRequest) ProtoMessage() {}
+
+func (x *StartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartRequest.ProtoReflect.Descriptor instead.
+func (*StartRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StartRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *StartRequest) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *StartRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type StartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         proto.PhaseType        `protobuf:"varint,1,opt,name=phase,proto3,enum=werewolf.PhaseType" json:"phase,omitempty"`
+	Round         int32                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartResponse) Reset() {
+	*x = StartResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartResponse) ProtoMessage() {}
+
+func (x *StartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartResponse.ProtoReflect.Descriptor instead.
+func (*StartResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StartResponse) GetPhase() proto.PhaseType {
+	if x != nil {
+		return x.Phase
+	}
+	return proto.PhaseType(0)
+}
+
+func (x *StartResponse) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+type SubmitSkillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Skill         proto.SkillType        `protobuf:"varint,3,opt,name=skill,proto3,enum=werewolf.SkillType" json:"skill,omitempty"`
+	TargetId      string                 `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Token         string                 `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitSkillRequest) Reset() {
+	*x = SubmitSkillRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitSkillRequest) ProtoMessage() {}
+
+func (x *SubmitSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitSkillRequest.ProtoReflect.Descriptor instead.
+func (*SubmitSkillRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SubmitSkillRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *SubmitSkillRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *SubmitSkillRequest) GetSkill() proto.SkillType {
+	if x != nil {
+		return x.Skill
+	}
+	return proto.SkillType(0)
+}
+
+func (x *SubmitSkillRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *SubmitSkillRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SubmitSkillRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SubmitSkillResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitSkillResponse) Reset() {
+	*x = SubmitSkillResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitSkillResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitSkillResponse) ProtoMessage() {}
+
+func (x *SubmitSkillResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitSkillResponse.ProtoReflect.Descriptor instead.
+func (*SubmitSkillResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{19}
+}
+
+type SendMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SendMessageRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SendMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageResponse) Reset() {
+	*x = SendMessageResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageResponse) ProtoMessage() {}
+
+func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendMessageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{21}
+}
+
+type AdvancePhaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	HostId        string                 `protobuf:"bytes,2,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"` // 房主的座位令牌
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdvancePhaseRequest) Reset() {
+	*x = AdvancePhaseRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdvancePhaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvancePhaseRequest) ProtoMessage() {}
+
+func (x *AdvancePhaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvancePhaseRequest.ProtoReflect.Descriptor instead.
+func (*AdvancePhaseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AdvancePhaseRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *AdvancePhaseRequest) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *AdvancePhaseRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type AdvancePhaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         proto.PhaseType        `protobuf:"varint,1,opt,name=phase,proto3,enum=werewolf.PhaseType" json:"phase,omitempty"`
+	Round         int32                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	GameOver      bool                   `protobuf:"varint,3,opt,name=game_over,json=gameOver,proto3" json:"game_over,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdvancePhaseResponse) Reset() {
+	*x = AdvancePhaseResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdvancePhaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvancePhaseResponse) ProtoMessage() {}
+
+func (x *AdvancePhaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvancePhaseResponse.ProtoReflect.Descriptor instead.
+func (*AdvancePhaseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AdvancePhaseResponse) GetPhase() proto.PhaseType {
+	if x != nil {
+		return x.Phase
+	}
+	return proto.PhaseType(0)
+}
+
+func (x *AdvancePhaseResponse) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *AdvancePhaseResponse) GetGameOver() bool {
+	if x != nil {
+		return x.GameOver
+	}
+	return false
+}
+
+type GetViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetViewRequest) Reset() {
+	*x = GetViewRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetViewRequest) ProtoMessage() {}
+
+func (x *GetViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetViewRequest.ProtoReflect.Descriptor instead.
+func (*GetViewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetViewRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *GetViewRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetViewRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	View          *proto.PlayerView      `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetViewResponse) Reset() {
+	*x = GetViewResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetViewResponse) ProtoMessage() {}
+
+func (x *GetViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetViewResponse.ProtoReflect.Descriptor instead.
+func (*GetViewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetViewResponse) GetView() *proto.PlayerView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StreamEventsRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// StreamEventsResponse 流式推送项
+// 订阅成功后首条推送为当前玩家视角，之后为该玩家可见的事件和消息
+type StreamEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Item:
+	//
+	//	*StreamEventsResponse_Event
+	//	*StreamEventsResponse_Message
+	//	*StreamEventsResponse_View
+	Item          isStreamEventsResponse_Item `protobuf_oneof:"item"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsResponse) Reset() {
+	*x = StreamEventsResponse{}
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsResponse) ProtoMessage() {}
+
+func (x *StreamEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_servicepb_game_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsResponse.ProtoReflect.Descriptor instead.
+func (*StreamEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_servicepb_game_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StreamEventsResponse) GetItem() isStreamEventsResponse_Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *StreamEventsResponse) GetEvent() *proto.Event {
+	if x != nil {
+		if x, ok := x.Item.(*StreamEventsResponse_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *StreamEventsResponse) GetMessage() *proto.ChatMessage {
+	if x != nil {
+		if x, ok := x.Item.(*StreamEventsResponse_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *StreamEventsResponse) GetView() *proto.PlayerView {
+	if x != nil {
+		if x, ok := x.Item.(*StreamEventsResponse_View); ok {
+			return x.View
+		}
+	}
+	return nil
+}
+
+type isStreamEventsResponse_Item interface {
+	isStreamEventsResponse_Item()
+}
+
+type StreamEventsResponse_Event struct {
+	Event *proto.Event `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type StreamEventsResponse_Message struct {
+	Message *proto.ChatMessage `protobuf:"bytes,2,opt,name=message,proto3,oneof"`
+}
+
+type StreamEventsResponse_View struct {
+	View *proto.PlayerView `protobuf:"bytes,3,opt,name=view,proto3,oneof"`
+}
+
+func (*StreamEventsResponse_Event) isStreamEventsResponse_Item() {}
+
+func (*StreamEventsResponse_Message) isStreamEventsResponse_Item() {}
+
+func (*StreamEventsResponse_View) isStreamEventsResponse_Item() {}
+
+var File_proto_servicepb_game_service_proto protoreflect.FileDescriptor
+
+const file_proto_servicepb_game_service_proto_rawDesc = "" +
+	"\n" +
+	"\"proto/servicepb/game_service.proto\x12\x10werewolf.service\x1a\x11proto/event.proto\x1a\x10proto/view.proto\"\xa9\x02\n" +
+	"\tGameRules\x12-\n" +
+	"\x13witch_can_save_self\x18\x01 \x01(\bR\x10witchCanSaveSelf\x123\n" +
+	"\x16guard_can_protect_self\x18\x02 \x01(\bR\x13guardCanProtectSelf\x12(\n" +
+	"\x10guard_can_repeat\x18\x03 \x01(\bR\x0eguardCanRepeat\x126\n" +
+	"\x18same_guard_kill_is_empty\x18\x04 \x01(\bR\x14sameGuardKillIsEmpty\x12.\n" +
+	"\x13wolf_kill_unanimous\x18\x05 \x01(\bR\x11wolfKillUnanimous\x12&\n" +
+	"\x0fwolf_tie_policy\x18\x06 \x01(\tR\rwolfTiePolicy\"\x86\x01\n" +
+	"\x11CreateGameRequest\x12(\n" +
+	"\x05roles\x18\x01 \x03(\x0e2\x12.werewolf.RoleTypeR\x05roles\x121\n" +
+	"\x05rules\x18\x02 \x01(\v2\x1b.werewolf.service.GameRulesR\x05rules\x12\x14\n" +
+	"\x05board\x18\x03 \x01(\tR\x05board\"-\n" +
+	"\x12CreateGameResponse\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"G\n" +
+	"\x0fJoinGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"<\n" +
+	"\x10JoinGameResponse\x12\x12\n" +
+	"\x04seat\x18\x01 \x01(\x05R\x04seat\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"^\n" +
+	"\x10LeaveGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"\x13\n" +
+	"\x11LeaveGameResponse\"s\n" +
+	"\x0fSetReadyRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05ready\x18\x03 \x01(\bR\x05ready\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\"\x12\n" +
+	"\x10SetReadyResponse\"\x99\x01\n" +
+	"\x0fSetBoardRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x17\n" +
+	"\ahost_id\x18\x02 \x01(\tR\x06hostId\x12(\n" +
+	"\x05roles\x18\x03 \x03(\x0e2\x12.werewolf.RoleTypeR\x05roles\x12\x14\n" +
+	"\x05board\x18\x04 \x01(\tR\x05board\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\"\x12\n" +
+	"\x10SetBoardResponse\"\x8c\x01\n" +
+	"\x0fSetRulesRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x17\n" +
+	"\ahost_id\x18\x02 \x01(\tR\x06hostId\x121\n" +
+	"\x05rules\x18\x03 \x01(\v2\x1b.werewolf.service.GameRulesR\x05rules\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\"\x12\n" +
+	"\x10SetRulesResponse\"*\n" +
+	"\x0fGetLobbyRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"Q\n" +
+	"\x04Seat\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05ready\x18\x03 \x01(\bR\x05ready\"\xe6\x01\n" +
+	"\x10GetLobbyResponse\x12\x17\n" +
+	"\ahost_id\x18\x01 \x01(\tR\x06hostId\x12\x14\n" +
+	"\x05board\x18\x02 \x01(\tR\x05board\x12(\n" +
+	"\x05roles\x18\x03 \x03(\x0e2\x12.werewolf.RoleTypeR\x05roles\x121\n" +
+	"\x05rules\x18\x04 \x01(\v2\x1b.werewolf.service.GameRulesR\x05rules\x12,\n" +
+	"\x05seats\x18\x05 \x03(\v2\x16.werewolf.service.SeatR\x05seats\x12\x18\n" +
+	"\astarted\x18\x06 \x01(\bR\astarted\"V\n" +
+	"\fStartRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x17\n" +
+	"\ahost_id\x18\x02 \x01(\tR\x06hostId\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"P\n" +
+	"\rStartResponse\x12)\n" +
+	"\x05phase\x18\x01 \x01(\x0e2\x13.werewolf.PhaseTypeR\x05phase\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x05R\x05round\"\xc2\x01\n" +
+	"\x12SubmitSkillRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12)\n" +
+	"\x05skill\x18\x03 \x01(\x0e2\x13.werewolf.SkillTypeR\x05skill\x12\x1b\n" +
+	"\ttarget_id\x18\x04 \x01(\tR\btargetId\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x12\x14\n" +
+	"\x05token\x18\x06 \x01(\tR\x05token\"\x15\n" +
+	"\x13SubmitSkillResponse\"z\n" +
+	"\x12SendMessageRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\"\x15\n" +
+	"\x13SendMessageResponse\"]\n" +
+	"\x13AdvancePhaseRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x17\n" +
+	"\ahost_id\x18\x02 \x01(\tR\x06hostId\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"t\n" +
+	"\x14AdvancePhaseResponse\x12)\n" +
+	"\x05phase\x18\x01 \x01(\x0e2\x13.werewolf.PhaseTypeR\x05phase\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x05R\x05round\x12\x1b\n" +
+	"\tgame_over\x18\x03 \x01(\bR\bgameOver\"\\\n" +
+	"\x0eGetViewRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\";\n" +
+	"\x0fGetViewResponse\x12(\n" +
+	"\x04view\x18\x01 \x01(\v2\x14.werewolf.PlayerViewR\x04view\"a\n" +
+	"\x13StreamEventsRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"\xa6\x01\n" +
+	"\x14StreamEventsResponse\x12'\n" +
+	"\x05event\x18\x01 \x01(\v2\x0f.werewolf.EventH\x00R\x05event\x121\n" +
+	"\amessage\x18\x02 \x01(\v2\x15.werewolf.ChatMessageH\x00R\amessage\x12*\n" +
+	"\x04view\x18\x03 \x01(\v2\x14.werewolf.PlayerViewH\x00R\x04viewB\x06\n" +
+	"\x04item2\xed\b\n" +
+	"\vGameService\x12W\n" +
+	"\n" +
+	"CreateGame\x12#.werewolf.service.CreateGameRequest\x1a$.werewolf.service.CreateGameResponse\x12Q\n" +
+	"\bJoinGame\x12!.werewolf.service.JoinGameRequest\x1a\".werewolf.service.JoinGameResponse\x12T\n" +
+	"\tLeaveGame\x12\".werewolf.service.LeaveGameRequest\x1a#.werewolf.service.LeaveGameResponse\x12Q\n" +
+	"\bSetReady\x12!.werewolf.service.SetReadyRequest\x1a\".werewolf.service.SetReadyResponse\x12Q\n" +
+	"\bSetBoard\x12!.werewolf.service.SetBoardRequest\x1a\".werewolf.service.SetBoardResponse\x12Q\n" +
+	"\bSetRules\x12!.werewolf.service.SetRulesRequest\x1a\".werewolf.service.SetRulesResponse\x12Q\n" +
+	"\bGetLobby\x12!.werewolf.service.GetLobbyRequest\x1a\".werewolf.service.GetLobbyResponse\x12H\n" +
+	"\x05Start\x12\x1e.werewolf.service.StartRequest\x1a\x1f.werewolf.service.StartResponse\x12Z\n" +
+	"\vSubmitSkill\x12$.werewolf.service.SubmitSkillRequest\x1a%.werewolf.service.SubmitSkillResponse\x12Z\n" +
+	"\vSendMessage\x12$.werewolf.service.SendMessageRequest\x1a%.werewolf.service.SendMessageResponse\x12]\n" +
+	"\fAdvancePhase\x12%.werewolf.service.AdvancePhaseRequest\x1a&.werewolf.service.AdvancePhaseResponse\x12N\n" +
+	"\aGetView\x12 .werewolf.service.GetViewRequest\x1a!.werewolf.service.GetViewResponse\x12_\n" +
+	"\fStreamEvents\x12%.werewolf.service.StreamEventsRequest\x1a&.werewolf.service.StreamEventsResponse0\x01B-Z+github.com/Zereker/werewolf/proto/servicepbb\x06proto3"
+
+var (
+	file_proto_servicepb_game_service_proto_rawDescOnce sync.Once
+	file_proto_servicepb_game_service_proto_rawDescData []byte
+)
+
+func file_proto_servicepb_game_service_proto_rawDescGZIP() []byte {
+	file_proto_servicepb_game_service_proto_rawDescOnce.Do(func() {
+		file_proto_servicepb_game_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_servicepb_game_service_proto_rawDesc), len(file_proto_servicepb_game_service_proto_rawDesc)))
+	})
+	return file_proto_servicepb_game_service_proto_rawDescData
+}
+
+var file_proto_servicepb_game_service_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_proto_servicepb_game_service_proto_goTypes = []any{
+	(*GameRules)(nil),            // 0: werewolf.service.GameRules
+	(*CreateGameRequest)(nil),    // 1: werewolf.service.CreateGameRequest
+	(*CreateGameResponse)(nil),   // 2: werewolf.service.CreateGameResponse
+	(*JoinGameRequest)(nil),      // 3: werewolf.service.JoinGameRequest
+	(*JoinGameResponse)(nil),     // 4: werewolf.service.JoinGameResponse
+	(*LeaveGameRequest)(nil),     // 5: werewolf.service.LeaveGameRequest
+	(*LeaveGameResponse)(nil),    // 6: werewolf.service.LeaveGameResponse
+	(*SetReadyRequest)(nil),      // 7: werewolf.service.SetReadyRequest
+	(*SetReadyResponse)(nil),     // 8: werewolf.service.SetReadyResponse
+	(*SetBoardRequest)(nil),      // 9: werewolf.service.SetBoardRequest
+	(*SetBoardResponse)(nil),     // 10: werewolf.service.SetBoardResponse
+	(*SetRulesRequest)(nil),      // 11: werewolf.service.SetRulesRequest
+	(*SetRulesResponse)(nil),     // 12: werewolf.service.SetRulesResponse
+	(*GetLobbyRequest)(nil),      // 13: werewolf.service.GetLobbyRequest
+	(*Seat)(nil),                 // 14: werewolf.service.Seat
+	(*GetLobbyResponse)(nil),     // 15: werewolf.service.GetLobbyResponse
+	(*StartRequest)(nil),         // 16: werewolf.service.StartRequest
+	(*StartResponse)(nil),        // 17: werewolf.service.StartResponse
+	(*SubmitSkillRequest)(nil),   // 18: werewolf.service.SubmitSkillRequest
+	(*SubmitSkillResponse)(nil),  // 19: werewolf.service.SubmitSkillResponse
+	(*SendMessageRequest)(nil),   // 20: werewolf.service.SendMessageRequest
+	(*SendMessageResponse)(nil),  // 21: werewolf.service.SendMessageResponse
+	(*AdvancePhaseRequest)(nil),  // 22: werewolf.service.AdvancePhaseRequest
+	(*AdvancePhaseResponse)(nil), // 23: werewolf.service.AdvancePhaseResponse
+	(*GetViewRequest)(nil),       // 24: werewolf.service.GetViewRequest
+	(*GetViewResponse)(nil),      // 25: werewolf.service.GetViewResponse
+	(*StreamEventsRequest)(nil),  // 26: werewolf.service.StreamEventsRequest
+	(*StreamEventsResponse)(nil), // 27: werewolf.service.StreamEventsResponse
+	(proto.RoleType)(0),          // 28: werewolf.RoleType
+	(proto.PhaseType)(0),         // 29: werewolf.PhaseType
+	(proto.SkillType)(0),         // 30: werewolf.SkillType
+	(*proto.PlayerView)(nil),     // 31: werewolf.PlayerView
+	(*proto.Event)(nil),          // 32: werewolf.Event
+	(*proto.ChatMessage)(nil),    // 33: werewolf.ChatMessage
+}
+var file_proto_servicepb_game_service_proto_depIdxs = []int32{
+	28, // 0: werewolf.service.CreateGameRequest.roles:type_name -> werewolf.RoleType
+	0,  // 1: werewolf.service.CreateGameRequest.rules:type_name -> werewolf.service.GameRules
+	28, // 2: werewolf.service.SetBoardRequest.roles:type_name -> werewolf.RoleType
+	0,  // 3: werewolf.service.SetRulesRequest.rules:type_name -> werewolf.service.GameRules
+	28, // 4: werewolf.service.GetLobbyResponse.roles:type_name -> werewolf.RoleType
+	0,  // 5: werewolf.service.GetLobbyResponse.rules:type_name -> werewolf.service.GameRules
+	14, // 6: werewolf.service.GetLobbyResponse.seats:type_name -> werewolf.service.Seat
+	29, // 7: werewolf.service.StartResponse.phase:type_name -> werewolf.PhaseType
+	30, // 8: werewolf.service.SubmitSkillRequest.skill:type_name -> werewolf.SkillType
+	29, // 9: werewolf.service.AdvancePhaseResponse.phase:type_name -> werewolf.PhaseType
+	31, // 10: werewolf.service.GetViewResponse.view:type_name -> werewolf.PlayerView
+	32, // 11: werewolf.service.StreamEventsResponse.event:type_name -> werewolf.Event
+	33, // 12: werewolf.service.StreamEventsResponse.message:type_name -> werewolf.ChatMessage
+	31, // 13: werewolf.service.StreamEventsResponse.view:type_name -> werewolf.PlayerView
+	1,  // 14: werewolf.service.GameService.CreateGame:input_type -> werewolf.service.CreateGameRequest
+	3,  // 15: werewolf.service.GameService.JoinGame:input_type -> werewolf.service.JoinGameRequest
+	5,  // 16: werewolf.service.GameService.LeaveGame:input_type -> werewolf.service.LeaveGameRequest
+	7,  // 17: werewolf.service.GameService.SetReady:input_type -> werewolf.service.SetReadyRequest
+	9,  // 18: werewolf.service.GameService.SetBoard:input_type -> werewolf.service.SetBoardRequest
+	11, // 19: werewolf.service.GameService.SetRules:input_type -> werewolf.service.SetRulesRequest
+	13, // 20: werewolf.service.GameService.GetLobby:input_type -> werewolf.service.GetLobbyRequest
+	16, // 21: werewolf.service.GameService.Start:input_type -> werewolf.service.StartRequest
+	18, // 22: werewolf.service.GameService.SubmitSkill:input_type -> werewolf.service.SubmitSkillRequest
+	20, // 23: werewolf.service.GameService.SendMessage:input_type -> werewolf.service.SendMessageRequest
+	22, // 24: werewolf.service.GameService.AdvancePhase:input_type -> werewolf.service.AdvancePhaseRequest
+	24, // 25: werewolf.service.GameService.GetView:input_type -> werewolf.service.GetViewRequest
+	26, // 26: werewolf.service.GameService.StreamEvents:input_type -> werewolf.service.StreamEventsRequest
+	2,  // 27: werewolf.service.GameService.CreateGame:output_type -> werewolf.service.CreateGameResponse
+	4,  // 28: werewolf.service.GameService.JoinGame:output_type -> werewolf.service.JoinGameResponse
+	6,  // 29: werewolf.service.GameService.LeaveGame:output_type -> werewolf.service.LeaveGameResponse
+	8,  // 30: werewolf.service.GameService.SetReady:output_type -> werewolf.service.SetReadyResponse
+	10, // 31: werewolf.service.GameService.SetBoard:output_type -> werewolf.service.SetBoardResponse
+	12, // 32: werewolf.service.GameService.SetRules:output_type -> werewolf.service.SetRulesResponse
+	15, // 33: werewolf.service.GameService.GetLobby:output_type -> werewolf.service.GetLobbyResponse
+	17, // 34: werewolf.service.GameService.Start:output_type -> werewolf.service.StartResponse
+	19, // 35: werewolf.service.GameService.SubmitSkill:output_type -> werewolf.service.SubmitSkillResponse
+	21, // 36: werewolf.service.GameService.SendMessage:output_type -> werewolf.service.SendMessageResponse
+	23, // 37: werewolf.service.GameService.AdvancePhase:output_type -> werewolf.service.AdvancePhaseResponse
+	25, // 38: werewolf.service.GameService.GetView:output_type -> werewolf.service.GetViewResponse
+	27, // 39: werewolf.service.GameService.StreamEvents:output_type -> werewolf.service.StreamEventsResponse
+	27, // [27:40] is the sub-list for method output_type
+	14, // [14:27] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_proto_servicepb_game_service_proto_init() }
+func file_proto_servicepb_game_service_proto_init() {
+	if File_proto_servicepb_game_service_proto != nil {
+		return
+	}
+	file_proto_servicepb_game_service_proto_msgTypes[27].OneofWrappers = []any{
+		(*StreamEventsResponse_Event)(nil),
+		(*StreamEventsResponse_Message)(nil),
+		(*StreamEventsResponse_View)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_servicepb_game_service_proto_rawDesc), len(file_proto_servicepb_game_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   28,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_servicepb_game_service_proto_goTypes,
+		DependencyIndexes: file_proto_servicepb_game_service_proto_depIdxs,
+		MessageInfos:      file_proto_servicepb_game_service_proto_msgTypes,
+	}.Build()
+	File_proto_servicepb_game_service_proto = out.File
+	file_proto_servicepb_game_service_proto_goTypes = nil
+	file_proto_servicepb_game_service_proto_depIdxs = nil
+}
@@ -0,0 +1,624 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.0
+// source: proto/view.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// KnowledgeType 私有信息类型
+type KnowledgeType int32
+
+const (
+	KnowledgeType_KNOWLEDGE_TYPE_UNSPECIFIED   KnowledgeType = 0
+	KnowledgeType_KNOWLEDGE_TYPE_CHECK         KnowledgeType = 1 // 预言家查验结果（target_id、camp）
+	KnowledgeType_KNOWLEDGE_TYPE_NIGHT_KILL    KnowledgeType = 2 // 女巫得知的当晚被杀目标（target_id 为空表示无人被杀）
+	KnowledgeType_KNOWLEDGE_TYPE_PROTECT       KnowledgeType = 3 // 守卫的守护记录（target_id）
+	KnowledgeType_KNOWLEDGE_TYPE_GUN_TRIGGERED KnowledgeType = 4 // 猎人死亡，可以开枪
+	KnowledgeType_KNOWLEDGE_TYPE_GUN_FIRED     KnowledgeType = 5 // 猎人开枪（target_id）
+	KnowledgeType_KNOWLEDGE_TYPE_GUN_HELD      KnowledgeType = 6 // 猎人放弃开枪
+)
+
+// Enum value maps for KnowledgeType.
+var (
+	KnowledgeType_name = map[int32]string{
+		0: "KNOWLEDGE_TYPE_UNSPECIFIED",
+		1: "KNOWLEDGE_TYPE_CHECK",
+		2: "KNOWLEDGE_TYPE_NIGHT_KILL",
+		3: "KNOWLEDGE_TYPE_PROTECT",
+		4: "KNOWLEDGE_TYPE_GUN_TRIGGERED",
+		5: "KNOWLEDGE_TYPE_GUN_FIRED",
+		6: "KNOWLEDGE_TYPE_GUN_HELD",
+	}
+	KnowledgeType_value = map[string]int32{
+		"KNOWLEDGE_TYPE_UNSPECIFIED":   0,
+		"KNOWLEDGE_TYPE_CHECK":         1,
+		"KNOWLEDGE_TYPE_NIGHT_KILL":    2,
+		"KNOWLEDGE_TYPE_PROTECT":       3,
+		"KNOWLEDGE_TYPE_GUN_TRIGGERED": 4,
+		"KNOWLEDGE_TYPE_GUN_FIRED":     5,
+		"KNOWLEDGE_TYPE_GUN_HELD":      6,
+	}
+)
+
+func (x KnowledgeType) Enum() *KnowledgeType {
+	p := new(KnowledgeType)
+	*p = x
+	return p
+}
+
+func (x KnowledgeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KnowledgeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_view_proto_enumTypes[0].Descriptor()
+}
+
+func (KnowledgeType) Type() protoreflect.EnumType {
+	return &file_proto_view_proto_enumTypes[0]
+}
+
+func (x KnowledgeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KnowledgeType.Descriptor instead.
+func (KnowledgeType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{0}
+}
+
+// PlayerSummary 其他玩家的公开信息（迷雾过滤后）
+type PlayerSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Alive         bool                   `protobuf:"varint,2,opt,name=alive,proto3" json:"alive,omitempty"`
+	Role          RoleType               `protobuf:"varint,3,opt,name=role,proto3,enum=werewolf.RoleType" json:"role,omitempty"` // 仅自己、狼人队友或游戏结束后可见，否则为 UNSPECIFIED
+	Camp          Camp                   `protobuf:"varint,4,opt,name=camp,proto3,enum=werewolf.Camp" json:"camp,omitempty"`     // 同上
+	Seat          int32                  `protobuf:"varint,5,opt,name=seat,proto3" json:"seat,omitempty"`                        // 座位号
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerSummary) Reset() {
+	*x = PlayerSummary{}
+	mi := &file_proto_view_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerSummary) ProtoMessage() {}
+
+func (x *PlayerSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerSummary.ProtoReflect.Descriptor instead.
+func (*PlayerSummary) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlayerSummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PlayerSummary) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+func (x *PlayerSummary) GetRole() RoleType {
+	if x != nil {
+		return x.Role
+	}
+	return RoleType_ROLE_TYPE_UNSPECIFIED
+}
+
+func (x *PlayerSummary) GetCamp() Camp {
+	if x != nil {
+		return x.Camp
+	}
+	return Camp_CAMP_UNSPECIFIED
+}
+
+func (x *PlayerSummary) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+// PlayerView 单个玩家的视角（只包含该玩家有权看到的信息）
+type PlayerView struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId        string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Phase           PhaseType              `protobuf:"varint,2,opt,name=phase,proto3,enum=werewolf.PhaseType" json:"phase,omitempty"`
+	Round           int32                  `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Role            RoleType               `protobuf:"varint,4,opt,name=role,proto3,enum=werewolf.RoleType" json:"role,omitempty"`
+	Camp            Camp                   `protobuf:"varint,5,opt,name=camp,proto3,enum=werewolf.Camp" json:"camp,omitempty"`
+	Alive           bool                   `protobuf:"varint,6,opt,name=alive,proto3" json:"alive,omitempty"`
+	HasAntidote     bool                   `protobuf:"varint,7,opt,name=has_antidote,json=hasAntidote,proto3" json:"has_antidote,omitempty"`               // 女巫可见
+	HasPoison       bool                   `protobuf:"varint,8,opt,name=has_poison,json=hasPoison,proto3" json:"has_poison,omitempty"`                     // 女巫可见
+	Players         []*PlayerSummary       `protobuf:"bytes,9,rep,name=players,proto3" json:"players,omitempty"`                                           // 所有玩家（迷雾过滤后）
+	Teammates       []string               `protobuf:"bytes,10,rep,name=teammates,proto3" json:"teammates,omitempty"`                                      // 狼人队友
+	NightKillTarget string                 `protobuf:"bytes,11,opt,name=night_kill_target,json=nightKillTarget,proto3" json:"night_kill_target,omitempty"` // 当晚被杀目标（仅女巫阶段的女巫可见）
+	AllowedSkills   []SkillType            `protobuf:"varint,12,rep,packed,name=allowed_skills,json=allowedSkills,proto3,enum=werewolf.SkillType" json:"allowed_skills,omitempty"`
+	GameOver        bool                   `protobuf:"varint,13,opt,name=game_over,json=gameOver,proto3" json:"game_over,omitempty"`
+	Seat            int32                  `protobuf:"varint,14,opt,name=seat,proto3" json:"seat,omitempty"`                           // 自己的座位号
+	WolfTally       *WolfTallyEvent        `protobuf:"bytes,15,opt,name=wolf_tally,json=wolfTally,proto3" json:"wolf_tally,omitempty"` // 狼人阶段的实时票型（仅狼人可见）
+	Knowledge       []*KnowledgeEntry      `protobuf:"bytes,16,rep,name=knowledge,proto3" json:"knowledge,omitempty"`                  // 自己的私有信息记录（按时间顺序）
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PlayerView) Reset() {
+	*x = PlayerView{}
+	mi := &file_proto_view_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerView) ProtoMessage() {}
+
+func (x *PlayerView) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerView.ProtoReflect.Descriptor instead.
+func (*PlayerView) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlayerView) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerView) GetPhase() PhaseType {
+	if x != nil {
+		return x.Phase
+	}
+	return PhaseType_PHASE_TYPE_UNSPECIFIED
+}
+
+func (x *PlayerView) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *PlayerView) GetRole() RoleType {
+	if x != nil {
+		return x.Role
+	}
+	return RoleType_ROLE_TYPE_UNSPECIFIED
+}
+
+func (x *PlayerView) GetCamp() Camp {
+	if x != nil {
+		return x.Camp
+	}
+	return Camp_CAMP_UNSPECIFIED
+}
+
+func (x *PlayerView) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+func (x *PlayerView) GetHasAntidote() bool {
+	if x != nil {
+		return x.HasAntidote
+	}
+	return false
+}
+
+func (x *PlayerView) GetHasPoison() bool {
+	if x != nil {
+		return x.HasPoison
+	}
+	return false
+}
+
+func (x *PlayerView) GetPlayers() []*PlayerSummary {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *PlayerView) GetTeammates() []string {
+	if x != nil {
+		return x.Teammates
+	}
+	return nil
+}
+
+func (x *PlayerView) GetNightKillTarget() string {
+	if x != nil {
+		return x.NightKillTarget
+	}
+	return ""
+}
+
+func (x *PlayerView) GetAllowedSkills() []SkillType {
+	if x != nil {
+		return x.AllowedSkills
+	}
+	return nil
+}
+
+func (x *PlayerView) GetGameOver() bool {
+	if x != nil {
+		return x.GameOver
+	}
+	return false
+}
+
+func (x *PlayerView) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *PlayerView) GetWolfTally() *WolfTallyEvent {
+	if x != nil {
+		return x.WolfTally
+	}
+	return nil
+}
+
+func (x *PlayerView) GetKnowledge() []*KnowledgeEntry {
+	if x != nil {
+		return x.Knowledge
+	}
+	return nil
+}
+
+// KnowledgeEntry 玩家私有信息记录中的一条（玩家的"笔记本"）
+type KnowledgeEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          KnowledgeType          `protobuf:"varint,1,opt,name=type,proto3,enum=werewolf.KnowledgeType" json:"type,omitempty"`
+	Round         int32                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Phase         PhaseType              `protobuf:"varint,3,opt,name=phase,proto3,enum=werewolf.PhaseType" json:"phase,omitempty"` // 得知该信息的阶段
+	TargetId      string                 `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Camp          Camp                   `protobuf:"varint,5,opt,name=camp,proto3,enum=werewolf.Camp" json:"camp,omitempty"` // 查验结果的阵营
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KnowledgeEntry) Reset() {
+	*x = KnowledgeEntry{}
+	mi := &file_proto_view_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnowledgeEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnowledgeEntry) ProtoMessage() {}
+
+func (x *KnowledgeEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnowledgeEntry.ProtoReflect.Descriptor instead.
+func (*KnowledgeEntry) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KnowledgeEntry) GetType() KnowledgeType {
+	if x != nil {
+		return x.Type
+	}
+	return KnowledgeType_KNOWLEDGE_TYPE_UNSPECIFIED
+}
+
+func (x *KnowledgeEntry) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *KnowledgeEntry) GetPhase() PhaseType {
+	if x != nil {
+		return x.Phase
+	}
+	return PhaseType_PHASE_TYPE_UNSPECIFIED
+}
+
+func (x *KnowledgeEntry) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *KnowledgeEntry) GetCamp() Camp {
+	if x != nil {
+		return x.Camp
+	}
+	return Camp_CAMP_UNSPECIFIED
+}
+
+// ChatMessage 游戏内聊天消息
+type ChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SenderId      string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Phase         PhaseType              `protobuf:"varint,3,opt,name=phase,proto3,enum=werewolf.PhaseType" json:"phase,omitempty"`
+	Round         int32                  `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`
+	TimestampMs   int64                  `protobuf:"varint,5,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // 发送时间（Unix 毫秒）
+	ReceiverIds   []string               `protobuf:"bytes,6,rep,name=receiver_ids,json=receiverIds,proto3" json:"receiver_ids,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"` // 本局内的发布序号（与事件共用）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	mi := &file_proto_view_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChatMessage) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetPhase() PhaseType {
+	if x != nil {
+		return x.Phase
+	}
+	return PhaseType_PHASE_TYPE_UNSPECIFIED
+}
+
+func (x *ChatMessage) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetReceiverIds() []string {
+	if x != nil {
+		return x.ReceiverIds
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+var File_proto_view_proto protoreflect.FileDescriptor
+
+const file_proto_view_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/view.proto\x12\bwerewolf\x1a\x11proto/event.proto\"\x95\x01\n" +
+	"\rPlayerSummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05alive\x18\x02 \x01(\bR\x05alive\x12&\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x12.werewolf.RoleTypeR\x04role\x12\"\n" +
+	"\x04camp\x18\x04 \x01(\x0e2\x0e.werewolf.CampR\x04camp\x12\x12\n" +
+	"\x04seat\x18\x05 \x01(\x05R\x04seat\"\xe9\x04\n" +
+	"\n" +
+	"PlayerView\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12)\n" +
+	"\x05phase\x18\x02 \x01(\x0e2\x13.werewolf.PhaseTypeR\x05phase\x12\x14\n" +
+	"\x05round\x18\x03 \x01(\x05R\x05round\x12&\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x12.werewolf.RoleTypeR\x04role\x12\"\n" +
+	"\x04camp\x18\x05 \x01(\x0e2\x0e.werewolf.CampR\x04camp\x12\x14\n" +
+	"\x05alive\x18\x06 \x01(\bR\x05alive\x12!\n" +
+	"\fhas_antidote\x18\a \x01(\bR\vhasAntidote\x12\x1d\n" +
+	"\n" +
+	"has_poison\x18\b \x01(\bR\thasPoison\x121\n" +
+	"\aplayers\x18\t \x03(\v2\x17.werewolf.PlayerSummaryR\aplayers\x12\x1c\n" +
+	"\tteammates\x18\n" +
+	" \x03(\tR\tteammates\x12*\n" +
+	"\x11night_kill_target\x18\v \x01(\tR\x0fnightKillTarget\x12:\n" +
+	"\x0eallowed_skills\x18\f \x03(\x0e2\x13.werewolf.SkillTypeR\rallowedSkills\x12\x1b\n" +
+	"\tgame_over\x18\r \x01(\bR\bgameOver\x12\x12\n" +
+	"\x04seat\x18\x0e \x01(\x05R\x04seat\x127\n" +
+	"\n" +
+	"wolf_tally\x18\x0f \x01(\v2\x18.werewolf.WolfTallyEventR\twolfTally\x126\n" +
+	"\tknowledge\x18\x10 \x03(\v2\x18.werewolf.KnowledgeEntryR\tknowledge\"\xbf\x01\n" +
+	"\x0eKnowledgeEntry\x12+\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x17.werewolf.KnowledgeTypeR\x04type\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x05R\x05round\x12)\n" +
+	"\x05phase\x18\x03 \x01(\x0e2\x13.werewolf.PhaseTypeR\x05phase\x12\x1b\n" +
+	"\ttarget_id\x18\x04 \x01(\tR\btargetId\x12\"\n" +
+	"\x04camp\x18\x05 \x01(\x0e2\x0e.werewolf.CampR\x04camp\"\xe7\x01\n" +
+	"\vChatMessage\x12\x1b\n" +
+	"\tsender_id\x18\x01 \x01(\tR\bsenderId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12)\n" +
+	"\x05phase\x18\x03 \x01(\x0e2\x13.werewolf.PhaseTypeR\x05phase\x12\x14\n" +
+	"\x05round\x18\x04 \x01(\x05R\x05round\x12!\n" +
+	"\ftimestamp_ms\x18\x05 \x01(\x03R\vtimestampMs\x12!\n" +
+	"\freceiver_ids\x18\x06 \x03(\tR\vreceiverIds\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\x04R\bsequence*\xe1\x01\n" +
+	"\rKnowledgeType\x12\x1e\n" +
+	"\x1aKNOWLEDGE_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14KNOWLEDGE_TYPE_CHECK\x10\x01\x12\x1d\n" +
+	"\x19KNOWLEDGE_TYPE_NIGHT_KILL\x10\x02\x12\x1a\n" +
+	"\x16KNOWLEDGE_TYPE_PROTECT\x10\x03\x12 \n" +
+	"\x1cKNOWLEDGE_TYPE_GUN_TRIGGERED\x10\x04\x12\x1c\n" +
+	"\x18KNOWLEDGE_TYPE_GUN_FIRED\x10\x05\x12\x1b\n" +
+	"\x17KNOWLEDGE_TYPE_GUN_HELD\x10\x06B#Z!github.com/Zereker/werewolf/protob\x06proto3"
+
+var (
+	file_proto_view_proto_rawDescOnce sync.Once
+	file_proto_view_proto_rawDescData []byte
+)
+
+func file_proto_view_proto_rawDescGZIP() []byte {
+	file_proto_view_proto_rawDescOnce.Do(func() {
+		file_proto_view_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_view_proto_rawDesc), len(file_proto_view_proto_rawDesc)))
+	})
+	return file_proto_view_proto_rawDescData
+}
+
+var file_proto_view_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_view_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_view_proto_goTypes = []any{
+	(KnowledgeType)(0),     // 0: werewolf.KnowledgeType
+	(*PlayerSummary)(nil),  // 1: werewolf.PlayerSummary
+	(*PlayerView)(nil),     // 2: werewolf.PlayerView
+	(*KnowledgeEntry)(nil), // 3: werewolf.KnowledgeEntry
+	(*ChatMessage)(nil),    // 4: werewolf.ChatMessage
+	(RoleType)(0),          // 5: werewolf.RoleType
+	(Camp)(0),              // 6: werewolf.Camp
+	(PhaseType)(0),         // 7: werewolf.PhaseType
+	(SkillType)(0),         // 8: werewolf.SkillType
+	(*WolfTallyEvent)(nil), // 9: werewolf.WolfTallyEvent
+}
+var file_proto_view_proto_depIdxs = []int32{
+	5,  // 0: werewolf.PlayerSummary.role:type_name -> werewolf.RoleType
+	6,  // 1: werewolf.PlayerSummary.camp:type_name -> werewolf.Camp
+	7,  // 2: werewolf.PlayerView.phase:type_name -> werewolf.PhaseType
+	5,  // 3: werewolf.PlayerView.role:type_name -> werewolf.RoleType
+	6,  // 4: werewolf.PlayerView.camp:type_name -> werewolf.Camp
+	1,  // 5: werewolf.PlayerView.players:type_name -> werewolf.PlayerSummary
+	8,  // 6: werewolf.PlayerView.allowed_skills:type_name -> werewolf.SkillType
+	9,  // 7: werewolf.PlayerView.wolf_tally:type_name -> werewolf.WolfTallyEvent
+	3,  // 8: werewolf.PlayerView.knowledge:type_name -> werewolf.KnowledgeEntry
+	0,  // 9: werewolf.KnowledgeEntry.type:type_name -> werewolf.KnowledgeType
+	7,  // 10: werewolf.KnowledgeEntry.phase:type_name -> werewolf.PhaseType
+	6,  // 11: werewolf.KnowledgeEntry.camp:type_name -> werewolf.Camp
+	7,  // 12: werewolf.ChatMessage.phase:type_name -> werewolf.PhaseType
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_proto_view_proto_init() }
+func file_proto_view_proto_init() {
+	if File_proto_view_proto != nil {
+		return
+	}
+	file_proto_event_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_view_proto_rawDesc), len(file_proto_view_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_view_proto_goTypes,
+		DependencyIndexes: file_proto_view_proto_depIdxs,
+		EnumInfos:         file_proto_view_proto_enumTypes,
+		MessageInfos:      file_proto_view_proto_msgTypes,
+	}.Build()
+	File_proto_view_proto = out.File
+	file_proto_view_proto_goTypes = nil
+	file_proto_view_proto_depIdxs = nil
+}
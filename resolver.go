@@ -65,6 +65,16 @@ func countVotes(uses []*SkillUse, skillType pb.SkillType) VoteResult {
 	}
 }
 
+// hasVoted 玩家是否投出了有效票
+func hasVoted(uses []*SkillUse, playerID string) bool {
+	for _, use := range uses {
+		if use.PlayerID == playerID && use.Skill == pb.SkillType_SKILL_TYPE_VOTE && use.TargetID != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // VoteResolver 投票阶段解析器
 type VoteResolver struct{}
 
@@ -79,12 +89,23 @@ func (r *VoteResolver) Resolve(uses []*SkillUse, state *State, config *GameConfi
 
 	result := countVotes(uses, pb.SkillType_SKILL_TYPE_VOTE)
 
+	// 存活但未投票的玩家
+	var abstained []string
+	for _, id := range state.getAlivePlayerIDsBySeat() {
+		if !hasVoted(uses, id) {
+			abstained = append(abstained, id)
+		}
+	}
+
+	// 无论是否有人出局都公布投票结果
+	resultEffect := NewEffect(pb.EventType_EVENT_TYPE_VOTE_RESULT, "", result.Winner).
+		WithData("votes", result.Votes).
+		WithPayload(voteResultPayload(result, abstained))
+	effects = append(effects, resultEffect)
+
 	// 如果平票或无票，不处决任何人
 	if result.Tied || result.Winner == "" {
-		effect := NewEffect(pb.EventType_EVENT_TYPE_UNSPECIFIED, "", "").
-			WithData("result", "tied").
-			WithData("votes", result.Votes)
-		effects = append(effects, effect)
+		resultEffect.WithData("result", "tied")
 		return effects
 	}
 
@@ -92,16 +113,12 @@ func (r *VoteResolver) Resolve(uses []*SkillUse, state *State, config *GameConfi
 	effect := NewEffect(pb.EventType_EVENT_TYPE_ELIMINATE, "", result.Winner).
 		WithData("votes", result.MaxVote).
 		WithData("voters", result.Voters[result.Winner]).
-		WithData("allVotes", result.Votes)
+		WithData("allVotes", result.Votes).
+		WithPayload(killPayload(pb.EventType_EVENT_TYPE_ELIMINATE, result.Winner, ""))
 	effects = append(effects, effect)
 
-	// 检查被处决者是否是猎人
-	if target, ok := state.GetPlayerInfo(result.Winner); ok {
-		if target.Role == pb.RoleType_ROLE_TYPE_HUNTER {
-			hunterTriggerEffect := NewEffect(pb.EventType_EVENT_TYPE_HUNTER_TRIGGERED, result.Winner, "")
-			effects = append(effects, hunterTriggerEffect)
-		}
-	}
+	// 被处决者的死亡钩子（如猎人触发开枪）
+	effects = append(effects, deathEffects(state, result.Winner, pb.EventType_EVENT_TYPE_ELIMINATE)...)
 
 	return effects
 }
@@ -174,14 +191,15 @@ func (r *WolfResolver) Resolve(uses []*SkillUse, state *State, config *GameConfi
 	// 使用公共投票统计函数
 	result := countVotes(uses, pb.SkillType_SKILL_TYPE_KILL)
 
-	// 无票或平票则空刀（狼人未达成共识）
-	if result.Winner == "" {
+	// 无票、未达成一致或平票未能决出则空刀
+	target := wolfKillTarget(result, uses, state, config)
+	if target == "" {
 		return effects
 	}
 
 	// 检查同守同杀：使用 RoundContext 检查保护状态
 	// Guard 的 PROTECT Effect 已经在上一阶段应用到 RoundContext
-	if state.RoundCtx.IsProtected(result.Winner) && config.SameGuardKillIsEmpty {
+	if state.RoundCtx.IsProtected(target) && config.SameGuardKillIsEmpty {
 		// 同守同杀空刀 - 不设置击杀目标
 		// 女巫不知道有人被攻击
 		return effects
@@ -189,12 +207,91 @@ func (r *WolfResolver) Resolve(uses []*SkillUse, state *State, config *GameConfi
 
 	// 通过 Effect 设置狼人击杀目标（供女巫查询）
 	// 不直接修改 state，由 ApplyEffect 统一处理
-	setKillEffect := NewEffect(pb.EventType_EVENT_TYPE_SET_NIGHT_KILL, "", result.Winner)
+	setKillEffect := NewEffect(pb.EventType_EVENT_TYPE_SET_NIGHT_KILL, "", target)
 	effects = append(effects, setKillEffect)
 
 	return effects
 }
 
+// wolfKillTarget 按配置的一致性要求和平票策略决定狼人的击杀目标，空字符串表示空刀
+func wolfKillTarget(result VoteResult, uses []*SkillUse, state *State, config *GameConfig) string {
+	if len(result.Votes) == 0 {
+		return ""
+	}
+
+	// 全票一致：所有存活狼人都选择了同一目标
+	if config.WolfKillUnanimous {
+		wolves := state.getAlivePlayerIDsByRole(pb.RoleType_ROLE_TYPE_WEREWOLF)
+		if len(result.Votes) != 1 || result.MaxVote < len(wolves) {
+			return ""
+		}
+		return result.Winner
+	}
+
+	if !result.Tied {
+		return result.Winner
+	}
+
+	tied := make(map[string]bool)
+	for target, votes := range result.Votes {
+		if votes == result.MaxVote {
+			tied[target] = true
+		}
+	}
+
+	switch config.WolfTiePolicy {
+	case WolfTieRandom:
+		candidates := state.sortBySeat(tied)
+		return candidates[state.randIntn(len(candidates))]
+	case WolfTieLeadWolf:
+		lead := leadWolf(state)
+		for _, use := range uses {
+			if use.PlayerID == lead && use.Skill == pb.SkillType_SKILL_TYPE_KILL && tied[use.TargetID] {
+				return use.TargetID
+			}
+		}
+		return ""
+	default:
+		return ""
+	}
+}
+
+// leadWolf 狼队长：座位号最小的存活狼人
+func leadWolf(state *State) string {
+	wolves := make(map[string]bool)
+	for _, id := range state.getAlivePlayerIDsByRole(pb.RoleType_ROLE_TYPE_WEREWOLF) {
+		wolves[id] = true
+	}
+	if sorted := state.sortBySeat(wolves); len(sorted) > 0 {
+		return sorted[0]
+	}
+	return ""
+}
+
+// wolfTallyPayload 狼人阶段的实时票型
+func wolfTallyPayload(uses []*SkillUse, state *State) *pb.WolfTallyEvent {
+	result := countVotes(uses, pb.SkillType_SKILL_TYPE_KILL)
+	picks := make(map[string]string)
+	for target, voters := range result.Voters {
+		for _, voter := range voters {
+			picks[voter] = target
+		}
+	}
+
+	wolves := make(map[string]bool)
+	for _, id := range state.getAlivePlayerIDsByRole(pb.RoleType_ROLE_TYPE_WEREWOLF) {
+		wolves[id] = true
+	}
+	payload := &pb.WolfTallyEvent{
+		Round:   int32(state.Round),
+		Tallies: voteResultPayload(result, nil).GetTallies(),
+	}
+	for _, id := range state.sortBySeat(wolves) {
+		payload.Picks = append(payload.Picks, &pb.WolfPick{WolfId: id, TargetId: picks[id]})
+	}
+	return payload
+}
+
 // WitchResolver 女巫阶段解析器
 type WitchResolver struct{}
 
@@ -298,7 +395,8 @@ func (r *SeerResolver) Resolve(uses []*SkillUse, state *State, config *GameConfi
 			if target, ok := state.GetPlayerInfo(use.TargetID); ok {
 				checkEffect.
 					WithData("camp", target.Camp).
-					WithData("isGood", target.Camp == pb.Camp_CAMP_GOOD)
+					WithData("isGood", target.Camp == pb.Camp_CAMP_GOOD).
+					WithPayload(&pb.CheckEvent{TargetId: target.ID, Camp: target.Camp, IsGood: target.Camp == pb.Camp_CAMP_GOOD})
 			}
 			effects = append(effects, checkEffect)
 		}
@@ -317,6 +415,7 @@ func NewNightResolveResolver() *NightResolveResolver {
 
 func (r *NightResolveResolver) Resolve(uses []*SkillUse, state *State, config *GameConfig) []*Effect {
 	effects := make([]*Effect, 0)
+	dead := make(map[string]bool)
 
 	// 处理狼人击杀
 	if state.RoundCtx.KillTarget != "" {
@@ -329,33 +428,35 @@ func (r *NightResolveResolver) Resolve(uses []*SkillUse, state *State, config *G
 			effects = append(effects, clearKillEffect)
 		} else {
 			// 正常击杀
-			killEffect := NewEffect(pb.EventType_EVENT_TYPE_KILL, "", killTarget)
+			dead[killTarget] = true
+			killEffect := NewEffect(pb.EventType_EVENT_TYPE_KILL, "", killTarget).
+				WithPayload(killPayload(pb.EventType_EVENT_TYPE_KILL, killTarget, ""))
 			effects = append(effects, killEffect)
 
-			// 检查被杀者是否是猎人，如果是则触发猎人技能
-			if target, ok := state.GetPlayerInfo(killTarget); ok {
-				if target.Role == pb.RoleType_ROLE_TYPE_HUNTER {
-					hunterTriggerEffect := NewEffect(pb.EventType_EVENT_TYPE_HUNTER_TRIGGERED, killTarget, "")
-					effects = append(effects, hunterTriggerEffect)
-				}
-			}
+			// 被杀者的死亡钩子（如猎人触发开枪）
+			effects = append(effects, deathEffects(state, killTarget, pb.EventType_EVENT_TYPE_KILL)...)
 		}
 	}
 
 	// 处理女巫毒杀（毒杀的玩家已在 WitchResolver 中标记到 RoundContext）
 	for playerID := range state.RoundCtx.PoisonedPlayers {
-		poisonKillEffect := NewEffect(pb.EventType_EVENT_TYPE_POISON, "", playerID)
+		dead[playerID] = true
+		poisonKillEffect := NewEffect(pb.EventType_EVENT_TYPE_POISON, "", playerID).
+			WithPayload(killPayload(pb.EventType_EVENT_TYPE_POISON, playerID, ""))
 		effects = append(effects, poisonKillEffect)
 
-		// 检查被毒者是否是猎人
-		if target, ok := state.GetPlayerInfo(playerID); ok {
-			if target.Role == pb.RoleType_ROLE_TYPE_HUNTER {
-				hunterTriggerEffect := NewEffect(pb.EventType_EVENT_TYPE_HUNTER_TRIGGERED, playerID, "")
-				effects = append(effects, hunterTriggerEffect)
-			}
-		}
+		// 被毒者的死亡钩子
+		effects = append(effects, deathEffects(state, playerID, pb.EventType_EVENT_TYPE_POISON)...)
 	}
 
+	// 公布夜晚结果（只公布死亡名单，不公布死因）
+	deadIDs := state.sortBySeat(dead)
+	summaryEffect := NewEffect(pb.EventType_EVENT_TYPE_NIGHT_SUMMARY, "", "").
+		WithData("peaceful", len(deadIDs) == 0).
+		WithData("dead", deadIDs).
+		WithPayload(&pb.NightSummaryEvent{Round: int32(state.Round), DeadPlayerIds: deadIDs, Peaceful: len(deadIDs) == 0})
+	effects = append(effects, summaryEffect)
+
 	return effects
 }
 
@@ -380,7 +481,8 @@ func (r *HunterResolver) Resolve(uses []*SkillUse, state *State, config *GameCon
 		case pb.SkillType_SKILL_TYPE_SHOOT:
 			if use.TargetID != "" {
 				usedPlayers[use.PlayerID] = true
-				shootEffect := NewEffect(pb.EventType_EVENT_TYPE_SHOOT, use.PlayerID, use.TargetID)
+				shootEffect := NewEffect(pb.EventType_EVENT_TYPE_SHOOT, use.PlayerID, use.TargetID).
+					WithPayload(killPayload(pb.EventType_EVENT_TYPE_SHOOT, use.TargetID, use.PlayerID))
 				effects = append(effects, shootEffect)
 			}
 		case pb.SkillType_SKILL_TYPE_SKIP:
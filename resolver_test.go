@@ -1,6 +1,7 @@
 package werewolf
 
 import (
+	"math/rand"
 	"testing"
 
 	pb "github.com/Zereker/werewolf/proto"
@@ -18,8 +19,8 @@ func TestVoteResolver_Empty(t *testing.T) {
 	if len(effects) != 1 {
 		t.Fatalf("expected 1 effect (tied), got %d", len(effects))
 	}
-	if effects[0].Type != pb.EventType_EVENT_TYPE_UNSPECIFIED {
-		t.Errorf("expected EVENT_TYPE_UNSPECIFIED for empty votes, got %v", effects[0].Type)
+	if effects[0].Type != pb.EventType_EVENT_TYPE_VOTE_RESULT {
+		t.Errorf("expected EVENT_TYPE_VOTE_RESULT for empty votes, got %v", effects[0].Type)
 	}
 }
 
@@ -36,14 +37,15 @@ func TestVoteResolver_Single(t *testing.T) {
 
 	effects := resolver.Resolve(uses, state, config)
 
-	if len(effects) != 1 {
-		t.Fatalf("expected 1 effect, got %d", len(effects))
+	// 投票结果之后是出局效果
+	if len(effects) != 2 {
+		t.Fatalf("expected 2 effects, got %d", len(effects))
 	}
-	if effects[0].Type != pb.EventType_EVENT_TYPE_ELIMINATE {
-		t.Errorf("expected EVENT_TYPE_ELIMINATE, got %v", effects[0].Type)
+	if effects[1].Type != pb.EventType_EVENT_TYPE_ELIMINATE {
+		t.Errorf("expected EVENT_TYPE_ELIMINATE, got %v", effects[1].Type)
 	}
-	if effects[0].TargetID != "p2" {
-		t.Errorf("expected target=p2, got %s", effects[0].TargetID)
+	if effects[1].TargetID != "p2" {
+		t.Errorf("expected target=p2, got %s", effects[1].TargetID)
 	}
 }
 
@@ -64,14 +66,15 @@ func TestVoteResolver_Clear(t *testing.T) {
 
 	effects := resolver.Resolve(uses, state, config)
 
-	if len(effects) != 1 {
-		t.Fatalf("expected 1 effect, got %d", len(effects))
+	// 投票结果之后是出局效果
+	if len(effects) != 2 {
+		t.Fatalf("expected 2 effects, got %d", len(effects))
 	}
-	if effects[0].Type != pb.EventType_EVENT_TYPE_ELIMINATE {
-		t.Errorf("expected EVENT_TYPE_ELIMINATE, got %v", effects[0].Type)
+	if effects[1].Type != pb.EventType_EVENT_TYPE_ELIMINATE {
+		t.Errorf("expected EVENT_TYPE_ELIMINATE, got %v", effects[1].Type)
 	}
-	if effects[0].TargetID != "wolf" {
-		t.Errorf("expected target=wolf (majority), got %s", effects[0].TargetID)
+	if effects[1].TargetID != "wolf" {
+		t.Errorf("expected target=wolf (majority), got %s", effects[1].TargetID)
 	}
 }
 
@@ -96,8 +99,8 @@ func TestVoteResolver_Tie(t *testing.T) {
 	if len(effects) != 1 {
 		t.Fatalf("expected 1 effect, got %d", len(effects))
 	}
-	if effects[0].Type != pb.EventType_EVENT_TYPE_UNSPECIFIED {
-		t.Errorf("expected EVENT_TYPE_UNSPECIFIED for tie, got %v", effects[0].Type)
+	if effects[0].Type != pb.EventType_EVENT_TYPE_VOTE_RESULT {
+		t.Errorf("expected EVENT_TYPE_VOTE_RESULT for tie, got %v", effects[0].Type)
 	}
 	if effects[0].Data["result"] != "tied" {
 		t.Errorf("expected result=tied, got %v", effects[0].Data["result"])
@@ -124,8 +127,11 @@ func TestVoteResolver_Invalid(t *testing.T) {
 	if len(effects) != 1 {
 		t.Fatalf("expected 1 effect, got %d", len(effects))
 	}
-	if effects[0].Type != pb.EventType_EVENT_TYPE_UNSPECIFIED {
-		t.Errorf("expected EVENT_TYPE_UNSPECIFIED for invalid votes, got %v", effects[0].Type)
+	if effects[0].Type != pb.EventType_EVENT_TYPE_VOTE_RESULT {
+		t.Errorf("expected EVENT_TYPE_VOTE_RESULT for invalid votes, got %v", effects[0].Type)
+	}
+	if abstained := effects[0].ToEvent().GetVoteResult().GetAbstainedIds(); len(abstained) != 2 {
+		t.Errorf("expected both players to abstain, got %v", abstained)
 	}
 }
 
@@ -492,6 +498,11 @@ func TestSeerResolver_CheckWolf(t *testing.T) {
 	if effects[0].Data["camp"] != pb.Camp_CAMP_EVIL {
 		t.Errorf("expected camp=EVIL, got %v", effects[0].Data["camp"])
 	}
+
+	check := effects[0].ToEvent().GetCheck()
+	if check.GetCamp() != pb.Camp_CAMP_EVIL || check.GetIsGood() || check.GetTargetId() != "wolf" {
+		t.Errorf("expected typed check payload for wolf, got %v", check)
+	}
 }
 
 func TestSeerResolver_CheckGood(t *testing.T) {
@@ -576,3 +587,155 @@ func filterEffects(effects []*Effect, eventType pb.EventType) []*Effect {
 	}
 	return result
 }
+
+func TestVoteResolver_Payload(t *testing.T) {
+	resolver := NewVoteResolver()
+	state := NewState()
+	for _, id := range []string{"p1", "p2", "p3", "p4"} {
+		state.AddPlayer(id, pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	}
+
+	effects := resolver.Resolve([]*SkillUse{
+		{PlayerID: "p1", Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "p4"},
+		{PlayerID: "p2", Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "p4"},
+		{PlayerID: "p3", Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "p1"},
+	}, state, DefaultGameConfig())
+
+	result := effects[0].ToEvent().GetVoteResult()
+	if result.GetEliminatedId() != "p4" || result.GetTied() {
+		t.Fatalf("expected p4 eliminated, got %v", result)
+	}
+	tallies := result.GetTallies()
+	if len(tallies) != 2 || tallies[0].GetTargetId() != "p4" || tallies[0].GetVotes() != 2 || tallies[1].GetVotes() != 1 {
+		t.Fatalf("expected tallies sorted by votes, got %v", tallies)
+	}
+	if voters := tallies[0].GetVoterIds(); len(voters) != 2 || voters[0] != "p1" || voters[1] != "p2" {
+		t.Errorf("expected voters p1, p2, got %v", voters)
+	}
+
+	tied := resolver.Resolve([]*SkillUse{
+		{PlayerID: "p1", Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "p3"},
+		{PlayerID: "p2", Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "p4"},
+	}, state, DefaultGameConfig())
+	if result := tied[0].ToEvent().GetVoteResult(); !result.GetTied() || result.GetEliminatedId() != "" || len(result.GetTallies()) != 2 {
+		t.Errorf("expected tied vote result, got %v", result)
+	}
+}
+
+func TestNightResolveResolver_Summary(t *testing.T) {
+	resolver := NewNightResolveResolver()
+	state := NewState()
+	for _, id := range []string{"p1", "p2", "p3"} {
+		state.AddPlayer(id, pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	}
+	state.Round = 2
+
+	// 无人死亡：平安夜
+	effects := resolver.Resolve(nil, state, DefaultGameConfig())
+	summary := effects[len(effects)-1].ToEvent().GetNightSummary()
+	if !summary.GetPeaceful() || len(summary.GetDeadPlayerIds()) != 0 || summary.GetRound() != 2 {
+		t.Errorf("expected peaceful night in round 2, got %v", summary)
+	}
+
+	// 被杀和被毒的玩家按座位列出
+	state.RoundCtx.KillTarget = "p3"
+	state.RoundCtx.PoisonedPlayers["p1"] = true
+	effects = resolver.Resolve(nil, state, DefaultGameConfig())
+	last := effects[len(effects)-1]
+	if last.Type != pb.EventType_EVENT_TYPE_NIGHT_SUMMARY {
+		t.Fatalf("expected night summary last, got %v", last.Type)
+	}
+	dead := last.ToEvent().GetNightSummary().GetDeadPlayerIds()
+	if len(dead) != 2 || dead[0] != "p1" || dead[1] != "p3" {
+		t.Errorf("expected dead [p1 p3], got %v", dead)
+	}
+}
+
+func TestWolfResolver_TiePolicyAndUnanimous(t *testing.T) {
+	newState := func() *State {
+		state := NewState()
+		state.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+		state.AddPlayer("wolf2", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+		state.AddPlayer("wolf3", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+		state.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+		state.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+		state.AddPlayer("v3", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+		return state
+	}
+	kill := func(wolf, target string) *SkillUse {
+		return &SkillUse{PlayerID: wolf, Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: target}
+	}
+	tied := []*SkillUse{kill("wolf2", "v2"), kill("wolf1", "v1")}
+
+	tests := []struct {
+		name      string
+		unanimous bool
+		policy    WolfTiePolicy
+		uses      []*SkillUse
+		want      string
+	}{
+		{"tie no kill", false, WolfTieNoKill, tied, ""},
+		{"tie lead wolf", false, WolfTieLeadWolf, tied, "v1"},
+		{"tie lead wolf abstained", false, WolfTieLeadWolf, []*SkillUse{kill("wolf2", "v2"), kill("wolf3", "v3")}, ""},
+		{"lead wolf outvoted", false, WolfTieLeadWolf, []*SkillUse{kill("wolf1", "v1"), kill("wolf2", "v2"), kill("wolf3", "v2")}, "v2"},
+		{"unanimous", true, WolfTieNoKill, []*SkillUse{kill("wolf1", "v1"), kill("wolf2", "v1"), kill("wolf3", "v1")}, "v1"},
+		{"unanimous majority only", true, WolfTieNoKill, []*SkillUse{kill("wolf1", "v1"), kill("wolf2", "v1"), kill("wolf3", "v2")}, ""},
+		{"unanimous missing pick", true, WolfTieLeadWolf, []*SkillUse{kill("wolf1", "v1"), kill("wolf2", "v1")}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultGameConfig()
+			config.WolfKillUnanimous = tt.unanimous
+			config.WolfTiePolicy = tt.policy
+
+			effects := NewWolfResolver().Resolve(tt.uses, newState(), config)
+			got := ""
+			if len(effects) == 1 {
+				got = effects[0].TargetID
+			}
+			if got != tt.want {
+				t.Errorf("expected kill target %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWolfResolver_TieRandom(t *testing.T) {
+	config := DefaultGameConfig()
+	config.WolfTiePolicy = WolfTieRandom
+
+	// 随机策略只在平票目标中选择，相同种子结果相同
+	seen := make(map[string]bool)
+	for seed := int64(0); seed < 20; seed++ {
+		pick := func() string {
+			state := NewState()
+			state.rng = rand.New(rand.NewSource(seed))
+			state.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+			state.AddPlayer("wolf2", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+			state.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+			state.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+			state.AddPlayer("v3", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+			effects := NewWolfResolver().Resolve([]*SkillUse{
+				{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"},
+				{PlayerID: "wolf2", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v2"},
+			}, state, config)
+			if len(effects) != 1 {
+				t.Fatalf("expected a kill with random tie policy, got %d effects", len(effects))
+			}
+			return effects[0].TargetID
+		}
+
+		target := pick()
+		if target != "v1" && target != "v2" {
+			t.Fatalf("expected a tied target, got %s", target)
+		}
+		if again := pick(); again != target {
+			t.Fatalf("expected same pick for seed %d, got %s and %s", seed, target, again)
+		}
+		seen[target] = true
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected both tied targets across seeds, got %v", seen)
+	}
+}
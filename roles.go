@@ -0,0 +1,365 @@
+package werewolf
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// RoleDefinition 角色定义
+// 描述一个角色的阵营、技能、行动阶段、解析器和规则钩子。通过 RegisterRole 注册后，
+// 引擎从注册表组装默认阶段配置和阶段解析器，新增角色无需修改引擎代码。
+type RoleDefinition struct {
+	Role   pb.RoleType    // 角色类型
+	Camp   pb.Camp        // 默认阵营（发牌时使用）
+	Skills []pb.SkillType // 角色拥有的技能，阶段步骤中该角色只能使用这些技能
+
+	// 夜晚行动阶段配置，nil 表示夜晚不行动（如村民）
+	// 组装默认配置时按 NightOrder 串联各角色的夜晚阶段，NextPhase 会被覆盖
+	NightPhase func() *PhaseConfig
+	NightOrder int // 夜晚行动顺序（越小越早，入口阶段 FirstPhase 总是第一个）
+
+	// 被动触发的阶段配置（如猎人开枪），由其他阶段的条件转换进入
+	TriggeredPhases func() []*PhaseConfig
+	// 角色依赖的转换条件，板子上有该角色时配置中必须有使用该条件的转换
+	Conditions []string
+
+	// 角色所有阶段（夜晚阶段和被动触发阶段）共用的解析器
+	Resolver Resolver
+
+	// 死亡的角色玩家能否在当前阶段（state.Phase）行动（如被触发的猎人开枪），nil 表示不能，调用时引擎持有锁
+	CanActWhenDead func(player PlayerInfo, state *State) bool
+	// 技能能否以死亡玩家为目标（如女巫解药），nil 表示不能
+	CanTargetDead func(skill pb.SkillType) bool
+	// 额外的技能校验（如守卫、女巫的游戏规则），在通用校验（存活、技能允许、目标有效）通过后调用，
+	// config 为引擎当前的配置（大厅中可以通过 SetRules 更换），调用时引擎持有锁
+	ValidateSkillUse func(use *SkillUse, state *State, config *GameConfig) error
+
+	// 角色玩家死亡（夜晚被杀、被毒或被投票出局）时产生的额外效果，cause 为死亡效果类型
+	OnDeath func(player PlayerInfo, cause pb.EventType, state *State) []*Effect
+
+	// 角色玩家的私有信息记录（见 KnowledgeTrigger），返回要追加的记录，nil 表示不记录
+	// 调用时 State 持有锁，钩子只能读取参数，不能再调用 State 的方法
+	RecordKnowledge func(player PlayerInfo, trigger KnowledgeTrigger) []*pb.KnowledgeEntry
+
+	phases []pb.PhaseType // 角色带来的阶段，注册时计算
+}
+
+var (
+	rolesMu sync.RWMutex
+	roles   = registerBuiltinRoles()
+)
+
+// RegisterRole 注册角色，注册后可以出现在板子和阶段配置中
+// 角色为 UNSPECIFIED/GOD、阵营未指定、有阶段但没有解析器、与已注册角色重复或争用同一阶段时 panic
+// （与 RegisterCondition 一致，应在 init 中调用）
+func RegisterRole(def RoleDefinition) {
+	rolesMu.Lock()
+	defer rolesMu.Unlock()
+
+	registerRoleLocked(roles, def)
+}
+
+// LookupRole 按角色类型查找角色定义
+func LookupRole(role pb.RoleType) (RoleDefinition, bool) {
+	rolesMu.RLock()
+	defer rolesMu.RUnlock()
+
+	def, ok := roles[role]
+	if !ok {
+		return RoleDefinition{}, false
+	}
+	return *def, true
+}
+
+// RoleDefinitions 已注册的角色定义（按角色类型排序）
+func RoleDefinitions() []RoleDefinition {
+	rolesMu.RLock()
+	defer rolesMu.RUnlock()
+
+	defs := make([]RoleDefinition, 0, len(roles))
+	for _, def := range roles {
+		defs = append(defs, *def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Role < defs[j].Role })
+	return defs
+}
+
+// registerRoleLocked 校验并写入角色定义（调用方持有 rolesMu 或处于包初始化阶段）
+func registerRoleLocked(registry map[pb.RoleType]*RoleDefinition, def RoleDefinition) {
+	name := roleName(def.Role)
+	if def.Role == pb.RoleType_ROLE_TYPE_UNSPECIFIED || def.Role == pb.RoleType_ROLE_TYPE_GOD {
+		panic(fmt.Sprintf("werewolf: RegisterRole cannot register %s", name))
+	}
+	if def.Camp == pb.Camp_CAMP_UNSPECIFIED {
+		panic(fmt.Sprintf("werewolf: RegisterRole requires a camp for %s", name))
+	}
+	if _, dup := registry[def.Role]; dup {
+		panic(fmt.Sprintf("werewolf: RegisterRole called twice for %s", name))
+	}
+
+	def.phases = nil
+	if def.NightPhase != nil {
+		def.phases = append(def.phases, def.NightPhase().Type)
+	}
+	if def.TriggeredPhases != nil {
+		for _, config := range def.TriggeredPhases() {
+			def.phases = append(def.phases, config.Type)
+		}
+	}
+	if len(def.phases) > 0 && def.Resolver == nil {
+		panic(fmt.Sprintf("werewolf: RegisterRole requires a resolver for %s", name))
+	}
+	for _, phase := range def.phases {
+		if _, shared := sharedResolvers()[phase]; shared {
+			panic(fmt.Sprintf("werewolf: RegisterRole: phase %s of %s is a shared phase", phaseName(phase), name))
+		}
+		for _, other := range registry {
+			if other.ownsPhase(phase) {
+				panic(fmt.Sprintf("werewolf: RegisterRole: phase %s of %s is already owned by %s", phaseName(phase), name, roleName(other.Role)))
+			}
+		}
+	}
+
+	def.Skills = append([]pb.SkillType(nil), def.Skills...)
+	def.Conditions = append([]string(nil), def.Conditions...)
+	registry[def.Role] = &def
+}
+
+// ownsPhase 阶段是否由该角色带来
+func (d *RoleDefinition) ownsPhase(phase pb.PhaseType) bool {
+	for _, p := range d.phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// canAct 玩家能否在当前阶段行动：存活，或角色定义允许死亡后行动（调用时引擎持有锁）
+func canAct(player PlayerInfo, state *State) bool {
+	if player.Alive {
+		return true
+	}
+	def, ok := LookupRole(player.Role)
+	return ok && def.CanActWhenDead != nil && def.CanActWhenDead(player, state)
+}
+
+// hasSkill 角色是否拥有该技能
+func (d *RoleDefinition) hasSkill(skill pb.SkillType) bool {
+	for _, s := range d.Skills {
+		if s == skill {
+			return true
+		}
+	}
+	return false
+}
+
+// ==================== 组装 ====================
+
+// sharedResolvers 不属于任何角色的公共阶段解析器
+func sharedResolvers() map[pb.PhaseType]Resolver {
+	return map[pb.PhaseType]Resolver{
+		pb.PhaseType_PHASE_TYPE_DAY:           NewDayResolver(),
+		pb.PhaseType_PHASE_TYPE_VOTE:          NewVoteResolver(),
+		pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE: NewNightResolveResolver(),
+	}
+}
+
+// registeredResolvers 公共阶段解析器加上已注册角色的阶段解析器
+func registeredResolvers() map[pb.PhaseType]Resolver {
+	resolvers := sharedResolvers()
+	for _, def := range RoleDefinitions() {
+		for _, phase := range def.phases {
+			resolvers[phase] = def.Resolver
+		}
+	}
+	return resolvers
+}
+
+// registeredPhases 公共阶段加上已注册角色的阶段
+// 角色的夜晚阶段从 FirstPhase 开始按 NightOrder 串联，最后进入夜晚结算
+func registeredPhases() map[pb.PhaseType]*PhaseConfig {
+	phases := map[pb.PhaseType]*PhaseConfig{
+		pb.PhaseType_PHASE_TYPE_DAY:           StandardDayPhase(),
+		pb.PhaseType_PHASE_TYPE_VOTE:          StandardVotePhase(),
+		pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE: NightResolvePhase(),
+	}
+
+	type nightPhase struct {
+		config *PhaseConfig
+		order  int
+	}
+	var night []nightPhase
+	for _, def := range RoleDefinitions() {
+		if def.NightPhase != nil {
+			night = append(night, nightPhase{config: def.NightPhase(), order: def.NightOrder})
+		}
+		if def.TriggeredPhases != nil {
+			for _, config := range def.TriggeredPhases() {
+				phases[config.Type] = config
+			}
+		}
+	}
+
+	sort.SliceStable(night, func(i, j int) bool {
+		a, b := night[i], night[j]
+		if (a.config.Type == FirstPhase) != (b.config.Type == FirstPhase) {
+			return a.config.Type == FirstPhase
+		}
+		if a.order != b.order {
+			return a.order < b.order
+		}
+		return a.config.Type < b.config.Type
+	})
+	for i, n := range night {
+		n.config.NextPhase = pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE
+		if i+1 < len(night) {
+			n.config.NextPhase = night[i+1].config.Type
+		}
+		phases[n.config.Type] = n.config
+	}
+	return phases
+}
+
+// deathEffects 玩家死亡时由其角色的死亡钩子产生的效果
+func deathEffects(state *State, playerID string, cause pb.EventType) []*Effect {
+	player, ok := state.GetPlayerInfo(playerID)
+	if !ok {
+		return nil
+	}
+	def, ok := LookupRole(player.Role)
+	if !ok || def.OnDeath == nil {
+		return nil
+	}
+	return def.OnDeath(player, cause, state)
+}
+
+// ==================== 内置角色 ====================
+
+// registerBuiltinRoles 通过与自定义角色相同的注册流程注册内置角色
+func registerBuiltinRoles() map[pb.RoleType]*RoleDefinition {
+	registry := make(map[pb.RoleType]*RoleDefinition)
+	for _, def := range builtinRoles() {
+		registerRoleLocked(registry, def)
+	}
+	return registry
+}
+
+// builtinRoles 内置角色定义
+func builtinRoles() []RoleDefinition {
+	return []RoleDefinition{
+		{
+			Role:             pb.RoleType_ROLE_TYPE_GUARD,
+			Camp:             pb.Camp_CAMP_GOOD,
+			Skills:           []pb.SkillType{pb.SkillType_SKILL_TYPE_PROTECT},
+			NightPhase:       NightGuardPhase,
+			NightOrder:       10,
+			Resolver:         NewGuardResolver(),
+			ValidateSkillUse: validateGuardSkillUse,
+			RecordKnowledge:  guardKnowledge,
+		},
+		{
+			Role:       pb.RoleType_ROLE_TYPE_WEREWOLF,
+			Camp:       pb.Camp_CAMP_EVIL,
+			Skills:     []pb.SkillType{pb.SkillType_SKILL_TYPE_KILL},
+			NightPhase: NightWolfPhase,
+			NightOrder: 20,
+			Resolver:   NewWolfResolver(),
+		},
+		{
+			Role:       pb.RoleType_ROLE_TYPE_WITCH,
+			Camp:       pb.Camp_CAMP_GOOD,
+			Skills:     []pb.SkillType{pb.SkillType_SKILL_TYPE_ANTIDOTE, pb.SkillType_SKILL_TYPE_POISON},
+			NightPhase: NightWitchPhase,
+			NightOrder: 30,
+			Resolver:   NewWitchResolver(),
+			// 解药的目标是今晚被杀（已标记死亡）的玩家
+			CanTargetDead: func(skill pb.SkillType) bool {
+				return skill == pb.SkillType_SKILL_TYPE_ANTIDOTE
+			},
+			ValidateSkillUse: validateWitchSkillUse,
+			RecordKnowledge:  witchKnowledge,
+		},
+		{
+			Role:            pb.RoleType_ROLE_TYPE_SEER,
+			Camp:            pb.Camp_CAMP_GOOD,
+			Skills:          []pb.SkillType{pb.SkillType_SKILL_TYPE_CHECK},
+			NightPhase:      NightSeerPhase,
+			NightOrder:      40,
+			Resolver:        NewSeerResolver(),
+			RecordKnowledge: seerKnowledge,
+		},
+		{
+			Role:   pb.RoleType_ROLE_TYPE_HUNTER,
+			Camp:   pb.Camp_CAMP_GOOD,
+			Skills: []pb.SkillType{pb.SkillType_SKILL_TYPE_SHOOT, pb.SkillType_SKILL_TYPE_SKIP},
+			TriggeredPhases: func() []*PhaseConfig {
+				return []*PhaseConfig{NightHunterPhase(), DayHunterPhase()}
+			},
+			Conditions: []string{ConditionHunterTriggered},
+			Resolver:   NewHunterResolver(),
+			// 只有被触发的猎人死亡后在开枪阶段行动
+			CanActWhenDead: func(player PlayerInfo, state *State) bool {
+				return isHunterPhase(state.Phase) && player.ID == state.RoundCtx.TriggeredHunterID
+			},
+			OnDeath: func(player PlayerInfo, cause pb.EventType, state *State) []*Effect {
+				return []*Effect{NewEffect(pb.EventType_EVENT_TYPE_HUNTER_TRIGGERED, player.ID, "")}
+			},
+			RecordKnowledge: hunterKnowledge,
+		},
+		{
+			Role: pb.RoleType_ROLE_TYPE_VILLAGER,
+			Camp: pb.Camp_CAMP_GOOD,
+		},
+	}
+}
+
+// isHunterPhase 是否是猎人开枪阶段
+func isHunterPhase(phase pb.PhaseType) bool {
+	return phase == pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER || phase == pb.PhaseType_PHASE_TYPE_DAY_HUNTER
+}
+
+// validateGuardSkillUse 按游戏规则校验守卫的守护（与解析器取消效果的条件一致），在提交时就拒绝必然被取消的技能
+func validateGuardSkillUse(use *SkillUse, state *State, config *GameConfig) error {
+	if use.Skill != pb.SkillType_SKILL_TYPE_PROTECT || use.TargetID == "" {
+		return nil
+	}
+	if !state.CanProtect(use.PlayerID, use.TargetID, config.GuardCanRepeat) {
+		return ErrGuardRepeat
+	}
+	if use.PlayerID == use.TargetID && !config.GuardCanProtectSelf {
+		return ErrSelfTarget
+	}
+	return nil
+}
+
+// validateWitchSkillUse 按游戏规则校验女巫的解药和毒药
+func validateWitchSkillUse(use *SkillUse, state *State, config *GameConfig) error {
+	if use.TargetID == "" {
+		return nil
+	}
+
+	switch use.Skill {
+	case pb.SkillType_SKILL_TYPE_ANTIDOTE:
+		if !state.CanUseAntidote(use.PlayerID) {
+			return ErrNoAntidote
+		}
+		if use.PlayerID == use.TargetID && !config.WitchCanSaveSelf {
+			return ErrSelfTarget
+		}
+		if use.TargetID != state.RoundCtx.KillTarget {
+			return ErrTargetNotDying
+		}
+	case pb.SkillType_SKILL_TYPE_POISON:
+		if !state.CanUsePoison(use.PlayerID) {
+			return ErrNoPoison
+		}
+		if use.PlayerID == use.TargetID {
+			return ErrSelfTarget
+		}
+	}
+	return nil
+}
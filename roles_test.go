@@ -0,0 +1,259 @@
+package werewolf
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// 测试用的自定义角色和阶段（不在 proto 枚举中）
+const (
+	testRole  = pb.RoleType(100)
+	testPhase = pb.PhaseType(100)
+)
+
+// withTestRegistry 在测试期间使用注册表的副本，测试结束后恢复
+func withTestRegistry(t *testing.T) {
+	t.Helper()
+	rolesMu.Lock()
+	saved := roles
+	roles = make(map[pb.RoleType]*RoleDefinition, len(saved))
+	for role, def := range saved {
+		roles[role] = def
+	}
+	rolesMu.Unlock()
+
+	t.Cleanup(func() {
+		rolesMu.Lock()
+		roles = saved
+		rolesMu.Unlock()
+	})
+}
+
+// recordingResolver 记录收到的技能使用
+type recordingResolver struct {
+	uses []*SkillUse
+}
+
+func (r *recordingResolver) Resolve(uses []*SkillUse, state *State, config *GameConfig) []*Effect {
+	r.uses = append(r.uses, uses...)
+	return nil
+}
+
+// testRoleDefinition 夜晚在狼人之后查验的自定义角色
+func testRoleDefinition(resolver Resolver) RoleDefinition {
+	return RoleDefinition{
+		Role:   testRole,
+		Camp:   pb.Camp_CAMP_GOOD,
+		Skills: []pb.SkillType{pb.SkillType_SKILL_TYPE_CHECK},
+		NightPhase: func() *PhaseConfig {
+			return &PhaseConfig{
+				Type: testPhase,
+				Steps: []PhaseStep{
+					{Role: pb.RoleType_ROLE_TYPE_GOD, Skill: pb.SkillType_SKILL_TYPE_ANNOUNCE, Order: 0, Required: true},
+					{Role: testRole, Skill: pb.SkillType_SKILL_TYPE_CHECK, Order: 1},
+				},
+				Timeout: NightPhaseTimeout,
+			}
+		},
+		NightOrder: 25,
+		Resolver:   resolver,
+		CanTargetDead: func(skill pb.SkillType) bool {
+			return skill == pb.SkillType_SKILL_TYPE_CHECK
+		},
+		ValidateSkillUse: func(use *SkillUse, state *State, config *GameConfig) error {
+			if use.PlayerID == use.TargetID {
+				return ErrSkillNotAllowed
+			}
+			return nil
+		},
+		OnDeath: func(player PlayerInfo, cause pb.EventType, state *State) []*Effect {
+			return []*Effect{NewEffect(pb.EventType_EVENT_TYPE_SKIP, player.ID, "").WithData("cause", cause)}
+		},
+		RecordKnowledge: func(player PlayerInfo, trigger KnowledgeTrigger) []*pb.KnowledgeEntry {
+			if trigger.Enter != testPhase {
+				return nil
+			}
+			return []*pb.KnowledgeEntry{{Type: pb.KnowledgeType_KNOWLEDGE_TYPE_NIGHT_KILL, TargetId: trigger.Round.KillTarget}}
+		},
+	}
+}
+
+func TestRoleDefinitions_Builtin(t *testing.T) {
+	var got []pb.RoleType
+	for _, def := range RoleDefinitions() {
+		got = append(got, def.Role)
+	}
+	want := []pb.RoleType{
+		pb.RoleType_ROLE_TYPE_WEREWOLF,
+		pb.RoleType_ROLE_TYPE_SEER,
+		pb.RoleType_ROLE_TYPE_WITCH,
+		pb.RoleType_ROLE_TYPE_HUNTER,
+		pb.RoleType_ROLE_TYPE_VILLAGER,
+		pb.RoleType_ROLE_TYPE_GUARD,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected built-in roles %v, got %v", want, got)
+	}
+
+	// 默认配置的夜晚顺序由内置角色的 NightOrder 组装
+	config := DefaultGameConfig()
+	for phase, next := range map[pb.PhaseType]pb.PhaseType{
+		pb.PhaseType_PHASE_TYPE_NIGHT_GUARD: pb.PhaseType_PHASE_TYPE_NIGHT_WOLF,
+		pb.PhaseType_PHASE_TYPE_NIGHT_WOLF:  pb.PhaseType_PHASE_TYPE_NIGHT_WITCH,
+		pb.PhaseType_PHASE_TYPE_NIGHT_WITCH: pb.PhaseType_PHASE_TYPE_NIGHT_SEER,
+		pb.PhaseType_PHASE_TYPE_NIGHT_SEER:  pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE,
+	} {
+		if got := config.Phases[phase].NextPhase; got != next {
+			t.Errorf("expected %v -> %v, got %v", phase, next, got)
+		}
+	}
+
+	// 每个有玩家步骤的阶段都有解析器
+	resolvers := registeredResolvers()
+	if _, ok := resolvers[pb.PhaseType_PHASE_TYPE_DAY_HUNTER].(*HunterResolver); !ok {
+		t.Errorf("expected hunter resolver for DAY_HUNTER, got %T", resolvers[pb.PhaseType_PHASE_TYPE_DAY_HUNTER])
+	}
+	if len(resolvers) != len(config.Phases) {
+		t.Errorf("expected a resolver per default phase, got %d resolvers for %d phases", len(resolvers), len(config.Phases))
+	}
+}
+
+func TestRegisterRole_CustomRole(t *testing.T) {
+	withTestRegistry(t)
+	resolver := &recordingResolver{}
+	RegisterRole(testRoleDefinition(resolver))
+
+	if DefaultCamp(testRole) != pb.Camp_CAMP_GOOD {
+		t.Errorf("expected custom role camp GOOD, got %v", DefaultCamp(testRole))
+	}
+
+	config := DefaultGameConfig()
+	if next := config.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_WOLF].NextPhase; next != testPhase {
+		t.Fatalf("expected custom phase after wolves, got %v", next)
+	}
+	if next := config.Phases[testPhase].NextPhase; next != pb.PhaseType_PHASE_TYPE_NIGHT_WITCH {
+		t.Fatalf("expected witch after custom phase, got %v", next)
+	}
+	if err := config.ValidateBoard(Board{Roles: []pb.RoleType{testRole, pb.RoleType_ROLE_TYPE_WEREWOLF}}); err != nil {
+		t.Errorf("expected custom role to be dealt, got %v", err)
+	}
+
+	engine := newTestEngine(t, config)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("custom", testRole, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+	advancePhase(t, engine, testPhase)
+
+	// 记录钩子：进入自定义阶段时得知当晚被杀目标
+	knowledge := engine.state.GetKnowledge("custom")
+	if len(knowledge) != 1 || knowledge[0].Type != pb.KnowledgeType_KNOWLEDGE_TYPE_NIGHT_KILL || knowledge[0].Phase != testPhase {
+		t.Errorf("expected knowledge from record hook, got %v", knowledge)
+	}
+
+	// 阶段信息和可用技能来自阶段步骤
+	info := engine.GetPhaseInfo()
+	if len(info.ActiveRoles) != 1 || info.ActiveRoles[0] != testRole {
+		t.Fatalf("expected custom role to be active, got %v", info.ActiveRoles)
+	}
+	if roleInfo := info.RoleInfos[testRole]; !reflect.DeepEqual(roleInfo.PlayerIDs, []string{"custom"}) ||
+		!reflect.DeepEqual(roleInfo.AllowedSkills, []pb.SkillType{pb.SkillType_SKILL_TYPE_CHECK}) {
+		t.Errorf("expected custom role info, got %+v", roleInfo)
+	}
+	if view, _ := engine.GetPlayerView("custom"); !reflect.DeepEqual(view.AllowedSkills, []pb.SkillType{pb.SkillType_SKILL_TYPE_CHECK}) {
+		t.Errorf("expected CHECK in custom view, got %v", view.AllowedSkills)
+	}
+
+	if err := engine.SubmitSkillUse(&SkillUse{PlayerID: "custom", Skill: pb.SkillType_SKILL_TYPE_CHECK, TargetID: "custom"}); !errors.Is(err, ErrSkillNotAllowed) {
+		t.Errorf("expected validation hook to reject self target, got %v", err)
+	}
+	if err := engine.SubmitSkillUse(&SkillUse{PlayerID: "custom", Skill: pb.SkillType_SKILL_TYPE_CHECK, TargetID: "wolf1"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	engine.EndSubStep()
+	if len(resolver.uses) != 1 || resolver.uses[0].TargetID != "wolf1" {
+		t.Errorf("expected custom resolver to receive the check, got %+v", resolver.uses)
+	}
+
+	// 死亡钩子
+	effects := deathEffects(engine.state, "custom", pb.EventType_EVENT_TYPE_ELIMINATE)
+	if len(effects) != 1 || effects[0].Data["cause"] != pb.EventType_EVENT_TYPE_ELIMINATE {
+		t.Errorf("expected death hook effect, got %+v", effects)
+	}
+}
+
+func TestPhase_ValidateSkillUse_RoleHooks(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WITCH)
+	engine.state.players["v1"].Alive = false
+	engine.state.RoundCtx.KillTarget = "v1"
+
+	// 女巫的 CanTargetDead 只放开解药
+	if err := engine.SubmitSkillUse(&SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v1"}); err != nil {
+		t.Errorf("expected antidote on dead target, got %v", err)
+	}
+	if err := engine.SubmitSkillUse(&SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_POISON, TargetID: "v1"}); !errors.Is(err, ErrTargetDead) {
+		t.Errorf("expected ErrTargetDead for poison, got %v", err)
+	}
+}
+
+func TestValidate_RoleSkills(t *testing.T) {
+	config := DefaultGameConfig()
+	config.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_SEER].Steps = append(config.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_SEER].Steps,
+		PhaseStep{Role: pb.RoleType_ROLE_TYPE_SEER, Skill: pb.SkillType_SKILL_TYPE_KILL, Order: 2},
+		PhaseStep{Role: testRole, Skill: pb.SkillType_SKILL_TYPE_CHECK, Order: 3},
+	)
+
+	err := config.Validate()
+	for _, want := range []string{
+		"phase NIGHT_SEER step 2: role SEER has no skill KILL",
+		"phase NIGHT_SEER step 3: unknown role 100",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %v", want, err)
+		}
+	}
+}
+
+func TestRegisterRole_Panics(t *testing.T) {
+	withTestRegistry(t)
+
+	tests := map[string]RoleDefinition{
+		"god":         {Role: pb.RoleType_ROLE_TYPE_GOD, Camp: pb.Camp_CAMP_GOOD},
+		"no camp":     {Role: testRole},
+		"duplicate":   {Role: pb.RoleType_ROLE_TYPE_SEER, Camp: pb.Camp_CAMP_GOOD},
+		"no resolver": func() RoleDefinition { def := testRoleDefinition(nil); def.Resolver = nil; return def }(),
+		"owned phase": {
+			Role:       testRole,
+			Camp:       pb.Camp_CAMP_GOOD,
+			NightPhase: NightSeerPhase,
+			Resolver:   &recordingResolver{},
+		},
+		"shared phase": {
+			Role:       testRole,
+			Camp:       pb.Camp_CAMP_GOOD,
+			NightPhase: StandardDayPhase,
+			Resolver:   &recordingResolver{},
+		},
+	}
+	for name, def := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			RegisterRole(def)
+		})
+	}
+}
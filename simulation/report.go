@@ -0,0 +1,204 @@
+package simulation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// z95 95% 置信水平对应的正态分位数
+const z95 = 1.96
+
+// Interval 估计值及 95% 置信区间
+type Interval struct {
+	Value float64 `json:"value"`
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+}
+
+// DeathStat 某角色因某原因死亡的统计
+type DeathStat struct {
+	Role    string  `json:"role"`     // 如 SEER
+	Cause   string  `json:"cause"`    // kill、poison、vote、shoot
+	Count   int     `json:"count"`    // 总次数
+	PerGame float64 `json:"per_game"` // 平均每局次数
+}
+
+// Report 场景报告
+type Report struct {
+	Scenario    string      `json:"scenario"`
+	Board       string      `json:"board"`
+	Rules       string      `json:"rules"`
+	Games       int         `json:"games"`
+	GoodWins    int         `json:"good_wins"`
+	EvilWins    int         `json:"evil_wins"`
+	Unfinished  int         `json:"unfinished"`
+	GoodWinRate Interval    `json:"good_win_rate"` // Wilson 区间
+	EvilWinRate Interval    `json:"evil_win_rate"` // Wilson 区间
+	AvgRounds   Interval    `json:"avg_rounds"`    // 已完成对局的平均回合数（正态近似区间）
+	Deaths      []DeathStat `json:"deaths"`
+}
+
+// causeNames 死亡原因名称
+var causeNames = map[pb.EventType]string{
+	pb.EventType_EVENT_TYPE_KILL:      "kill",
+	pb.EventType_EVENT_TYPE_POISON:    "poison",
+	pb.EventType_EVENT_TYPE_ELIMINATE: "vote",
+	pb.EventType_EVENT_TYPE_SHOOT:     "shoot",
+}
+
+// newReport 汇总单个场景的对局结果
+func newReport(scenario Scenario, results []GameResult) Report {
+	report := Report{
+		Scenario: scenario.Name,
+		Board:    scenario.Board.Name,
+		Rules:    FormatRules(scenario.Rules),
+		Games:    len(results),
+	}
+
+	type deathKey struct {
+		role  pb.RoleType
+		cause pb.EventType
+	}
+	deaths := make(map[deathKey]int)
+	var rounds []float64
+
+	for _, result := range results {
+		switch {
+		case !result.Finished:
+			report.Unfinished++
+		case result.Winner == pb.Camp_CAMP_GOOD:
+			report.GoodWins++
+		case result.Winner == pb.Camp_CAMP_EVIL:
+			report.EvilWins++
+		}
+		if result.Finished {
+			rounds = append(rounds, float64(result.Rounds))
+		}
+		for _, death := range result.Deaths {
+			deaths[deathKey{death.Role, death.Cause}]++
+		}
+	}
+
+	report.GoodWinRate = wilson(report.GoodWins, report.Games)
+	report.EvilWinRate = wilson(report.EvilWins, report.Games)
+	report.AvgRounds = meanInterval(rounds)
+
+	for key, count := range deaths {
+		stat := DeathStat{
+			Role:  strings.TrimPrefix(key.role.String(), "ROLE_TYPE_"),
+			Cause: causeNames[key.cause],
+			Count: count,
+		}
+		if report.Games > 0 {
+			stat.PerGame = float64(count) / float64(report.Games)
+		}
+		report.Deaths = append(report.Deaths, stat)
+	}
+	sort.Slice(report.Deaths, func(i, j int) bool {
+		a, b := report.Deaths[i], report.Deaths[j]
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		return a.Cause < b.Cause
+	})
+
+	return report
+}
+
+// wilson 二项比例的 Wilson 置信区间（样本少或比例接近 0/1 时比正态近似更稳定）
+func wilson(successes, n int) Interval {
+	if n == 0 {
+		return Interval{}
+	}
+	p := float64(successes) / float64(n)
+	nf := float64(n)
+	z2 := z95 * z95
+
+	center := (p + z2/(2*nf)) / (1 + z2/nf)
+	margin := z95 * math.Sqrt(p*(1-p)/nf+z2/(4*nf*nf)) / (1 + z2/nf)
+	return Interval{Value: p, Low: math.Max(0, center-margin), High: math.Min(1, center+margin)}
+}
+
+// meanInterval 均值及正态近似置信区间
+func meanInterval(values []float64) Interval {
+	n := len(values)
+	if n == 0 {
+		return Interval{}
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(n)
+	if n == 1 {
+		return Interval{Value: mean, Low: mean, High: mean}
+	}
+
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	margin := z95 * math.Sqrt(sq/float64(n-1)) / math.Sqrt(float64(n))
+	return Interval{Value: mean, Low: mean - margin, High: mean + margin}
+}
+
+// WriteJSON 以 JSON 数组输出报告
+func WriteJSON(w io.Writer, reports []Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reports)
+}
+
+// csvHeader CSV 列
+var csvHeader = []string{
+	"scenario", "board", "rules", "games",
+	"good_wins", "evil_wins", "unfinished",
+	"good_win_rate", "good_win_low", "good_win_high",
+	"evil_win_rate", "evil_win_low", "evil_win_high",
+	"avg_rounds", "avg_rounds_low", "avg_rounds_high",
+	"deaths",
+}
+
+// WriteCSV 以 CSV 输出报告，每个场景一行
+// deaths 列格式为 "ROLE:cause=count;..."
+func WriteCSV(w io.Writer, reports []Report) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, r := range reports {
+		deaths := make([]string, len(r.Deaths))
+		for i, d := range r.Deaths {
+			deaths[i] = fmt.Sprintf("%s:%s=%d", d.Role, d.Cause, d.Count)
+		}
+
+		record := []string{
+			r.Scenario, r.Board, r.Rules, strconv.Itoa(r.Games),
+			strconv.Itoa(r.GoodWins), strconv.Itoa(r.EvilWins), strconv.Itoa(r.Unfinished),
+			formatFloat(r.GoodWinRate.Value), formatFloat(r.GoodWinRate.Low), formatFloat(r.GoodWinRate.High),
+			formatFloat(r.EvilWinRate.Value), formatFloat(r.EvilWinRate.Low), formatFloat(r.EvilWinRate.High),
+			formatFloat(r.AvgRounds.Value), formatFloat(r.AvgRounds.Low), formatFloat(r.AvgRounds.High),
+			strings.Join(deaths, ";"),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
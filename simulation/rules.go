@@ -0,0 +1,82 @@
+package simulation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Zereker/werewolf"
+)
+
+// 规则变体的简写名称（用于命令行和报告）
+const (
+	RuleWitchSelfSave      = "witch-self-save"
+	RuleGuardSelf          = "guard-self"
+	RuleGuardRepeat        = "guard-repeat"
+	RuleSameGuardKillEmpty = "same-guard-kill-empty"
+)
+
+// ruleFields 简写名称到规则字段的映射（按报告中的输出顺序）
+var ruleFields = []struct {
+	name  string
+	field func(r *werewolf.Rules) *bool
+}{
+	{RuleWitchSelfSave, func(r *werewolf.Rules) *bool { return &r.WitchCanSaveSelf }},
+	{RuleGuardSelf, func(r *werewolf.Rules) *bool { return &r.GuardCanProtectSelf }},
+	{RuleGuardRepeat, func(r *werewolf.Rules) *bool { return &r.GuardCanRepeat }},
+	{RuleSameGuardKillEmpty, func(r *werewolf.Rules) *bool { return &r.SameGuardKillIsEmpty }},
+}
+
+// DefaultRules 默认规则变体（与 DefaultGameConfig 一致）
+func DefaultRules() werewolf.Rules {
+	return werewolf.DefaultGameConfig().GetRules()
+}
+
+// ParseRules 解析规则描述，在默认规则基础上覆盖
+// 格式为逗号分隔的 name=bool，如 "guard-repeat=true,same-guard-kill-empty=false"；
+// "default" 或空字符串表示默认规则
+func ParseRules(spec string) (werewolf.Rules, error) {
+	rules := DefaultRules()
+
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == "default" {
+		return rules, nil
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return rules, fmt.Errorf("invalid rule %q (expected name=true|false)", part)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return rules, fmt.Errorf("invalid value for rule %q: %w", name, err)
+		}
+
+		field := lookupRule(&rules, strings.TrimSpace(name))
+		if field == nil {
+			return rules, fmt.Errorf("unknown rule %q", name)
+		}
+		*field = enabled
+	}
+	return rules, nil
+}
+
+// FormatRules 格式化规则变体（ParseRules 的逆操作，列出所有规则）
+func FormatRules(rules werewolf.Rules) string {
+	parts := make([]string, 0, len(ruleFields))
+	for _, f := range ruleFields {
+		parts = append(parts, fmt.Sprintf("%s=%t", f.name, *f.field(&rules)))
+	}
+	return strings.Join(parts, ",")
+}
+
+// lookupRule 根据简写名称获取规则字段
+func lookupRule(rules *werewolf.Rules, name string) *bool {
+	for _, f := range ruleFields {
+		if f.name == name {
+			return f.field(rules)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Package simulation 提供蒙特卡洛模拟
+//
+// 在不同板子和规则变体下用机器人策略并行跑大量带种子的对局，
+// 统计各阵营胜率（含置信区间）、平均对局回合数以及各角色的死亡原因，用于比较规则配置的平衡性。
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/Zereker/werewolf"
+	"github.com/Zereker/werewolf/bot"
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// DefaultMaxRounds 单局最大回合数，超过视为未完成（防止机器人陷入僵局）
+const DefaultMaxRounds = 30
+
+// Scenario 模拟场景：板子 + 规则变体
+type Scenario struct {
+	Name  string
+	Board werewolf.Board
+	Rules werewolf.Rules
+}
+
+// NewScenario 创建场景，名称为 "板子/规则"
+func NewScenario(board werewolf.Board, rules werewolf.Rules) Scenario {
+	return Scenario{
+		Name:  board.Name + "/" + FormatRules(rules),
+		Board: board,
+		Rules: rules,
+	}
+}
+
+// Config 模拟配置
+type Config struct {
+	Games     int   // 每个场景的对局数
+	Seed      int64 // 起始种子，第 i 局使用 Seed+i
+	Workers   int   // 并行数，0 表示 runtime.NumCPU()
+	MaxRounds int   // 单局最大回合数，0 表示 DefaultMaxRounds
+}
+
+// Death 一次死亡记录
+type Death struct {
+	PlayerID string
+	Role     pb.RoleType
+	Cause    pb.EventType // KILL、POISON、ELIMINATE、SHOOT
+	Round    int
+}
+
+// GameResult 单局结果
+type GameResult struct {
+	Seed     int64
+	Winner   pb.Camp // 未完成时为 CAMP_UNSPECIFIED
+	Rounds   int
+	Finished bool
+	Deaths   []Death
+}
+
+// Run 依次模拟所有场景，返回每个场景的报告
+func Run(ctx context.Context, scenarios []Scenario, config Config) ([]Report, error) {
+	reports := make([]Report, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		report, err := RunScenario(ctx, scenario, config)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// RunScenario 并行模拟一个场景的所有对局
+// 结果按种子顺序汇总，相同配置的报告可复现
+func RunScenario(ctx context.Context, scenario Scenario, config Config) (Report, error) {
+	workers := config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]GameResult, config.Games)
+	errs := make([]error, config.Games)
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = PlayGame(scenario, config.Seed+int64(i), config.MaxRounds)
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < config.Games; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return Report{}, err
+	}
+	for i, err := range errs {
+		if err != nil {
+			return Report{}, fmt.Errorf("scenario %s seed %d: %w", scenario.Name, config.Seed+int64(i), err)
+		}
+	}
+	return newReport(scenario, results), nil
+}
+
+// PlayGame 用默认机器人策略模拟一局
+func PlayGame(scenario Scenario, seed int64, maxRounds int) (GameResult, error) {
+	if maxRounds <= 0 {
+		maxRounds = DefaultMaxRounds
+	}
+
+	result := GameResult{Seed: seed}
+	engine, err := werewolf.NewEngine(nil)
+	if err != nil {
+		return result, err
+	}
+	engine.SetSeed(seed)
+
+	engine.OnEvent(func(event *pb.Event) {
+		switch event.Type {
+		case pb.EventType_EVENT_TYPE_KILL,
+			pb.EventType_EVENT_TYPE_ELIMINATE,
+			pb.EventType_EVENT_TYPE_SHOOT:
+		case pb.EventType_EVENT_TYPE_POISON:
+			// 带来源的毒药事件只是女巫的提交，夜晚结算时才真正死亡
+			if event.SourceId != "" {
+				return
+			}
+		case pb.EventType_EVENT_TYPE_GAME_ENDED:
+			result.Winner = event.GetGameEnded().GetWinner()
+			return
+		default:
+			return
+		}
+		info, _ := engine.GetPlayerInfo(event.TargetId)
+		result.Deaths = append(result.Deaths, Death{
+			PlayerID: event.TargetId,
+			Role:     info.Role,
+			Cause:    event.Type,
+			Round:    engine.GetCurrentRound(),
+		})
+	})
+
+	driver := bot.NewDriver(engine)
+	if err := engine.SetBoard("", scenario.Board); err != nil {
+		return result, err
+	}
+	for seat := 1; seat <= scenario.Board.Size(); seat++ {
+		id := fmt.Sprintf("p%d", seat)
+		if _, err := engine.Join(id); err != nil {
+			return result, err
+		}
+		if err := engine.SetReady(id, true); err != nil {
+			return result, err
+		}
+		driver.Add(id, bot.NewStrategyAgent(id, seed*int64(scenario.Board.Size()+1)+int64(seat)))
+	}
+	// 和真实对局一样由房主在大厅中选择规则，机器人通过规则变更事件得知规则
+	if err := engine.SetRules("p1", scenario.Rules); err != nil {
+		return result, err
+	}
+	if err := engine.Start(); err != nil {
+		return result, err
+	}
+
+	for !engine.IsGameOver() && engine.GetCurrentRound() <= maxRounds {
+		if err := driver.PlayPhase(); err != nil {
+			return result, err
+		}
+		if _, err := engine.EndSubStep(); err != nil {
+			return result, err
+		}
+	}
+
+	result.Finished = engine.IsGameOver()
+	result.Rounds = engine.GetCurrentRound()
+	return result, nil
+}
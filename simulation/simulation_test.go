@@ -0,0 +1,134 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Zereker/werewolf"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("guard-repeat=true, same-guard-kill-empty=false")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := DefaultRules()
+	want.GuardCanRepeat = true
+	want.SameGuardKillIsEmpty = false
+	if rules != want {
+		t.Errorf("expected %+v, got %+v", want, rules)
+	}
+
+	// FormatRules 与 ParseRules 互逆
+	if parsed, err := ParseRules(FormatRules(rules)); err != nil || parsed != rules {
+		t.Errorf("expected round trip, got %+v (%v)", parsed, err)
+	}
+
+	if rules, _ := ParseRules("default"); rules != DefaultRules() {
+		t.Errorf("expected default rules, got %+v", rules)
+	}
+	for _, spec := range []string{"guard-repeat", "guard-repeat=maybe", "fly=true"} {
+		if _, err := ParseRules(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestRunScenario(t *testing.T) {
+	board, _ := werewolf.LookupBoard(werewolf.BoardTwelvePlayers)
+	scenario := NewScenario(board, DefaultRules())
+
+	report, err := RunScenario(context.Background(), scenario, Config{Games: 40, Seed: 1, Workers: 4})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if report.Games != 40 || report.GoodWins+report.EvilWins+report.Unfinished != 40 {
+		t.Errorf("expected outcomes to add up to 40 games, got %+v", report)
+	}
+	for _, rate := range []Interval{report.GoodWinRate, report.EvilWinRate, report.AvgRounds} {
+		if rate.Low > rate.Value || rate.Value > rate.High {
+			t.Errorf("expected value within interval, got %+v", rate)
+		}
+	}
+	if report.AvgRounds.Value < 1 {
+		t.Errorf("expected games to last at least one round, got %v", report.AvgRounds.Value)
+	}
+	if len(report.Deaths) == 0 {
+		t.Error("expected death statistics")
+	}
+
+	// 并行度不影响结果
+	serial, _ := RunScenario(context.Background(), scenario, Config{Games: 40, Seed: 1, Workers: 1})
+	if !reflect.DeepEqual(report, serial) {
+		t.Errorf("expected reproducible report, got\n%+v\n%+v", report, serial)
+	}
+}
+
+func TestRunScenario_Cancelled(t *testing.T) {
+	board, _ := werewolf.LookupBoard(werewolf.BoardSixPlayers)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := RunScenario(ctx, NewScenario(board, DefaultRules()), Config{Games: 10}); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWilson(t *testing.T) {
+	got := wilson(50, 100)
+	if math.Abs(got.Low-0.4038) > 0.001 || math.Abs(got.High-0.5962) > 0.001 {
+		t.Errorf("unexpected Wilson interval %+v", got)
+	}
+	if got := wilson(0, 10); got.Low != 0 || got.High <= 0 {
+		t.Errorf("expected [0, >0] for zero successes, got %+v", got)
+	}
+}
+
+func TestWriteReports(t *testing.T) {
+	var reports []Report
+	for _, name := range []string{werewolf.BoardSixPlayers, werewolf.BoardNinePlayers} {
+		board, _ := werewolf.LookupBoard(name)
+		rules, _ := ParseRules("same-guard-kill-empty=false")
+		reports = append(reports, runReport(t, NewScenario(board, rules)))
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, reports); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("expected valid CSV, got %v", err)
+	}
+	if len(records) != 3 || !reflect.DeepEqual(records[0], csvHeader) {
+		t.Errorf("expected header and 2 rows, got %v", records)
+	}
+
+	buf.Reset()
+	if err := WriteJSON(&buf, reports); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var decoded []Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+	if !reflect.DeepEqual(decoded, reports) {
+		t.Errorf("expected JSON round trip, got %+v", decoded)
+	}
+}
+
+// runReport 跑少量对局生成报告
+func runReport(t *testing.T, s Scenario) Report {
+	t.Helper()
+	report, err := RunScenario(context.Background(), s, Config{Games: 5, Seed: 7})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return report
+}
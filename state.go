@@ -1,6 +1,8 @@
 package werewolf
 
 import (
+	"math/rand"
+	"sort"
 	"sync"
 
 	pb "github.com/Zereker/werewolf/proto"
@@ -16,6 +18,8 @@ type RoundContext struct {
 	PoisonedPlayers   map[string]bool // 被女巫毒的玩家
 	HunterTriggered   bool            // 猎人是否被触发（死亡时）
 	TriggeredHunterID string          // 被触发的猎人ID
+
+	ext map[string]any // 回合扩展数据（见 RoundKey）
 }
 
 // NewRoundContext 创建新的回合上下文
@@ -54,6 +58,7 @@ func (rc *RoundContext) IsPoisoned(playerID string) bool {
 // PlayerState 玩家状态
 type PlayerState struct {
 	ID    string
+	Seat  int // 座位号（按加入顺序，从 1 开始）
 	Role  pb.RoleType
 	Camp  pb.Camp
 	Alive bool
@@ -64,6 +69,9 @@ type PlayerState struct {
 
 	// 守卫连续保护限制
 	LastProtectedTarget string // 上一回合保护的目标
+
+	ext       map[string]any       // 玩家扩展数据（见 PlayerKey）
+	knowledge []*pb.KnowledgeEntry // 私有信息记录（见 GetKnowledge）
 }
 
 // State 游戏状态
@@ -96,16 +104,27 @@ type State struct {
 
 	// 回合临时上下文（每个回合重新创建）
 	RoundCtx *RoundContext
+
+	// 随机源（解析器的随机规则使用，与引擎共用以便 SetSeed 复现）
+	rng *rand.Rand
 }
 
 // NewState 创建游戏状态
 func NewState() *State {
 	return &State{
-		Phase:   pb.PhaseType_PHASE_TYPE_START,
-		Round:   0,
-		players: make(map[string]*PlayerState),
-		RoundCtx:  NewRoundContext(),
+		Phase:    pb.PhaseType_PHASE_TYPE_START,
+		Round:    0,
+		players:  make(map[string]*PlayerState),
+		RoundCtx: NewRoundContext(),
+	}
+}
+
+// randIntn 返回 [0, n) 的随机数（调用方需持有引擎锁，rng 非并发安全）
+func (s *State) randIntn(n int) int {
+	if s.rng == nil {
+		return rand.Intn(n)
 	}
+	return s.rng.Intn(n)
 }
 
 // AddPlayer 添加玩家
@@ -118,10 +137,14 @@ func (s *State) AddPlayer(id string, role pb.RoleType, camp pb.Camp) {
 	// 目前采用覆盖策略，允许重新设置玩家属性
 	player := &PlayerState{
 		ID:    id,
+		Seat:  len(s.players) + 1,
 		Role:  role,
 		Camp:  camp,
 		Alive: true,
 	}
+	if existing, ok := s.players[id]; ok {
+		player.Seat = existing.Seat
+	}
 
 	// 女巫初始有解药和毒药各一瓶
 	if role == pb.RoleType_ROLE_TYPE_WITCH {
@@ -144,6 +167,7 @@ func (s *State) AddPlayerIfNotExists(id string, role pb.RoleType, camp pb.Camp)
 
 	player := &PlayerState{
 		ID:    id,
+		Seat:  len(s.players) + 1,
 		Role:  role,
 		Camp:  camp,
 		Alive: true,
@@ -172,6 +196,7 @@ func (s *State) getPlayer(id string) (*PlayerState, bool) {
 // PlayerInfo 玩家信息只读视图
 type PlayerInfo struct {
 	ID          string
+	Seat        int
 	Role        pb.RoleType
 	Camp        pb.Camp
 	Alive       bool
@@ -189,16 +214,21 @@ func (s *State) GetPlayerInfo(id string) (PlayerInfo, bool) {
 	if !ok {
 		return PlayerInfo{}, false
 	}
+	return s.playerInfoLocked(p), true
+}
 
+// playerInfoLocked 构建玩家信息（调用方持有锁）
+func (s *State) playerInfoLocked(p *PlayerState) PlayerInfo {
 	return PlayerInfo{
 		ID:          p.ID,
+		Seat:        p.Seat,
 		Role:        p.Role,
 		Camp:        p.Camp,
 		Alive:       p.Alive,
-		Protected:   s.RoundCtx.IsProtected(id), // 从 RoundContext 获取
+		Protected:   s.RoundCtx.IsProtected(p.ID), // 从 RoundContext 获取
 		HasAntidote: p.HasAntidote,
 		HasPoison:   p.HasPoison,
-	}, true
+	}
 }
 
 // getAlivePlayers 获取存活玩家（包内使用）
@@ -271,8 +301,69 @@ func (s *State) getAlivePlayerIDs() []string {
 	return result
 }
 
+// getAlivePlayerIDsBySeat 获取存活玩家ID列表，按座位排序（包内使用）
+func (s *State) getAlivePlayerIDsBySeat() []string {
+	alive := make(map[string]bool)
+	for _, id := range s.getAlivePlayerIDs() {
+		alive[id] = true
+	}
+	return s.sortBySeat(alive)
+}
+
+// sortBySeat 将玩家ID集合按座位排序，忽略不存在的玩家（包内使用）
+func (s *State) sortBySeat(ids map[string]bool) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]string, 0, len(ids))
+	for id := range ids {
+		if _, ok := s.players[id]; ok {
+			result = append(result, id)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return s.players[result[i]].Seat < s.players[result[j]].Seat
+	})
+	return result
+}
+
+// playerCount 获取玩家总数（包内使用）
+func (s *State) playerCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.players)
+}
+
+// getPlayerIDs 获取所有玩家ID列表（按座位号排序，包内使用）
+func (s *State) getPlayerIDs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]string, 0, len(s.players))
+	for id := range s.players {
+		result = append(result, id)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := s.players[result[i]], s.players[result[j]]
+		if a.Seat != b.Seat {
+			return a.Seat < b.Seat
+		}
+		return a.ID < b.ID
+	})
+	return result
+}
+
 // ApplyEffect 应用效果
+// 内置效果类型在这里处理，其他类型交给 RegisterEffectHandler 注册的处理器
 func (s *State) ApplyEffect(effect *Effect) {
+	// 自定义效果处理器不持有 State 锁，可以使用 State 的公开方法和扩展数据
+	if handler, ok := LookupEffectHandler(effect.Type); ok {
+		if !effect.Canceled {
+			handler(s, effect)
+		}
+		return
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -330,6 +421,8 @@ func (s *State) ApplyEffect(effect *Effect) {
 		s.RoundCtx.HunterTriggered = true
 		s.RoundCtx.TriggeredHunterID = effect.SourceID
 	}
+
+	s.recordEffectKnowledgeLocked(effect)
 }
 
 // ResetRoundState 重置回合状态（每回合开始时调用）
@@ -352,12 +445,14 @@ func (s *State) NextPhase(phase pb.PhaseType) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.recordPhaseKnowledgeLocked(KnowledgeTrigger{Exit: s.Phase})
 	s.Phase = phase
 	// 进入新的夜晚（守卫阶段）时增加回合数并重置状态
 	if phase == pb.PhaseType_PHASE_TYPE_NIGHT_GUARD {
 		s.Round++
 		s.resetRoundStateUnlocked()
 	}
+	s.recordPhaseKnowledgeLocked(KnowledgeTrigger{Enter: phase})
 }
 
 // GetWolfTeammates 获取狼人队友（不包括自己）
@@ -383,6 +478,12 @@ func (s *State) GetWolfTeammates(playerID string) []string {
 
 // CheckVictory 检查胜利条件
 func (s *State) CheckVictory() (bool, pb.Camp) {
+	gameOver, winner, _ := s.checkVictoryReason()
+	return gameOver, winner
+}
+
+// checkVictoryReason 检查胜利条件并给出胜负原因
+func (s *State) checkVictoryReason() (bool, pb.Camp, pb.VictoryReason) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -403,15 +504,15 @@ func (s *State) CheckVictory() (bool, pb.Camp) {
 
 	// 狼人全死，好人胜利
 	if evilAlive == 0 {
-		return true, pb.Camp_CAMP_GOOD
+		return true, pb.Camp_CAMP_GOOD, pb.VictoryReason_VICTORY_REASON_WOLVES_ELIMINATED
 	}
 
 	// 好人数量 <= 狼人数量，狼人胜利
 	if goodAlive <= evilAlive {
-		return true, pb.Camp_CAMP_EVIL
+		return true, pb.Camp_CAMP_EVIL, pb.VictoryReason_VICTORY_REASON_WOLVES_OUTNUMBER
 	}
 
-	return false, pb.Camp_CAMP_UNSPECIFIED
+	return false, pb.Camp_CAMP_UNSPECIFIED, pb.VictoryReason_VICTORY_REASON_UNSPECIFIED
 }
 
 // UseAntidote 女巫使用解药
@@ -512,18 +613,23 @@ func (s *State) GetRoundContext() *RoundContext {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if s.RoundCtx == nil {
+	// 返回副本以避免外部修改
+	return s.RoundCtx.clone()
+}
+
+// clone 复制回合上下文（包括扩展数据）
+func (rc *RoundContext) clone() *RoundContext {
+	if rc == nil {
 		return nil
 	}
-
-	// 返回副本以避免外部修改
 	return &RoundContext{
-		KillTarget:        s.RoundCtx.KillTarget,
-		ProtectedPlayers:  copyStringBoolMap(s.RoundCtx.ProtectedPlayers),
-		SavedPlayers:      copyStringBoolMap(s.RoundCtx.SavedPlayers),
-		PoisonedPlayers:   copyStringBoolMap(s.RoundCtx.PoisonedPlayers),
-		HunterTriggered:   s.RoundCtx.HunterTriggered,
-		TriggeredHunterID: s.RoundCtx.TriggeredHunterID,
+		KillTarget:        rc.KillTarget,
+		ProtectedPlayers:  copyStringBoolMap(rc.ProtectedPlayers),
+		SavedPlayers:      copyStringBoolMap(rc.SavedPlayers),
+		PoisonedPlayers:   copyStringBoolMap(rc.PoisonedPlayers),
+		HunterTriggered:   rc.HunterTriggered,
+		TriggeredHunterID: rc.TriggeredHunterID,
+		ext:               copyExtensions(rc.ext),
 	}
 }
 
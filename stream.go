@@ -0,0 +1,127 @@
+package werewolf
+
+import (
+	"context"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// DefaultStreamBuffer Subscribe 未指定 WithBuffer 时的缓冲区大小
+const DefaultStreamBuffer = 64
+
+// StreamItem 事件流中的一项（事件或消息）
+type StreamItem struct {
+	Sequence    uint64    // 本局内的发布序号
+	Event       *pb.Event // 事件（与 Message 二选一）
+	Message     *Message  // 消息
+	ReceiverIDs []string  // 消息的接收者
+}
+
+// StreamFilter 事件流过滤条件，零值表示接收所有事件和消息
+type StreamFilter struct {
+	EventTypes []pb.EventType // 只接收这些类型的事件，为空时不按类型过滤
+	PlayerID   string         // 只接收该玩家可见的事件（迷雾过滤）和发给该玩家的消息
+	Phases     []pb.PhaseType // 只接收这些阶段内的事件（按 Event.PhaseType）和消息
+	EventsOnly bool           // 不接收消息
+}
+
+// match 是否通过过滤
+func (f *StreamFilter) match(item busItem) bool {
+	if item.event != nil {
+		return f.matchEvent(item.event)
+	}
+	return f.matchMessage(item.msg, item.receiverIDs)
+}
+
+func (f *StreamFilter) matchEvent(event *pb.Event) bool {
+	if len(f.EventTypes) > 0 && !containsEventType(f.EventTypes, event.Type) {
+		return false
+	}
+	if len(f.Phases) > 0 && !containsPhase(f.Phases, event.PhaseType) {
+		return false
+	}
+	return f.PlayerID == "" || eventVisibleTo(event, f.PlayerID)
+}
+
+func (f *StreamFilter) matchMessage(msg *Message, receiverIDs []string) bool {
+	if f.EventsOnly {
+		return false
+	}
+	if len(f.Phases) > 0 && !containsPhase(f.Phases, msg.Phase) {
+		return false
+	}
+	if f.PlayerID == "" {
+		return true
+	}
+	for _, id := range receiverIDs {
+		if id == f.PlayerID {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe 以 channel 形式订阅事件和消息
+// channel 在 ctx 结束、GAME_ENDED 投递之后或订阅因缓冲区溢出被断开时关闭；
+// GAME_ENDED 已开始投递后订阅时只补发 GAME_ENDED（通过过滤时）后关闭。
+// 投递总是异步的（默认缓冲区 DefaultStreamBuffer，可通过 WithBuffer/WithOverflow 调整），
+// 消费方在循环中调用 EndPhase 等引擎方法不会死锁；不再读取时应取消 ctx。
+func (e *Engine) Subscribe(ctx context.Context, filter StreamFilter, opts ...SubscribeOption) <-chan StreamItem {
+	options := applySubscribeOptions(opts)
+	if options.buffer <= 0 {
+		options.buffer = DefaultStreamBuffer
+	}
+
+	out := make(chan StreamItem)
+	var sub *Subscription
+	sub = e.bus.newSubscription(func(item busItem) {
+		if filter.match(item) {
+			streamItem := StreamItem{Event: item.event, Message: item.msg, ReceiverIDs: item.receiverIDs}
+			if item.event != nil {
+				streamItem.Sequence = item.event.Sequence
+			} else {
+				streamItem.Sequence = item.msg.Sequence
+			}
+			select {
+			case out <- streamItem:
+			case <-ctx.Done():
+			case <-sub.Done():
+			}
+		}
+		if item.event != nil && item.event.Type == pb.EventType_EVENT_TYPE_GAME_ENDED {
+			sub.Unsubscribe()
+		}
+	}, options)
+	e.bus.addUntilEnded(sub)
+
+	// 投递循环退出后再关闭 channel，保证不会向已关闭的 channel 发送
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Unsubscribe()
+		case <-sub.Done():
+		}
+		<-sub.stopped
+		close(out)
+	}()
+
+	return out
+}
+
+func containsEventType(types []pb.EventType, t pb.EventType) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPhase(phases []pb.PhaseType, phase pb.PhaseType) bool {
+	for _, v := range phases {
+		if v == phase {
+			return true
+		}
+	}
+	return false
+}
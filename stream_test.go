@@ -0,0 +1,193 @@
+package werewolf
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// collectStream 读取 channel 直到关闭，超时则测试失败（可在其他 goroutine 中调用）
+func collectStream(t *testing.T, ch <-chan StreamItem) []StreamItem {
+	t.Helper()
+	var items []StreamItem
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case item, ok := <-ch:
+			if !ok {
+				return items
+			}
+			items = append(items, item)
+		case <-timeout:
+			t.Errorf("timed out waiting for stream to close, got %d items", len(items))
+			return items
+		}
+	}
+}
+
+func TestEngine_Subscribe_DrivesGameUntilEnd(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("seer", pb.RoleType_ROLE_TYPE_SEER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := engine.Subscribe(ctx, StreamFilter{PlayerID: "v1"})
+	engine.Start()
+
+	// 消费方在循环中推进游戏：每个阶段开始时提交技能并结束阶段
+	var items []StreamItem
+	for item := range stream {
+		items = append(items, item)
+		if item.Event.GetType() != pb.EventType_EVENT_TYPE_PHASE_STARTED {
+			continue
+		}
+		switch item.Event.GetPhase().GetPhase() {
+		case pb.PhaseType_PHASE_TYPE_NIGHT_WOLF:
+			engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "seer"})
+		case pb.PhaseType_PHASE_TYPE_NIGHT_SEER:
+			engine.SubmitSkillUse(&SkillUse{PlayerID: "seer", Skill: pb.SkillType_SKILL_TYPE_CHECK, TargetID: "wolf1"})
+		}
+		engine.EndPhase()
+	}
+
+	last := items[len(items)-1]
+	if last.Event.GetType() != pb.EventType_EVENT_TYPE_GAME_ENDED {
+		t.Fatalf("expected stream to end with GAME_ENDED, got %v", last.Event)
+	}
+	for i, item := range items {
+		if item.Event.GetType() == pb.EventType_EVENT_TYPE_CHECK {
+			t.Errorf("expected seer's check to be hidden from v1")
+		}
+		if i > 0 && item.Sequence <= items[i-1].Sequence {
+			t.Fatalf("expected increasing sequence numbers, got %d after %d", item.Sequence, items[i-1].Sequence)
+		}
+	}
+
+	// 游戏结束后订阅只补发 GAME_ENDED
+	if items := collectStream(t, engine.Subscribe(ctx, StreamFilter{})); len(items) != 1 || items[0].Sequence != last.Sequence {
+		t.Errorf("expected only GAME_ENDED after game end, got %+v", items)
+	}
+	if items := collectStream(t, engine.Subscribe(ctx, StreamFilter{EventsOnly: true, EventTypes: []pb.EventType{pb.EventType_EVENT_TYPE_PHASE_STARTED}})); len(items) != 0 {
+		t.Errorf("expected filtered stream to close without items, got %+v", items)
+	}
+}
+
+func TestEngine_Subscribe_BeforeGameEndedDelivered(t *testing.T) {
+	engine := newBusTestEngine(t)
+	engine.Start()
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_VOTE)
+
+	// 出局事件投递时游戏已结束，GAME_ENDED 还在队列中：此时订阅的流仍收到 GAME_ENDED
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var stream <-chan StreamItem
+	engine.SubscribeEvents(func(event *pb.Event) {
+		if event.Type == pb.EventType_EVENT_TYPE_ELIMINATE {
+			if !engine.IsGameOver() {
+				t.Error("expected game over when ELIMINATE is delivered")
+			}
+			stream = engine.Subscribe(ctx, StreamFilter{EventsOnly: true})
+		}
+	})
+	for _, id := range []string{"v1", "v2", "v3"} {
+		engine.SubmitSkillUse(&SkillUse{PlayerID: id, Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "wolf1"})
+	}
+	engine.EndPhase()
+
+	if stream == nil {
+		t.Fatal("expected ELIMINATE to be delivered")
+	}
+	items := collectStream(t, stream)
+	if len(items) == 0 || items[len(items)-1].Event.GetType() != pb.EventType_EVENT_TYPE_GAME_ENDED {
+		t.Errorf("expected stream to close after GAME_ENDED, got %+v", items)
+	}
+}
+
+func TestEngine_Subscribe_ContextCancel(t *testing.T) {
+	engine := newBusTestEngine(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := engine.Subscribe(ctx, StreamFilter{})
+	engine.Start()
+
+	// 不读取就取消：channel 仍会关闭，发布方不会被阻塞
+	cancel()
+	collectStream(t, stream)
+	if _, err := engine.EndPhase(); err != nil {
+		t.Fatalf("EndPhase failed: %v", err)
+	}
+}
+
+func TestEngine_Subscribe_Filters(t *testing.T) {
+	engine := newBusTestEngine(t)
+
+	// 并发读取各个流，直到游戏结束时关闭
+	collect := func(filter StreamFilter) <-chan []StreamItem {
+		stream := engine.Subscribe(context.Background(), filter)
+		result := make(chan []StreamItem, 1)
+		go func() { result <- collectStream(t, stream) }()
+		return result
+	}
+	phasesResult := collect(StreamFilter{
+		EventTypes: []pb.EventType{pb.EventType_EVENT_TYPE_PHASE_STARTED},
+		EventsOnly: true,
+	})
+	dayResult := collect(StreamFilter{Phases: []pb.PhaseType{pb.PhaseType_PHASE_TYPE_DAY}})
+	wolfChatResult := collect(StreamFilter{PlayerID: "wolf1", Phases: []pb.PhaseType{pb.PhaseType_PHASE_TYPE_NIGHT_WOLF}})
+
+	engine.Start()
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	engine.SendMessage("wolf1", "kill v1?")
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+	engine.SendMessage("v1", "good morning")
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_VOTE)
+	for _, id := range []string{"v1", "v2", "v3"} {
+		engine.SubmitSkillUse(&SkillUse{PlayerID: id, Skill: pb.SkillType_SKILL_TYPE_VOTE, TargetID: "wolf1"})
+	}
+	engine.EndPhase()
+	if !engine.IsGameOver() {
+		t.Fatal("expected game over after voting out the only wolf")
+	}
+	phases, day, wolfChat := <-phasesResult, <-dayResult, <-wolfChatResult
+
+	if len(phases) == 0 {
+		t.Error("expected PHASE_STARTED events")
+	}
+	for _, item := range phases {
+		if item.Message != nil || item.Event.GetType() != pb.EventType_EVENT_TYPE_PHASE_STARTED {
+			t.Errorf("expected only PHASE_STARTED events, got %+v", item)
+		}
+	}
+
+	var sawDayMessage, sawDayStart bool
+	for _, item := range day {
+		switch {
+		case item.Message != nil:
+			sawDayMessage = item.Message.Content == "good morning"
+		case item.Event.GetPhaseType() != pb.PhaseType_PHASE_TYPE_DAY:
+			t.Errorf("expected only DAY events, got %v", item.Event)
+		case item.Event.GetType() == pb.EventType_EVENT_TYPE_PHASE_STARTED:
+			sawDayStart = true
+		}
+	}
+	if !sawDayMessage || !sawDayStart {
+		t.Errorf("expected DAY phase start and message, got message=%v start=%v", sawDayMessage, sawDayStart)
+	}
+
+	var wolfMessages int
+	for _, item := range wolfChat {
+		if item.Message != nil {
+			wolfMessages++
+			if item.Message.Content != "kill v1?" {
+				t.Errorf("expected only the wolf chat, got %q", item.Message.Content)
+			}
+		}
+	}
+	if wolfMessages != 1 {
+		t.Errorf("expected 1 wolf message, got %d", wolfMessages)
+	}
+}
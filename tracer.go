@@ -0,0 +1,221 @@
+package werewolf
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ==================== 链路追踪 ====================
+//
+// 引擎通过 Tracer 打开三层 span（OpenTelemetry 风格，可以用适配器接到 OTel SDK）：
+//
+//	game                      Start 到游戏结束；属性 game_id、player_count，结束时 winner、reason、round
+//	└── phase                 进入阶段到结束阶段（每个子步骤一个）；属性 phase、round、sub_step，
+//	    │                     期间的提交/修改/撤回记为 span 事件，结束时 skill_count、effect_count
+//	    └── resolve           一次 Resolver.Resolve 调用；属性 resolver、skills（提交的技能）、
+//	                          effects（产生的效果）、canceled（被取消的效果及原因）
+//
+// span 在引擎锁内打开和结束，Tracer 实现应当快速返回，不要回调引擎。
+
+// Tracer 链路追踪接口
+type Tracer interface {
+	// StartSpan 打开 span，parent 为 nil 时是根 span
+	StartSpan(name string, parent Span, attrs ...Field) Span
+}
+
+// Span 一段被追踪的操作
+type Span interface {
+	// SetAttributes 设置属性（同名属性覆盖）
+	SetAttributes(attrs ...Field)
+	// AddEvent 记录 span 内的时间点事件
+	AddEvent(name string, attrs ...Field)
+	// End 结束 span，之后的调用被忽略
+	End()
+}
+
+// NopTracer 空追踪实现（默认）
+type NopTracer struct{}
+
+// NewNopTracer 创建空追踪
+func NewNopTracer() *NopTracer {
+	return &NopTracer{}
+}
+
+func (t *NopTracer) StartSpan(name string, parent Span, attrs ...Field) Span { return nopSpan{} }
+
+// nopSpan 空 span
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(attrs ...Field)         {}
+func (nopSpan) AddEvent(name string, attrs ...Field) {}
+func (nopSpan) End()                                 {}
+
+// SpanRecord MemoryTracer 记录的 span
+type SpanRecord struct {
+	ID         int // 从 1 开始，按打开顺序递增
+	ParentID   int // 0 表示根 span
+	Name       string
+	Attributes map[string]interface{}
+	Events     []SpanEvent
+	Start      time.Time
+	End        time.Time // 未结束时为零值
+}
+
+// Ended span 是否已结束
+func (r SpanRecord) Ended() bool {
+	return !r.End.IsZero()
+}
+
+// Duration span 持续时间（未结束时为 0）
+func (r SpanRecord) Duration() time.Duration {
+	if !r.Ended() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// SpanEvent span 内的时间点事件
+type SpanEvent struct {
+	Name       string
+	Attributes map[string]interface{}
+	Time       time.Time
+}
+
+// MemoryTracer 内存追踪实现（测试和调试用，并发安全）
+type MemoryTracer struct {
+	mu    sync.Mutex
+	spans []*SpanRecord
+}
+
+// NewMemoryTracer 创建内存追踪
+func NewMemoryTracer() *MemoryTracer {
+	return &MemoryTracer{}
+}
+
+// StartSpan 实现 Tracer；parent 不是本追踪打开的 span 时视为根 span
+func (t *MemoryTracer) StartSpan(name string, parent Span, attrs ...Field) Span {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record := &SpanRecord{
+		ID:         len(t.spans) + 1,
+		Name:       name,
+		Attributes: make(map[string]interface{}, len(attrs)),
+		Start:      time.Now(),
+	}
+	if p, ok := parent.(*memorySpan); ok && p.tracer == t {
+		record.ParentID = p.record.ID
+	}
+	setAttributes(record.Attributes, attrs)
+	t.spans = append(t.spans, record)
+	return &memorySpan{tracer: t, record: record}
+}
+
+// Spans 按打开顺序返回所有 span 的副本
+func (t *MemoryTracer) Spans() []SpanRecord {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]SpanRecord, len(t.spans))
+	for i, record := range t.spans {
+		result[i] = copySpanRecord(record)
+	}
+	return result
+}
+
+// Find 按名称筛选 span（按打开顺序）
+func (t *MemoryTracer) Find(name string) []SpanRecord {
+	var result []SpanRecord
+	for _, record := range t.Spans() {
+		if record.Name == name {
+			result = append(result, record)
+		}
+	}
+	return result
+}
+
+// Reset 清空记录
+func (t *MemoryTracer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = nil
+}
+
+// memorySpan MemoryTracer 打开的 span
+type memorySpan struct {
+	tracer *MemoryTracer
+	record *SpanRecord
+}
+
+func (s *memorySpan) SetAttributes(attrs ...Field) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	if !s.record.Ended() {
+		setAttributes(s.record.Attributes, attrs)
+	}
+}
+
+func (s *memorySpan) AddEvent(name string, attrs ...Field) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	if !s.record.Ended() {
+		event := SpanEvent{Name: name, Attributes: make(map[string]interface{}, len(attrs)), Time: time.Now()}
+		setAttributes(event.Attributes, attrs)
+		s.record.Events = append(s.record.Events, event)
+	}
+}
+
+func (s *memorySpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	if !s.record.Ended() {
+		s.record.End = time.Now()
+	}
+}
+
+// setAttributes 写入属性
+func setAttributes(dst map[string]interface{}, attrs []Field) {
+	for _, attr := range attrs {
+		dst[attr.Key] = attr.Value
+	}
+}
+
+// copySpanRecord 复制 span 记录（属性和事件不与追踪共享）
+func copySpanRecord(record *SpanRecord) SpanRecord {
+	result := *record
+	result.Attributes = make(map[string]interface{}, len(record.Attributes))
+	for k, v := range record.Attributes {
+		result.Attributes[k] = v
+	}
+	result.Events = make([]SpanEvent, len(record.Events))
+	for i, event := range record.Events {
+		result.Events[i] = event
+		result.Events[i].Attributes = make(map[string]interface{}, len(event.Attributes))
+		for k, v := range event.Attributes {
+			result.Events[i].Attributes[k] = v
+		}
+	}
+	return result
+}
+
+// ==================== 追踪属性 ====================
+
+// resolverAttribute 解析器的属性值（类型名），如 "*werewolf.WolfResolver"
+func resolverAttribute(resolver Resolver) string {
+	return fmt.Sprintf("%T", resolver)
+}
+
+// skillUseAttribute 技能使用的属性值，如 "wolf1:SKILL_TYPE_KILL->v1"
+func skillUseAttribute(use *SkillUse) string {
+	return fmt.Sprintf("%s:%s->%s", use.PlayerID, use.Skill, use.TargetID)
+}
+
+// effectAttribute 效果的属性值，如 "EVENT_TYPE_KILL wolf1->v1"，被取消时附带原因
+func effectAttribute(effect *Effect) string {
+	s := fmt.Sprintf("%s %s->%s", effect.Type, effect.SourceID, effect.TargetID)
+	if effect.Canceled {
+		s += " (" + effect.Reason + ")"
+	}
+	return s
+}
@@ -0,0 +1,124 @@
+package werewolf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+func TestMemoryTracer(t *testing.T) {
+	tracer := NewMemoryTracer()
+	root := tracer.StartSpan("root", nil, F("a", 1))
+	child := tracer.StartSpan("child", root)
+	child.AddEvent("tick", F("n", 1))
+	child.End()
+	child.SetAttributes(F("ignored", true)) // 结束后的调用被忽略
+	root.SetAttributes(F("a", 2))
+
+	spans := tracer.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %v", spans)
+	}
+	if spans[0].ParentID != 0 || spans[0].Attributes["a"] != 2 || spans[0].Ended() {
+		t.Errorf("expected open root span with overwritten attribute, got %+v", spans[0])
+	}
+	if spans[1].ParentID != spans[0].ID || !spans[1].Ended() || len(spans[1].Events) != 1 || spans[1].Attributes["ignored"] != nil {
+		t.Errorf("expected ended child span with one event, got %+v", spans[1])
+	}
+
+	// 返回的是副本
+	spans[0].Attributes["a"] = 3
+	if got := tracer.Find("root"); got[0].Attributes["a"] != 2 {
+		t.Errorf("expected Spans to copy attributes, got %v", got[0].Attributes)
+	}
+
+	// 其他追踪打开的 span 不作为父 span
+	other := NewMemoryTracer().StartSpan("other", nil)
+	if span := tracer.StartSpan("orphan", other); span == nil || tracer.Find("orphan")[0].ParentID != 0 {
+		t.Errorf("expected foreign parent to be ignored")
+	}
+
+	tracer.Reset()
+	if len(tracer.Spans()) != 0 {
+		t.Error("expected Reset to clear spans")
+	}
+}
+
+func TestEngine_Tracer(t *testing.T) {
+	tracer := NewMemoryTracer()
+	engine := newTestEngine(t, nil)
+	engine.SetTracer(tracer)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	submit := func(player string, skill pb.SkillType, target string) {
+		t.Helper()
+		if err := engine.SubmitSkillUse(&SkillUse{PlayerID: player, Skill: skill, TargetID: target}); err != nil {
+			t.Fatalf("%s %v failed: %v", player, skill, err)
+		}
+	}
+
+	// 狼人改刀 v1，女巫对不在刀口上的 v2 用解药（被取消），白天投出狼人
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	submit("wolf1", pb.SkillType_SKILL_TYPE_KILL, "v2")
+	submit("wolf1", pb.SkillType_SKILL_TYPE_KILL, "v1")
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WITCH)
+	// 解药目标在提交时就会被拒绝，这里绕过校验模拟解析时才被取消的技能
+	engine.pendingUses = append(engine.pendingUses, &SkillUse{PlayerID: "witch", Skill: pb.SkillType_SKILL_TYPE_ANTIDOTE, TargetID: "v2"})
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_VOTE)
+	submit("witch", pb.SkillType_SKILL_TYPE_VOTE, "wolf1")
+	submit("v2", pb.SkillType_SKILL_TYPE_VOTE, "wolf1")
+	engine.EndPhase()
+	if !engine.IsGameOver() {
+		t.Fatal("expected game to end")
+	}
+
+	games := tracer.Find("game")
+	if len(games) != 1 || !games[0].Ended() || games[0].Attributes["winner"] != pb.Camp_CAMP_GOOD.String() || games[0].Attributes["player_count"] != 4 {
+		t.Fatalf("expected one ended game span won by GOOD, got %+v", games)
+	}
+
+	phases := make(map[int]SpanRecord)
+	var wolfPhase SpanRecord
+	for _, span := range tracer.Find("phase") {
+		if span.ParentID != games[0].ID || !span.Ended() {
+			t.Errorf("expected ended phase span under game span, got %+v", span)
+		}
+		phases[span.ID] = span
+		if span.Attributes["phase"] == pb.PhaseType_PHASE_TYPE_NIGHT_WOLF.String() {
+			wolfPhase = span
+		}
+	}
+	var eventNames []string
+	for _, event := range wolfPhase.Events {
+		eventNames = append(eventNames, event.Name)
+	}
+	if !reflect.DeepEqual(eventNames, []string{"skill submitted", "skill changed"}) || wolfPhase.Attributes["skill_count"] != 1 {
+		t.Errorf("expected submit and change events on wolf phase span, got %+v", wolfPhase)
+	}
+
+	resolves := make(map[string]SpanRecord)
+	for _, span := range tracer.Find("resolve") {
+		if _, ok := phases[span.ParentID]; !ok {
+			t.Errorf("expected resolve span under a phase span, got %+v", span)
+		}
+		resolves[span.Attributes["resolver"].(string)] = span
+	}
+	wolf := resolves["*werewolf.WolfResolver"]
+	if !reflect.DeepEqual(wolf.Attributes["skills"], []string{"wolf1:SKILL_TYPE_KILL->v1"}) {
+		t.Errorf("expected wolf resolve span to list the final kill, got %v", wolf.Attributes)
+	}
+	witch := resolves["*werewolf.WitchResolver"]
+	canceled, _ := witch.Attributes["canceled"].([]string)
+	if len(canceled) != 1 || !strings.Contains(canceled[0], "target is not dying") {
+		t.Errorf("expected canceled antidote on witch resolve span, got %v", witch.Attributes)
+	}
+	if effects, _ := resolves["*werewolf.VoteResolver"].Attributes["effects"].([]string); len(effects) == 0 {
+		t.Errorf("expected vote resolve span to list produced effects, got %v", resolves["*werewolf.VoteResolver"].Attributes)
+	}
+}
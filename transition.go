@@ -0,0 +1,75 @@
+package werewolf
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// 内置转换条件名称
+const (
+	ConditionHunterTriggered = "hunter_triggered" // 本回合有猎人死亡且可以开枪
+	ConditionFirstRound      = "first_round"      // 第一回合（如首夜遗言）
+)
+
+// TransitionCondition 条件转换的判断函数
+// 在阶段结算之后、进入下一阶段之前调用，调用时引擎持有锁，只应读取状态，不能调用 Engine 方法
+type TransitionCondition func(state *State) bool
+
+// Transition 条件转换
+// 阶段结束时按 PhaseConfig.Transitions 的顺序检查，第一个满足条件的转换生效，都不满足时进入 NextPhase
+type Transition struct {
+	When   string       // 条件名称（内置条件或通过 RegisterCondition 注册的条件）
+	Target pb.PhaseType // 条件满足时进入的阶段
+}
+
+var (
+	conditionsMu sync.RWMutex
+	conditions   = map[string]TransitionCondition{
+		ConditionHunterTriggered: func(state *State) bool {
+			return state.RoundCtx != nil && state.RoundCtx.HunterTriggered
+		},
+		ConditionFirstRound: func(state *State) bool {
+			return state.Round == 1
+		},
+	}
+)
+
+// RegisterCondition 注册转换条件，注册后即可在阶段配置（包括配置文件）中按名称引用
+// 名称为空、条件为 nil 或名称重复时 panic（与 database/sql.Register 一致，应在 init 中调用）
+func RegisterCondition(name string, condition TransitionCondition) {
+	conditionsMu.Lock()
+	defer conditionsMu.Unlock()
+
+	if name == "" || condition == nil {
+		panic("werewolf: RegisterCondition requires a name and a condition")
+	}
+	if _, dup := conditions[name]; dup {
+		panic(fmt.Sprintf("werewolf: RegisterCondition called twice for %q", name))
+	}
+	conditions[name] = condition
+}
+
+// LookupCondition 按名称查找转换条件
+func LookupCondition(name string) (TransitionCondition, bool) {
+	conditionsMu.RLock()
+	defer conditionsMu.RUnlock()
+
+	condition, ok := conditions[name]
+	return condition, ok
+}
+
+// ConditionNames 已注册的转换条件名称（排序）
+func ConditionNames() []string {
+	conditionsMu.RLock()
+	defer conditionsMu.RUnlock()
+
+	names := make([]string, 0, len(conditions))
+	for name := range conditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
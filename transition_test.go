@@ -0,0 +1,143 @@
+package werewolf
+
+import (
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// advancePhase 推进子阶段直到进入目标阶段
+func advancePhase(t *testing.T, engine *Engine, target pb.PhaseType) {
+	t.Helper()
+	for i := 0; engine.GetCurrentPhase() != target; i++ {
+		if i > 20 {
+			t.Fatalf("did not reach %v, stuck at %v", target, engine.GetCurrentPhase())
+		}
+		if _, err := engine.EndSubStep(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+}
+
+// registerTestCondition 注册测试条件（重复运行测试时跳过已注册的条件）
+func registerTestCondition(name string, condition TransitionCondition) {
+	if _, ok := LookupCondition(name); !ok {
+		RegisterCondition(name, condition)
+	}
+}
+
+func TestTransition_HunterKilledAtNight(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("hunter", pb.RoleType_ROLE_TYPE_HUNTER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_WOLF)
+	if err := engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf1", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "hunter"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE)
+
+	engine.EndSubStep()
+	if phase := engine.GetCurrentPhase(); phase != pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER {
+		t.Errorf("expected hunter_triggered to lead to NIGHT_HUNTER, got %v", phase)
+	}
+}
+
+func TestTransition_CustomConfig(t *testing.T) {
+	// 首日不投票：第一回合白天结束后直接进入夜晚
+	config := DefaultGameConfig()
+	config.Phases[pb.PhaseType_PHASE_TYPE_DAY].Transitions = []Transition{
+		{When: ConditionFirstRound, Target: pb.PhaseType_PHASE_TYPE_NIGHT_GUARD},
+	}
+
+	engine := newTestEngine(t, config)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v3", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+	engine.EndSubStep()
+	if phase, round := engine.GetCurrentPhase(), engine.GetCurrentRound(); phase != pb.PhaseType_PHASE_TYPE_NIGHT_GUARD || round != 2 {
+		t.Fatalf("expected first day to skip vote, got %v in round %d", phase, round)
+	}
+
+	advancePhase(t, engine, pb.PhaseType_PHASE_TYPE_DAY)
+	engine.EndSubStep()
+	if phase := engine.GetCurrentPhase(); phase != pb.PhaseType_PHASE_TYPE_VOTE {
+		t.Errorf("expected vote on second day, got %v", phase)
+	}
+
+	// EndPhase 只使用声明式 NextPhase
+	engine2 := newTestEngine(t, config)
+	engine2.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine2.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine2.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine2.Start()
+	advancePhase(t, engine2, pb.PhaseType_PHASE_TYPE_DAY)
+	engine2.EndPhase()
+	if phase := engine2.GetCurrentPhase(); phase != pb.PhaseType_PHASE_TYPE_VOTE {
+		t.Errorf("expected EndPhase to follow NextPhase, got %v", phase)
+	}
+}
+
+func TestTransition_FirstMatchWins(t *testing.T) {
+	registerTestCondition("test_always", func(*State) bool { return true })
+
+	config := DefaultGameConfig()
+	config.Phases[pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE].Transitions = []Transition{
+		{When: ConditionFirstRound, Target: pb.PhaseType_PHASE_TYPE_VOTE},
+		{When: "test_always", Target: pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER},
+	}
+	phase := NewPhase(config)
+	state := NewState()
+
+	state.Round = 1
+	if next := phase.NextPhaseFor(pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE, state); next != pb.PhaseType_PHASE_TYPE_VOTE {
+		t.Errorf("expected first matching transition, got %v", next)
+	}
+	state.Round = 2
+	if next := phase.NextPhaseFor(pb.PhaseType_PHASE_TYPE_NIGHT_RESOLVE, state); next != pb.PhaseType_PHASE_TYPE_NIGHT_HUNTER {
+		t.Errorf("expected second transition, got %v", next)
+	}
+	if next := phase.NextPhaseFor(pb.PhaseType_PHASE_TYPE_DAY, state); next != pb.PhaseType_PHASE_TYPE_VOTE {
+		t.Errorf("expected NextPhase without transitions, got %v", next)
+	}
+}
+
+func TestRegisterCondition(t *testing.T) {
+	if _, ok := LookupCondition(ConditionHunterTriggered); !ok {
+		t.Error("expected built-in condition to be registered")
+	}
+	if _, ok := LookupCondition("missing"); ok {
+		t.Error("expected unknown condition not to be found")
+	}
+
+	registerTestCondition("test_registered", func(*State) bool { return false })
+	found := false
+	for _, name := range ConditionNames() {
+		found = found || name == "test_registered"
+	}
+	if !found {
+		t.Errorf("expected test_registered in %v", ConditionNames())
+	}
+
+	for _, register := range []func(){
+		func() { RegisterCondition("test_registered", func(*State) bool { return true }) },
+		func() { RegisterCondition("", func(*State) bool { return true }) },
+		func() { RegisterCondition("test_nil", nil) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			register()
+		}()
+	}
+}
@@ -0,0 +1,133 @@
+package werewolf
+
+import (
+	"sort"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+// DefaultCamp 获取角色的默认阵营（由角色定义声明，未注册的角色返回 UNSPECIFIED）
+func DefaultCamp(role pb.RoleType) pb.Camp {
+	if def, ok := LookupRole(role); ok {
+		return def.Camp
+	}
+	return pb.Camp_CAMP_UNSPECIFIED
+}
+
+// ==================== 迷雾过滤 ====================
+
+// IsEventVisibleTo 判断事件对指定玩家是否可见（迷雾过滤）
+// 用于服务端按玩家推送事件，保证每个玩家只收到自己有权知道的信息
+func (e *Engine) IsEventVisibleTo(event *pb.Event, playerID string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.hasPlayerLocked(playerID) {
+		return false
+	}
+	return eventVisibleTo(event, playerID)
+}
+
+// eventVisibleTo 事件可见性规则
+//   - 私密技能结果（保护、救人、查验）只有施放者可见
+//   - 被取消的女巫毒药（带来源）只有女巫可见
+//   - 夜晚结算的死亡（刀杀、无来源的毒杀）带有死因，只在上帝视角可见，玩家从夜晚总结得知死亡名单
+//   - 指定了受众（AudienceIds，如狼人实时票型）的事件只有受众可见
+//   - 夜晚总结、出局、开枪、大厅变更、游戏生命周期等事件公开
+func eventVisibleTo(event *pb.Event, playerID string) bool {
+	if len(event.AudienceIds) > 0 {
+		for _, id := range event.AudienceIds {
+			if id == playerID {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch event.Type {
+	case pb.EventType_EVENT_TYPE_PROTECT,
+		pb.EventType_EVENT_TYPE_SAVE,
+		pb.EventType_EVENT_TYPE_CHECK:
+		return event.SourceId == playerID
+	case pb.EventType_EVENT_TYPE_POISON:
+		return event.SourceId != "" && event.SourceId == playerID
+	case pb.EventType_EVENT_TYPE_KILL:
+		return false
+	default:
+		return true
+	}
+}
+
+// ==================== 玩家视角 ====================
+
+// GetPlayerView 获取玩家视角（迷雾过滤后的游戏状态）
+// 其他玩家的身份只对狼人队友或游戏结束后可见
+func (e *Engine) GetPlayerView(playerID string) (*pb.PlayerView, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	self, ok := e.state.GetPlayerInfo(playerID)
+	if !ok {
+		return nil, ErrPlayerNotFound
+	}
+
+	gameOver := e.state.Phase == pb.PhaseType_PHASE_TYPE_END
+	view := &pb.PlayerView{
+		PlayerId:      playerID,
+		Seat:          int32(self.Seat),
+		Phase:         e.state.Phase,
+		Round:         int32(e.state.Round),
+		Role:          self.Role,
+		Camp:          self.Camp,
+		Alive:         self.Alive,
+		AllowedSkills: e.allowedSkillsFor(self),
+		GameOver:      gameOver,
+		Knowledge:     e.state.GetKnowledge(playerID),
+	}
+
+	if self.Role == pb.RoleType_ROLE_TYPE_WITCH {
+		view.HasAntidote = self.HasAntidote
+		view.HasPoison = self.HasPoison
+		if self.Alive && e.state.Phase == pb.PhaseType_PHASE_TYPE_NIGHT_WITCH {
+			view.NightKillTarget = e.state.RoundCtx.KillTarget
+		}
+	}
+
+	teammates := make(map[string]bool)
+	if self.Role == pb.RoleType_ROLE_TYPE_WEREWOLF {
+		view.WolfTally = e.wolfTallyLocked(playerID)
+		view.Teammates = e.state.GetWolfTeammates(playerID)
+		sort.Strings(view.Teammates)
+		for _, id := range view.Teammates {
+			teammates[id] = true
+		}
+	}
+
+	for _, id := range e.state.getPlayerIDs() {
+		info, _ := e.state.GetPlayerInfo(id)
+		summary := &pb.PlayerSummary{Id: id, Seat: int32(info.Seat), Alive: info.Alive}
+		if id == playerID || teammates[id] || gameOver {
+			summary.Role = info.Role
+			summary.Camp = info.Camp
+		}
+		view.Players = append(view.Players, summary)
+	}
+
+	return view, nil
+}
+
+// allowedSkillsFor 获取玩家当前可用技能（调用前需持有锁）
+// 与 GetAllowedSkills 不同，死亡玩家按角色定义的 CanActWhenDead 判断（如被触发的猎人仍可开枪）
+func (e *Engine) allowedSkillsFor(player PlayerInfo) []pb.SkillType {
+	if e.state.Phase == pb.PhaseType_PHASE_TYPE_END || !canAct(player, e.state) {
+		return nil
+	}
+
+	var skills []pb.SkillType
+	for _, skill := range e.phase.GetAllowedSkills(e.state.Phase, player.Role) {
+		if skill != pb.SkillType_SKILL_TYPE_ANNOUNCE {
+			skills = append(skills, skill)
+		}
+	}
+	return skills
+}
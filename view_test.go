@@ -0,0 +1,146 @@
+package werewolf
+
+import (
+	"testing"
+
+	pb "github.com/Zereker/werewolf/proto"
+)
+
+func TestDefaultCamp(t *testing.T) {
+	if DefaultCamp(pb.RoleType_ROLE_TYPE_WEREWOLF) != pb.Camp_CAMP_EVIL {
+		t.Error("expected werewolf to be EVIL")
+	}
+	for _, role := range []pb.RoleType{
+		pb.RoleType_ROLE_TYPE_SEER,
+		pb.RoleType_ROLE_TYPE_WITCH,
+		pb.RoleType_ROLE_TYPE_HUNTER,
+		pb.RoleType_ROLE_TYPE_GUARD,
+		pb.RoleType_ROLE_TYPE_VILLAGER,
+	} {
+		if DefaultCamp(role) != pb.Camp_CAMP_GOOD {
+			t.Errorf("expected %v to be GOOD", role)
+		}
+	}
+	if DefaultCamp(pb.RoleType_ROLE_TYPE_GOD) != pb.Camp_CAMP_UNSPECIFIED {
+		t.Error("expected GOD to have no camp")
+	}
+}
+
+func TestEngine_IsEventVisibleTo(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("seer", pb.RoleType_ROLE_TYPE_SEER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+
+	tests := []struct {
+		name    string
+		event   *pb.Event
+		player  string
+		visible bool
+	}{
+		{"check to seer", &pb.Event{Type: pb.EventType_EVENT_TYPE_CHECK, SourceId: "seer", TargetId: "v1"}, "seer", true},
+		{"check to target", &pb.Event{Type: pb.EventType_EVENT_TYPE_CHECK, SourceId: "seer", TargetId: "v1"}, "v1", false},
+		{"save to other", &pb.Event{Type: pb.EventType_EVENT_TYPE_SAVE, SourceId: "witch", TargetId: "v1"}, "v1", false},
+		{"canceled poison to witch", &pb.Event{Type: pb.EventType_EVENT_TYPE_POISON, SourceId: "witch", TargetId: "v1"}, "witch", true},
+		{"canceled poison to other", &pb.Event{Type: pb.EventType_EVENT_TYPE_POISON, SourceId: "witch", TargetId: "v1"}, "seer", false},
+		{"poison death is hidden", &pb.Event{Type: pb.EventType_EVENT_TYPE_POISON, TargetId: "v1"}, "witch", false},
+		{"kill is hidden", &pb.Event{Type: pb.EventType_EVENT_TYPE_KILL, TargetId: "v1"}, "v1", false},
+		{"night summary is public", &pb.Event{Type: pb.EventType_EVENT_TYPE_NIGHT_SUMMARY}, "seer", true},
+		{"unknown player", &pb.Event{Type: pb.EventType_EVENT_TYPE_NIGHT_SUMMARY}, "ghost", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.IsEventVisibleTo(tt.event, tt.player); got != tt.visible {
+				t.Errorf("expected visible=%v, got %v", tt.visible, got)
+			}
+		})
+	}
+}
+
+func TestEngine_GetPlayerView_Wolf(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf1", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("wolf2", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("seer", pb.RoleType_ROLE_TYPE_SEER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+	engine.EndSubStep() // NIGHT_GUARD -> NIGHT_WOLF
+
+	view, err := engine.GetPlayerView("wolf1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if view.Role != pb.RoleType_ROLE_TYPE_WEREWOLF {
+		t.Errorf("expected Role=WEREWOLF, got %v", view.Role)
+	}
+	if len(view.Teammates) != 1 || view.Teammates[0] != "wolf2" {
+		t.Errorf("expected teammates [wolf2], got %v", view.Teammates)
+	}
+	if len(view.AllowedSkills) != 1 || view.AllowedSkills[0] != pb.SkillType_SKILL_TYPE_KILL {
+		t.Errorf("expected allowed skills [KILL], got %v", view.AllowedSkills)
+	}
+
+	for _, p := range view.Players {
+		switch p.Id {
+		case "wolf1", "wolf2":
+			if p.Role != pb.RoleType_ROLE_TYPE_WEREWOLF {
+				t.Errorf("expected %s role visible to wolf, got %v", p.Id, p.Role)
+			}
+		default:
+			if p.Role != pb.RoleType_ROLE_TYPE_UNSPECIFIED {
+				t.Errorf("expected %s role hidden, got %v", p.Id, p.Role)
+			}
+		}
+	}
+}
+
+func TestEngine_GetPlayerView_WitchSeesKillTarget(t *testing.T) {
+	engine := newTestEngine(t, nil)
+	engine.AddPlayer("wolf", pb.RoleType_ROLE_TYPE_WEREWOLF, pb.Camp_CAMP_EVIL)
+	engine.AddPlayer("witch", pb.RoleType_ROLE_TYPE_WITCH, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v1", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.AddPlayer("v2", pb.RoleType_ROLE_TYPE_VILLAGER, pb.Camp_CAMP_GOOD)
+	engine.Start()
+	engine.EndSubStep() // NIGHT_GUARD -> NIGHT_WOLF
+	engine.SubmitSkillUse(&SkillUse{PlayerID: "wolf", Skill: pb.SkillType_SKILL_TYPE_KILL, TargetID: "v1"})
+	engine.EndSubStep() // NIGHT_WOLF -> NIGHT_WITCH
+
+	witchView, _ := engine.GetPlayerView("witch")
+	if witchView.NightKillTarget != "v1" {
+		t.Errorf("expected witch to see kill target v1, got %q", witchView.NightKillTarget)
+	}
+	if !witchView.HasAntidote || !witchView.HasPoison {
+		t.Error("expected witch to have both potions")
+	}
+
+	villagerView, _ := engine.GetPlayerView("v2")
+	if villagerView.NightKillTarget != "" {
+		t.Errorf("expected villager not to see kill target, got %q", villagerView.NightKillTarget)
+	}
+	if len(villagerView.AllowedSkills) != 0 {
+		t.Errorf("expected no skills for villager at night, got %v", villagerView.AllowedSkills)
+	}
+}
+
+func TestEngine_GetPlayerView_NotFound(t *testing.T) {
+	engine := newTestEngine(t, nil)
+
+	_, err := engine.GetPlayerView("ghost")
+	if err != ErrPlayerNotFound {
+		t.Errorf("expected ErrPlayerNotFound, got %v", err)
+	}
+}
+
+func TestMessage_ToProto(t *testing.T) {
+	msg := &Message{SenderID: "p1", Content: "hello", Phase: pb.PhaseType_PHASE_TYPE_DAY, Round: 2}
+
+	m := msg.ToProto([]string{"p1", "p2"})
+	if m.SenderId != "p1" || m.Content != "hello" || m.Round != 2 {
+		t.Errorf("unexpected proto message: %v", m)
+	}
+	if len(m.ReceiverIds) != 2 {
+		t.Errorf("expected 2 receivers, got %v", m.ReceiverIds)
+	}
+}